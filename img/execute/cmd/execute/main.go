@@ -46,7 +46,7 @@ func runExecute() error {
 	if err != nil {
 		return err
 	}
-	mv, err := ui.NewMainView(g, dbms, c.SelectedItem, c.AttemptingToInject)
+	mv, err := ui.NewMainView(g, dbms, cfg.Location(), c.SelectedItem, c.AttemptingToInject)
 	if err != nil {
 		return err
 	}
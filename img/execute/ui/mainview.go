@@ -3,7 +3,6 @@ package ui
 import (
 	"context"
 	"fmt"
-	"math/rand"
 	"os/exec"
 	"sort"
 	"strconv"
@@ -12,6 +11,7 @@ import (
 
 	"github.com/jroimartin/gocui"
 	"github.com/ulmenhaus/env/img/jql/api"
+	"github.com/ulmenhaus/env/img/jql/types"
 	"github.com/ulmenhaus/env/lib/go/timedb"
 	"github.com/ulmenhaus/env/proto/jql/jqlpb"
 )
@@ -53,6 +53,7 @@ var (
 type MainView struct {
 	dbms   api.JQL_DBMS
 	tables map[string]*jqlpb.TableMeta
+	loc    *time.Location // the time zone of "today"
 
 	MainViewMode MainViewMode
 	TaskViewMode TaskViewMode
@@ -151,27 +152,22 @@ type habitPlacementMeta struct {
 	dayPlanOrder int
 }
 
-const alphanumChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
-
-func randPK() string {
-	b := make([]byte, 16)
-	for i := range b {
-		b[i] = alphanumChars[rand.Intn(len(alphanumChars))]
-	}
-	return string(b)
-}
-
 // NewMainView returns a MainView initialized with a given Table
-func NewMainView(g *gocui.Gui, dbms api.JQL_DBMS, preselectTask string, injectMatchingTasks bool) (*MainView, error) {
-	rand.Seed(time.Now().UnixNano())
+func NewMainView(g *gocui.Gui, dbms api.JQL_DBMS, loc *time.Location, preselectTask string, injectMatchingTasks bool) (*MainView, error) {
 	mv := &MainView{
 		dbms:                dbms,
+		loc:                 loc,
 		preselectTask:       preselectTask,
 		injectMatchingTasks: injectMatchingTasks,
 	}
 	return mv, mv.load(g)
 }
 
+// now returns the current time in the time zone of "today"
+func (mv *MainView) now() time.Time {
+	return time.Now().In(mv.loc)
+}
+
 func (mv *MainView) load(g *gocui.Gui) error {
 	mv.MainViewMode = MainViewModeListBar
 	mv.tasks = map[string]([]*jqlpb.Row){}
@@ -288,10 +284,8 @@ func (mv *MainView) createNewReminder(g *gocui.Gui, taskPK, checkText string) er
 	dayPlanPK := dayPlan.Entries[api.GetPrimary(tasksTable.Columns)].Formatted
 
 	// Write .Check assertion on the task
-	checkPK := randPK()
 	_, err = mv.dbms.WriteRow(ctx, &jqlpb.WriteRowRequest{
 		Table:      timedb.TableAssertions,
-		Pk:         checkPK,
 		InsertOnly: true,
 		Fields: map[string]string{
 			timedb.FieldRelation: ".Check",
@@ -310,7 +304,7 @@ func (mv *MainView) createNewReminder(g *gocui.Gui, taskPK, checkText string) er
 	}
 	mv.newReminderInsertAfterPK = ""
 
-	todayStr := time.Now().Format("2006-01-02")
+	todayStr := mv.now().Format("2006-01-02")
 	if err = mv.createReminderEntity(dayPlanPK, taskPK, checkText, todayStr, insertOrder); err != nil {
 		return err
 	}
@@ -403,8 +397,6 @@ func (mv *MainView) createNewPlan(g *gocui.Gui, taskPK, description string) erro
 		}
 	}
 
-	// pk doesn't really matter here so using a random integer
-	pk := randPK()
 	fields := map[string]string{
 		timedb.FieldArg0:      fmt.Sprintf("tasks %s", taskPK),
 		timedb.FieldArg1:      fmt.Sprintf("[ ] %s", description),
@@ -413,7 +405,6 @@ func (mv *MainView) createNewPlan(g *gocui.Gui, taskPK, description string) erro
 	}
 	_, err = mv.dbms.WriteRow(ctx, &jqlpb.WriteRowRequest{
 		Table:  timedb.TableAssertions,
-		Pk:     pk,
 		Fields: fields,
 	})
 	if err != nil {
@@ -510,10 +501,8 @@ func (mv *MainView) insertDayPlan(g *gocui.Gui, description string, delta int) e
 		timedb.FieldRelation: ".Do timedb.Today",
 		timedb.FieldOrder:     fmt.Sprintf("%d", dayOrder+1),
 	}
-	pk := randPK()
 	_, err = mv.dbms.WriteRow(ctx, &jqlpb.WriteRowRequest{
 		Table:      timedb.TableAssertions,
-		Pk:         pk,
 		Fields:     fields,
 		InsertOnly: true,
 	})
@@ -534,7 +523,7 @@ func (mv *MainView) syncPKs(table string, updated []string) error {
 			Table:            table,
 			PrimarySelection: pk,
 		}
-		_, err := api.RunMacro(ctx, mv.dbms, "jql-timedb-setpk --v2", view, true)
+		_, err := api.RunMacro(ctx, mv.dbms, "jql-timedb-setpk --v2", view, api.MacroV2)
 		if err != nil {
 			return err
 		}
@@ -1069,7 +1058,7 @@ func (mv *MainView) runProcedure(g *gocui.Gui, v *gocui.View) error {
 		Table:            timedb.TableTasks,
 		PrimarySelection: pk,
 	}
-	_, err = api.RunMacro(ctx, mv.dbms, "jql-timedb-run-procedure", view, true)
+	_, err = api.RunMacro(ctx, mv.dbms, "jql-timedb-run-procedure", view, api.MacroV2)
 	if err != nil {
 		return err
 	}
@@ -1123,7 +1112,7 @@ func (mv *MainView) logTime(g *gocui.Gui, v *gocui.View) error {
 		if err != nil {
 			return err
 		}
-	} else if mv.log[0].Entries[api.IndexOfField(logTable.Columns, timedb.FieldEnd)].Formatted == "31 Dec 1969 16:00:00" {
+	} else if endIndex := api.IndexOfField(logTable.Columns, timedb.FieldEnd); api.IsUnsetTime(mv.log[0].Entries[endIndex].Formatted, logTable.Columns[endIndex]) {
 		pk := mv.log[0].Entries[api.IndexOfField(logTable.Columns, timedb.FieldLogDescription)].Formatted
 		_, err := mv.dbms.WriteRow(ctx, &jqlpb.WriteRowRequest{
 			UpdateOnly: true,
@@ -1883,8 +1872,6 @@ func (mv *MainView) copyOldTasks() error {
 		if isDayTaskDone(val) {
 			continue
 		}
-		// pk doesn't really matter here so using a random integer
-		pk := randPK()
 		fields := map[string]string{
 			timedb.FieldArg0:      fmt.Sprintf("tasks %s", todayPK),
 			timedb.FieldArg1:      val,
@@ -1893,7 +1880,6 @@ func (mv *MainView) copyOldTasks() error {
 		}
 		_, err := mv.dbms.WriteRow(ctx, &jqlpb.WriteRowRequest{
 			Table:  timedb.TableAssertions,
-			Pk:     pk,
 			Fields: fields,
 		})
 		if err != nil {
@@ -2121,10 +2107,8 @@ func (mv *MainView) placeRemindersAtPosition(
 	}
 	nextOrder := insertAfterOrder + 1
 	for _, bareID := range filteredExisting {
-		newPK := randPK()
 		_, err = mv.dbms.WriteRow(ctx, &jqlpb.WriteRowRequest{
 			Table:      timedb.TableAssertions,
-			Pk:         newPK,
 			InsertOnly: true,
 			Fields: map[string]string{
 				timedb.FieldRelation: ".Entry",
@@ -2295,10 +2279,8 @@ func (mv *MainView) addDueRemindersToDay(dayPlanPK string, entries []dayPlanEntr
 		return err
 	}
 	for i, bareID := range toAdd {
-		newPK := randPK()
 		_, err := mv.dbms.WriteRow(ctx, &jqlpb.WriteRowRequest{
 			Table:      timedb.TableAssertions,
-			Pk:         newPK,
 			InsertOnly: true,
 			Fields: map[string]string{
 				timedb.FieldRelation: ".Entry",
@@ -2351,7 +2333,7 @@ func (mv *MainView) insertNewReminders() error {
 		}
 	}
 
-	todayStr := time.Now().Format("2006-01-02")
+	todayStr := mv.now().Format("2006-01-02")
 
 	candidates, err := mv.buildCandidatesForTasks(allPKs, activePKs)
 	if err != nil {
@@ -2657,7 +2639,7 @@ func (mv *MainView) resolveReminderPlacements(candidates []reminderToPlace) (toC
 			reminderTargetDate[arg0] = dateStr
 		}
 	}
-	todayStr := time.Now().Format("2006-01-02")
+	todayStr := mv.now().Format("2006-01-02")
 	existingSet := map[string]bool{}
 	for _, c := range candidates {
 		key := c.taskPK + "\x00" + c.checkText
@@ -2681,7 +2663,13 @@ func (mv *MainView) resolveReminderPlacements(candidates []reminderToPlace) (toC
 // createReminder creates the assertion cluster for a new reminder and returns its bare ID.
 // It does NOT add the reminder to any day plan; use createReminderEntity for that.
 func (mv *MainView) createReminder(taskPK, checkText, targetDate string) (string, error) {
-	bareID := randPK()
+	// Reminders are virtual so there's no table to generate their IDs from but we
+	// use the same strategy as the assertions that make them up
+	id, err := types.NewID(nil, map[string]interface{}{"strategy": "ulid"})
+	if err != nil {
+		return "", err
+	}
+	bareID := id.Format("")
 	reminderRef := fmt.Sprintf("vt.reminders %s", bareID)
 	assns := []map[string]string{
 		{timedb.FieldRelation: ".Status", timedb.FieldArg0: reminderRef, timedb.FieldArg1: "Awaiting"},
@@ -2692,10 +2680,8 @@ func (mv *MainView) createReminder(taskPK, checkText, targetDate string) (string
 		assns = append(assns, map[string]string{timedb.FieldRelation: ".Check", timedb.FieldArg0: reminderRef, timedb.FieldArg1: checkText})
 	}
 	for _, fields := range assns {
-		pk := randPK()
 		_, err := mv.dbms.WriteRow(ctx, &jqlpb.WriteRowRequest{
 			Table:      timedb.TableAssertions,
-			Pk:         pk,
 			InsertOnly: true,
 			Fields:     fields,
 		})
@@ -2711,10 +2697,8 @@ func (mv *MainView) createReminderEntity(dayPlanPK, taskPK, checkText, targetDat
 	if err != nil {
 		return err
 	}
-	entryPK := randPK()
 	_, err = mv.dbms.WriteRow(ctx, &jqlpb.WriteRowRequest{
 		Table:      timedb.TableAssertions,
-		Pk:         entryPK,
 		InsertOnly: true,
 		Fields: map[string]string{
 			timedb.FieldRelation: ".Entry",
@@ -2739,7 +2723,10 @@ func (mv *MainView) maybeMarkPreviousDayPlanSatisfied() error {
 	if err != nil {
 		return nil // unparseable date — skip rather than error
 	}
-	today := time.Now().Truncate(24 * time.Hour)
+	// formatted dates are calendar days so compare against today's calendar day
+	// in the configured time zone rather than truncating to a UTC day boundary
+	now := mv.now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
 	if !startDate.Before(today) {
 		return nil
 	}
@@ -2757,7 +2744,7 @@ func (mv *MainView) refreshTasks(g *gocui.Gui, v *gocui.View) error {
 	if err := mv.maybeMarkPreviousDayPlanSatisfied(); err != nil {
 		return err
 	}
-	_, err := api.RunMacro(ctx, mv.dbms, "jql-timedb-autofill --v2", api.MacroCurrentView{}, true)
+	_, err := api.RunMacro(ctx, mv.dbms, "jql-timedb-autofill --v2", api.MacroCurrentView{}, api.MacroV2)
 	if err != nil {
 		return err
 	}
@@ -2849,10 +2836,8 @@ func (mv *MainView) carryForwardEntries() error {
 				continue
 			}
 		}
-		newPK := randPK()
 		_, err := mv.dbms.WriteRow(ctx, &jqlpb.WriteRowRequest{
 			Table:      timedb.TableAssertions,
-			Pk:         newPK,
 			InsertOnly: true,
 			Fields: map[string]string{
 				timedb.FieldRelation: ".Entry",
@@ -2910,11 +2895,9 @@ func (mv *MainView) markTask(g *gocui.Gui, v *gocui.View, status string) error {
 			Fields:     map[string]string{timedb.FieldArg1: reminderStatus},
 		})
 	} else {
-		pk := randPK()
 		_, err = mv.dbms.WriteRow(ctx, &jqlpb.WriteRowRequest{
 			InsertOnly: true,
 			Table:      timedb.TableAssertions,
-			Pk:         pk,
 			Fields: map[string]string{
 				timedb.FieldRelation: ".Status",
 				timedb.FieldArg0:      fmt.Sprintf("vt.reminders %s", item.ReminderArg0),
@@ -3126,7 +3109,7 @@ func (mv *MainView) InjectTaskWithAllMatching(g *gocui.Gui, v *gocui.View, match
 		return 0, err
 	}
 	dayPlanPK := dayPlan.Entries[api.GetPrimary(tasksTable.Columns)].Formatted
-	todayStr := time.Now().Format("2006-01-02")
+	todayStr := mv.now().Format("2006-01-02")
 
 	entries, err := mv.queryDayPlanEntries(dayPlanPK)
 	if err != nil {
@@ -3441,7 +3424,7 @@ func (mv *MainView) wrapTaskInRamps(g *gocui.Gui, v *gocui.View) error {
 			Table:            timedb.TableTasks,
 			PrimarySelection: "",
 		}
-		_, err = api.RunMacro(ctx, mv.dbms, "jql-timedb-setpk --v2", view, true)
+		_, err = api.RunMacro(ctx, mv.dbms, "jql-timedb-setpk --v2", view, api.MacroV2)
 		if err != nil {
 			return err
 		}
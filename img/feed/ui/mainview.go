@@ -51,6 +51,7 @@ const (
 type MainView struct {
 	dbms   api.JQL_DBMS
 	tables map[string]*jqlpb.TableMeta
+	loc    *time.Location // the time zone of "today"
 
 	Mode      MainViewMode
 	DomainSet MainViewDomains
@@ -82,9 +83,10 @@ type channel struct {
 }
 
 // NewMainView returns a MainView initialized with a given Table
-func NewMainView(g *gocui.Gui, dbms api.JQL_DBMS, ignoredPath string, returnArgs []string, selected string) (*MainView, error) {
+func NewMainView(g *gocui.Gui, dbms api.JQL_DBMS, loc *time.Location, ignoredPath string, returnArgs []string, selected string) (*MainView, error) {
 	mv := &MainView{
 		dbms: dbms,
+		loc:  loc,
 
 		ignoredPath: ignoredPath,
 		returnArgs:  returnArgs,
@@ -957,7 +959,7 @@ func (mv *MainView) moveUp(g *gocui.Gui, v *gocui.View) error {
 	}
 	if status == timedb.StatusIdea {
 		arg0 := api.ConstructPolyForeign(mv.renderTable(timedb.TableNouns), pk)
-		arg1 := time.Now().Format("2006-01-02")
+		arg1 := time.Now().In(mv.loc).Format("2006-01-02")
 		order := "0000"
 		_, err = mv.dbms.WriteRow(ctx, &jqlpb.WriteRowRequest{
 			Table: mv.renderTable(timedb.TableAssertions),
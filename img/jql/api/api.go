@@ -5,7 +5,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ulmenhaus/env/img/jql/osm"
@@ -21,6 +23,18 @@ type JQL_DBMS interface {
 type LocalDBMS struct {
 	OSM  *osm.ObjectStoreMapper
 	path string
+	feed *mutationFeed
+
+	// mu guards the tables of the database. Triggers are fired after
+	// it's released since they may write back to the database.
+	mu sync.RWMutex
+
+	// CommandTriggers enables triggers that run external commands. They're
+	// disabled by default since they run in the process serving requests.
+	CommandTriggers bool
+	// ExactTables disables looking up tables by prefix so that clients
+	// can only reach the tables they've been authorized for
+	ExactTables bool
 }
 
 func NewLocalDBMS(mapper *osm.ObjectStoreMapper, path string) (*LocalDBMS, error) {
@@ -28,18 +42,22 @@ func NewLocalDBMS(mapper *osm.ObjectStoreMapper, path string) (*LocalDBMS, error
 		OSM: mapper,
 
 		path: path,
+		feed: newMutationFeed(),
 	}, nil
 }
 
 // findTable takes in a user-provided table name and returns
 // either that table if it's an exact match for a table, or
-// the first table to match the provided prefix, or an error if no
-// table matches
+// the first table to match the provided prefix unless lookups
+// are exact, or an error if no table matches
 func (s *LocalDBMS) findTable(t string) (string, *types.Table, error) {
 	table, ok := s.OSM.GetDB().Tables[t]
 	if ok {
 		return t, table, nil
 	}
+	if s.ExactTables {
+		return "", nil, fmt.Errorf("table does not exist: %s", t)
+	}
 	for name, table := range s.OSM.GetDB().Tables {
 		if strings.HasPrefix(name, t) {
 			return name, table, nil
@@ -49,6 +67,8 @@ func (s *LocalDBMS) findTable(t string) (string, *types.Table, error) {
 }
 
 func (s *LocalDBMS) ListTables(ctx context.Context, in *jqlpb.ListTablesRequest, opts ...grpc.CallOption) (*jqlpb.ListTablesResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	var tables []*jqlpb.TableMeta
 
 	for name, table := range s.OSM.GetDB().Tables {
@@ -70,6 +90,8 @@ func (s *LocalDBMS) ListRows(ctx context.Context, in *jqlpb.ListRowsRequest, opt
 	if len(in.Conditions) > 1 {
 		return nil, errors.New("lisiting with multiple conditions is not yet implemented")
 	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	name, table, err := s.findTable(in.GetTable())
 	if err != nil {
 		return nil, err
@@ -77,7 +99,10 @@ func (s *LocalDBMS) ListRows(ctx context.Context, in *jqlpb.ListRowsRequest, opt
 	var filters []types.Filter
 	if len(in.Conditions) == 1 {
 		for _, filter := range in.Conditions[0].Requires {
-			shim := newFilterShim(filter, table)
+			shim, err := newFilterShim(filter, table)
+			if err != nil {
+				return nil, err
+			}
 			filters = append(filters, shim)
 		}
 	}
@@ -111,9 +136,7 @@ func (s *LocalDBMS) ListRows(ctx context.Context, in *jqlpb.ListRowsRequest, opt
 	for _, row := range resp.Entries {
 		var entries []*jqlpb.Entry
 		for _, entry := range row {
-			entries = append(entries, &jqlpb.Entry{
-				Formatted: entry.Format(""),
-			})
+			entries = append(entries, newResponseEntry(entry))
 		}
 		rows = append(rows, &jqlpb.Row{
 			Entries: entries,
@@ -130,42 +153,67 @@ func (s *LocalDBMS) ListRows(ctx context.Context, in *jqlpb.ListRowsRequest, opt
 }
 
 func (s *LocalDBMS) WriteRow(ctx context.Context, in *jqlpb.WriteRowRequest, opts ...grpc.CallOption) (*jqlpb.WriteRowResponse, error) {
+	s.mu.Lock()
+	name, table, pk, old, err := s.writeRow(in)
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	s.fireTriggers(ctx, name, table, pk, old)
+	return &jqlpb.WriteRowResponse{Pk: pk}, nil
+}
+
+// writeRow writes the row and returns its table, final pk, and fields before
+// the write. The caller must hold the write lock.
+func (s *LocalDBMS) writeRow(in *jqlpb.WriteRowRequest) (string, *types.Table, string, map[string]string, error) {
 	// NOTE the default behavior is an upsert with explicit fields to enforce inserting/updating
 	// that are not implemented
-	_, table, err := s.findTable(in.GetTable())
+	name, table, err := s.findTable(in.GetTable())
 	if err != nil {
-		return nil, err
+		return "", nil, "", nil, err
+	}
+	if err := s.checkTriggers(table); err != nil {
+		return "", nil, "", nil, err
+	}
+	pk := in.GetPk()
+	old := rowFields(table, pk)
+	// watchers are sent both the original and final pk so that renames
+	// are mirrored as a deletion and an insertion
+	defer func() { s.publishRows(name, in.GetPk(), pk) }()
+	// Tables keyed by an ID can have the pk generated for them
+	if pk == "" && !in.GetUpdateOnly() {
+		if table.ColumnMeta[table.Columns[table.Primary()]].Type != jqlpb.EntryType_ID {
+			return "", nil, "", nil, fmt.Errorf("a pk is required for %s since its primary column isn't an id", name)
+		}
+		pk, err = table.GenerateID(table.Columns[table.Primary()])
+		if err != nil {
+			return "", nil, "", nil, err
+		}
 	}
 	if in.GetUpdateOnly() {
-		s.OSM.RowUpdating(in.GetTable(), in.GetPk())
-		// Take two passes here, one for updating non-pk fields
-		// and one for updating the pk. If the pk is updated before other
-		// fields, subsequent updates can't work
-		for key, value := range in.GetFields() {
-			if table.Primary() == table.IndexOfField(key) {
-				continue
-			}
-			if err := table.Update(in.GetPk(), key, value); err != nil {
-				return nil, err
-			}
+		s.OSM.RowUpdating(in.GetTable(), pk)
+		newPK, err := table.UpdateFields(pk, in.GetFields())
+		if newPK != pk {
+			s.OSM.RowUpdating(in.GetTable(), newPK)
+			pk = newPK
 		}
-		for key, value := range in.GetFields() {
-			if table.Primary() != table.IndexOfField(key) {
-				continue
-			}
-			if err := table.Update(in.GetPk(), key, value); err != nil {
-				return nil, err
-			}
-			s.OSM.RowUpdating(in.GetTable(), value)
+		if err != nil {
+			return "", nil, "", nil, err
 		}
 	} else {
-		table.InsertWithFields(in.GetPk(), in.GetFields())
-		s.OSM.RowUpdating(in.GetTable(), in.GetPk())
+		// an upsert of an existing row is a no-op
+		if err := table.InsertWithFields(pk, in.GetFields()); err != nil && old == nil {
+			return "", nil, "", nil, err
+		}
+		s.OSM.RowUpdating(in.GetTable(), pk)
+		s.OSM.IDsGenerated(name)
 	}
-	return &jqlpb.WriteRowResponse{}, nil
+	return name, table, pk, old, nil
 }
 
 func (s *LocalDBMS) GetRow(ctx context.Context, in *jqlpb.GetRowRequest, opts ...grpc.CallOption) (*jqlpb.GetRowResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	name, table, err := s.findTable(in.GetTable())
 	if err != nil {
 		return nil, err
@@ -176,9 +224,7 @@ func (s *LocalDBMS) GetRow(ctx context.Context, in *jqlpb.GetRowRequest, opts ..
 	}
 	var entries []*jqlpb.Entry
 	for _, entry := range row {
-		entries = append(entries, &jqlpb.Entry{
-			Formatted: entry.Format(""),
-		})
+		entries = append(entries, newResponseEntry(entry))
 	}
 	columns, err := s.generateResponseColumns(table)
 	if err != nil {
@@ -193,6 +239,17 @@ func (s *LocalDBMS) GetRow(ctx context.Context, in *jqlpb.GetRowRequest, opts ..
 	}, nil
 }
 
+// newResponseEntry converts a table entry to its API representation
+func newResponseEntry(entry types.Entry) *jqlpb.Entry {
+	resp := &jqlpb.Entry{
+		Formatted: entry.Format(""),
+	}
+	if link, ok := entry.(types.URL); ok {
+		resp.Link = string(link)
+	}
+	return resp
+}
+
 func (s *LocalDBMS) generateResponseColumns(table *types.Table) ([]*jqlpb.Column, error) {
 	var columns []*jqlpb.Column
 	for i, colname := range table.Columns {
@@ -201,20 +258,20 @@ func (s *LocalDBMS) generateResponseColumns(table *types.Table) ([]*jqlpb.Column
 			return nil, fmt.Errorf("could not find metadata for column: %s", colname)
 		}
 		col := &jqlpb.Column{
-			Name:         colname,
-			Type:         meta.Type,
-			MaxLength:    int32(meta.MaxLength),
-			Primary:      table.Primary() == i,
-			ForeignTable: meta.ForeignTable,
+			Name:          colname,
+			Type:          meta.Type,
+			MaxLength:     int32(meta.MaxLength),
+			Primary:       table.Primary() == i,
+			ForeignTable:  meta.ForeignTable,
+			ForeignTables: meta.ForeignTables,
+			Values:        meta.Values,
 		}
-		// TODO not a great interface. It'd be better to have the enum values as a field on the column metadata
-		// Instead we pick a row if it exists and try to get the values from it
-		for _, row := range table.Entries {
-			enum, ok := row[i].(types.Enum)
-			if ok {
-				col.Values = enum.Values()
+		if meta.Type == jqlpb.EntryType_DATE || meta.Type == jqlpb.EntryType_TIME {
+			loc, err := types.ColumnLocation(table.Features(colname))
+			if err != nil {
+				return nil, err
 			}
-			break
+			col.Timezone = loc.String()
 		}
 		columns = append(columns, col)
 	}
@@ -222,27 +279,67 @@ func (s *LocalDBMS) generateResponseColumns(table *types.Table) ([]*jqlpb.Column
 }
 
 func (s *LocalDBMS) DeleteRow(ctx context.Context, in *jqlpb.DeleteRowRequest, opts ...grpc.CallOption) (*jqlpb.DeleteRowResponse, error) {
-	_, table, err := s.findTable(in.GetTable())
+	s.mu.Lock()
+	name, table, old, err := s.deleteRow(in)
+	s.mu.Unlock()
 	if err != nil {
 		return nil, err
 	}
+	s.fireTriggers(ctx, name, table, in.GetPk(), old)
+	return &jqlpb.DeleteRowResponse{}, nil
+}
+
+// deleteRow deletes the row and returns its table and fields before the
+// deletion. The caller must hold the write lock.
+func (s *LocalDBMS) deleteRow(in *jqlpb.DeleteRowRequest) (string, *types.Table, map[string]string, error) {
+	name, table, err := s.findTable(in.GetTable())
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if err := s.checkTriggers(table); err != nil {
+		return "", nil, nil, err
+	}
 	s.OSM.RowUpdating(in.GetTable(), in.GetPk())
-	return &jqlpb.DeleteRowResponse{}, table.Delete(in.GetPk())
+	defer s.publishRows(name, in.GetPk())
+	old := rowFields(table, in.GetPk())
+	err = table.Delete(in.GetPk())
+	if err != nil {
+		return "", nil, nil, err
+	}
+	return name, table, old, nil
 }
 
 func (s *LocalDBMS) IncrementEntry(ctx context.Context, in *jqlpb.IncrementEntryRequest, opts ...grpc.CallOption) (*jqlpb.IncrementEntryResponse, error) {
-	_, table, err := s.findTable(in.GetTable())
+	s.mu.Lock()
+	name, table, old, err := s.incrementEntry(in)
+	s.mu.Unlock()
 	if err != nil {
 		return nil, err
 	}
+	s.fireTriggers(ctx, name, table, in.GetPk(), old)
+	return &jqlpb.IncrementEntryResponse{}, nil
+}
+
+// incrementEntry increments the entry and returns its table and the fields of
+// its row before the increment. The caller must hold the write lock.
+func (s *LocalDBMS) incrementEntry(in *jqlpb.IncrementEntryRequest) (string, *types.Table, map[string]string, error) {
+	name, table, err := s.findTable(in.GetTable())
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if err := s.checkTriggers(table); err != nil {
+		return "", nil, nil, err
+	}
 	row, ok := table.Entries[in.GetPk()]
 	if !ok {
-		return nil, fmt.Errorf("no such pk '%s' in table '%s'", in.GetPk(), in.GetTable())
+		return "", nil, nil, fmt.Errorf("no such pk '%s' in table '%s'", in.GetPk(), in.GetTable())
 	}
 	s.OSM.RowUpdating(in.GetTable(), in.GetPk())
+	defer s.publishRows(name, in.GetPk())
+	old := rowFields(table, in.GetPk())
 	colix := table.IndexOfField(in.GetColumn())
 	if colix == -1 {
-		return nil, fmt.Errorf("no such column '%s' in table '%s'", in.GetColumn(), in.GetTable())
+		return "", nil, nil, fmt.Errorf("no such column '%s' in table '%s'", in.GetColumn(), in.GetTable())
 	}
 	entry := row[colix]
 	// TODO leaky abstraction
@@ -254,7 +351,7 @@ func (s *LocalDBMS) IncrementEntry(ctx context.Context, in *jqlpb.IncrementEntry
 			OrderBy: ftable.Columns[ftable.Primary()],
 		})
 		if err != nil {
-			return nil, err
+			return "", nil, nil, err
 		}
 		index := map[string]int{}
 		for i, fentry := range fresp.Entries {
@@ -263,24 +360,36 @@ func (s *LocalDBMS) IncrementEntry(ctx context.Context, in *jqlpb.IncrementEntry
 		next := (index[entry.Format("")] + 1) % len(fresp.Entries)
 		err = table.Update(in.GetPk(), in.GetColumn(), fresp.Entries[next][ftable.Primary()].Format(""))
 		if err != nil {
-			return nil, err
+			return "", nil, nil, err
 		}
 	default:
 		// TODO should use an Update so table can modify any necessary internals
 		new, err := entry.Add(int(in.Amount))
 		if err != nil {
-			return nil, err
+			return "", nil, nil, err
+		}
+		err = table.CheckConstraints(in.GetPk(), in.GetColumn(), new)
+		if err != nil {
+			return "", nil, nil, err
 		}
 		row[colix] = new
+		err = table.Touch(in.GetPk(), in.GetColumn())
+		if err != nil {
+			return "", nil, nil, err
+		}
 	}
-	return &jqlpb.IncrementEntryResponse{}, nil
+	return name, table, old, nil
 }
 
 func (s *LocalDBMS) Persist(ctx context.Context, r *jqlpb.PersistRequest, opts ...grpc.CallOption) (*jqlpb.PersistResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	return &jqlpb.PersistResponse{}, s.OSM.StoreEntries()
 }
 
 func (s *LocalDBMS) GetSnapshot(ctx context.Context, r *jqlpb.GetSnapshotRequest, opts ...grpc.CallOption) (*jqlpb.GetSnapshotResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	snapshot, err := s.OSM.GetSnapshot(s.OSM.GetDB())
 	if err != nil {
 		return nil, err
@@ -291,6 +400,8 @@ func (s *LocalDBMS) GetSnapshot(ctx context.Context, r *jqlpb.GetSnapshotRequest
 }
 
 func (s *LocalDBMS) LoadSnapshot(ctx context.Context, r *jqlpb.LoadSnapshotRequest, opts ...grpc.CallOption) (*jqlpb.LoadSnapshotResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	var err error
 	// We mark all keys as updated both before and after loading the snapshot. This is because any keys which no longer
 	// exist after the load should be marked for purging and any new keys should be marked for writing.
@@ -302,9 +413,46 @@ func (s *LocalDBMS) LoadSnapshot(ctx context.Context, r *jqlpb.LoadSnapshotReque
 	if err != nil {
 		return nil, err
 	}
+	s.publishResync()
 	return &jqlpb.LoadSnapshotResponse{}, nil
 }
 
+func (s *LocalDBMS) MigrateEnum(ctx context.Context, r *jqlpb.MigrateEnumRequest, opts ...grpc.CallOption) (*jqlpb.MigrateEnumResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	name, _, err := s.findTable(r.GetTable())
+	if err != nil {
+		return nil, err
+	}
+	migrated, err := s.OSM.MigrateEnum(name, r.GetColumn(), r.GetValues(), r.GetRenames())
+	if err != nil {
+		return nil, err
+	}
+	s.publishResync()
+	return &jqlpb.MigrateEnumResponse{Migrated: uint32(migrated)}, nil
+}
+
+func (s *LocalDBMS) Stats(ctx context.Context, r *jqlpb.StatsRequest, opts ...grpc.CallOption) (*jqlpb.StatsResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	dirty := s.OSM.DirtyRows()
+	resp := &jqlpb.StatsResponse{}
+	for name, table := range s.OSM.GetDB().Tables {
+		resp.Tables = append(resp.Tables, &jqlpb.TableStats{
+			Name:      name,
+			Rows:      uint32(len(table.Entries)),
+			DirtyRows: uint32(dirty[name]),
+		})
+	}
+	slices.SortFunc(resp.Tables, func(a, b *jqlpb.TableStats) int { return strings.Compare(a.Name, b.Name) })
+	lastStored, duration := s.OSM.LastStore()
+	if !lastStored.IsZero() {
+		resp.LastPersistUnixMs = lastStored.UnixMilli()
+		resp.LastPersistDurationMs = float64(duration) / float64(time.Millisecond)
+	}
+	return resp, nil
+}
+
 func (s *LocalDBMS) calculateGroupings(in *jqlpb.ListRowsRequest, table *types.Table, filters []types.Filter) ([]*jqlpb.Grouping, []types.Filter, error) {
 	if in.GroupBy == nil {
 		return nil, nil, nil
@@ -334,10 +482,14 @@ func (s *LocalDBMS) calculateGroupings(in *jqlpb.ListRowsRequest, table *types.T
 			Values:   values,
 			Selected: requestedGrouping.Selected,
 		})
-		additionalFilters = append(additionalFilters, newFilterShim(&jqlpb.Filter{
+		shim, err := newFilterShim(&jqlpb.Filter{
 			Column: requestedGrouping.Field,
 			Match:  &jqlpb.Filter_EqualMatch{EqualMatch: &jqlpb.EqualMatch{Value: requestedGrouping.Selected}},
-		}, table))
+		}, table)
+		if err != nil {
+			return nil, nil, err
+		}
+		additionalFilters = append(additionalFilters, shim)
 		rows = filteredRows
 	}
 	return groupings, additionalFilters, nil
@@ -391,6 +543,30 @@ func (s *DBMSShim) LoadSnapshot(ctx context.Context, in *jqlpb.LoadSnapshotReque
 	return s.api.LoadSnapshot(ctx, in)
 }
 
+func (s *DBMSShim) MigrateEnum(ctx context.Context, in *jqlpb.MigrateEnumRequest) (*jqlpb.MigrateEnumResponse, error) {
+	return s.api.MigrateEnum(ctx, in)
+}
+
+func (s *DBMSShim) Stats(ctx context.Context, in *jqlpb.StatsRequest) (*jqlpb.StatsResponse, error) {
+	return s.api.Stats(ctx, in)
+}
+
+func (s *DBMSShim) WatchMutations(in *jqlpb.WatchMutationsRequest, srv grpc.ServerStreamingServer[jqlpb.Mutation]) error {
+	stream, err := s.api.WatchMutations(srv.Context(), in)
+	if err != nil {
+		return err
+	}
+	for {
+		mutation, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if err := srv.Send(mutation); err != nil {
+			return err
+		}
+	}
+}
+
 func IndexOfField(columns []*jqlpb.Column, fieldName string) int {
 	for i, col := range columns {
 		if col.GetName() == fieldName {
@@ -421,6 +597,18 @@ func GetForeign(columns []*jqlpb.Column, table string) []int {
 	return ret
 }
 
+// GetPolyforeign returns the index of the polyforeign columns that may
+// refer to the provided table
+func GetPolyforeign(columns []*jqlpb.Column, table string) []int {
+	var ret []int
+	for i, column := range columns {
+		if column.Type == jqlpb.EntryType_POLYFOREIGN && slices.Contains(column.ForeignTables, table) {
+			ret = append(ret, i)
+		}
+	}
+	return ret
+}
+
 func GetTables(ctx context.Context, dbms JQL_DBMS) (map[string]*jqlpb.TableMeta, error) {
 	tablesList, err := dbms.ListTables(ctx, &jqlpb.ListTablesRequest{})
 	if err != nil {
@@ -433,73 +621,9 @@ func GetTables(ctx context.Context, dbms JQL_DBMS) (map[string]*jqlpb.TableMeta,
 	return tables, nil
 }
 
-// Router is a layer on top of the LocalDBMS that provides gRPC handles for exposing the DBMS as a daemon
-type Router struct {
-	jqlpb.UnimplementedJQLServer
-	api            jqlpb.JQLServer
-	virtualGateway jqlpb.JQLServer
-}
-
-func NewRouter(api jqlpb.JQLServer, virtualGateway jqlpb.JQLServer) *Router {
-	return &Router{
-		api:            api,
-		virtualGateway: virtualGateway,
-	}
-}
-
-func (s *Router) ListTables(ctx context.Context, in *jqlpb.ListTablesRequest) (*jqlpb.ListTablesResponse, error) {
-	return s.api.ListTables(ctx, in)
-}
-
-func (s *Router) ListRows(ctx context.Context, in *jqlpb.ListRowsRequest) (*jqlpb.ListRowsResponse, error) {
-	if IsVirtualTable(in.Table) {
-		return s.virtualGateway.ListRows(ctx, in)
-	}
-	return s.api.ListRows(ctx, in)
-}
-
-func (s *Router) GetRow(ctx context.Context, in *jqlpb.GetRowRequest) (*jqlpb.GetRowResponse, error) {
-	if IsVirtualTable(in.Table) {
-		return s.virtualGateway.GetRow(ctx, in)
-	}
-	return s.api.GetRow(ctx, in)
-}
-
-func (s *Router) WriteRow(ctx context.Context, in *jqlpb.WriteRowRequest) (*jqlpb.WriteRowResponse, error) {
-	if IsVirtualTable(in.Table) {
-		return s.virtualGateway.WriteRow(ctx, in)
-	}
-	return s.api.WriteRow(ctx, in)
-}
-
-func (s *Router) DeleteRow(ctx context.Context, in *jqlpb.DeleteRowRequest) (*jqlpb.DeleteRowResponse, error) {
-	if IsVirtualTable(in.Table) {
-		return s.virtualGateway.DeleteRow(ctx, in)
-	}
-	return s.api.DeleteRow(ctx, in)
-}
-
-func (s *Router) IncrementEntry(ctx context.Context, in *jqlpb.IncrementEntryRequest) (*jqlpb.IncrementEntryResponse, error) {
-	if IsVirtualTable(in.Table) {
-		return s.virtualGateway.IncrementEntry(ctx, in)
-	}
-	return s.api.IncrementEntry(ctx, in)
-}
-
-func (s *Router) Persist(ctx context.Context, in *jqlpb.PersistRequest) (*jqlpb.PersistResponse, error) {
-	return s.api.Persist(ctx, in)
-}
-
-func (s *Router) GetSnapshot(ctx context.Context, in *jqlpb.GetSnapshotRequest) (*jqlpb.GetSnapshotResponse, error) {
-	return s.api.GetSnapshot(ctx, in)
-}
-
-func (s *Router) LoadSnapshot(ctx context.Context, in *jqlpb.LoadSnapshotRequest) (*jqlpb.LoadSnapshotResponse, error) {
-	return s.api.LoadSnapshot(ctx, in)
-}
-
+// IsVirtualTable returns true iff the table is served by a virtual gateway
 func IsVirtualTable(name string) bool {
-	return strings.HasPrefix(name, "vt.")
+	return types.IsVirtualTable(name)
 }
 
 func ConstructPolyForeign(table, pk string) string {
@@ -541,6 +665,17 @@ func ParseFormattedDate(s string) (time.Time, error) {
 	return time.Parse("02 Jan 2006", s)
 }
 
+// IsUnsetTime returns true iff s is the zero value of the time column as
+// formatted in "DD Mon YYYY HH:MM:SS" format. The zero value is the epoch
+// which is displayed in the time zone of the column.
+func IsUnsetTime(s string, column *jqlpb.Column) bool {
+	loc, err := time.LoadLocation(column.GetTimezone())
+	if err != nil {
+		return false
+	}
+	return s == time.Unix(0, 0).In(loc).Format("02 Jan 2006 15:04:05")
+}
+
 func ParseLink(entry *jqlpb.Entry) (string, []string) {
 	formatted := entry.Link
 
@@ -0,0 +1,376 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ulmenhaus/env/img/jql/osm"
+	"github.com/ulmenhaus/env/proto/jql/jqlpb"
+)
+
+const testSnapshot = `{
+	"_schemata": {
+		"tasks.Name": {"type": "string", "primary": true},
+		"tasks.Status": {"type": "enum", "features": {"values": "Pending, Active, Satisfied, Abandoned"}}
+	},
+	"tasks": {
+		"fix build": {"Status": "Active"},
+		"add filters": {"Status": "Satisfied"},
+		"plan week": {"Status": "Abandoned"}
+	}
+}`
+
+func newTestDBMS(t *testing.T, snapshot string) *LocalDBMS {
+	return newTestDBMSAt(t, "test.json", snapshot)
+}
+
+// newTestDBMSAt returns a database loaded from the snapshot that is stored at
+// the provided path
+func newTestDBMSAt(t *testing.T, path, snapshot string) *LocalDBMS {
+	mapper, err := osm.NewObjectStoreMapper(path)
+	require.NoError(t, err)
+	require.NoError(t, mapper.LoadSnapshot(strings.NewReader(snapshot)))
+	dbms, err := NewLocalDBMS(mapper, path)
+	require.NoError(t, err)
+	return dbms
+}
+
+func listColumn(t *testing.T, dbms *LocalDBMS, table, column string) (*jqlpb.Column, map[string]string) {
+	resp, err := dbms.ListRows(context.Background(), &jqlpb.ListRowsRequest{Table: table})
+	require.NoError(t, err)
+	colix := IndexOfField(resp.Columns, column)
+	primary := GetPrimary(resp.Columns)
+	values := map[string]string{}
+	for _, row := range resp.Rows {
+		values[row.Entries[primary].Formatted] = row.Entries[colix].Formatted
+	}
+	return resp.Columns[colix], values
+}
+
+func TestEnumValuesOnEmptyTable(t *testing.T) {
+	dbms := newTestDBMS(t, testSnapshot)
+	for _, row := range []string{"fix build", "add filters", "plan week"} {
+		_, err := dbms.DeleteRow(context.Background(), &jqlpb.DeleteRowRequest{Table: "tasks", Pk: row})
+		require.NoError(t, err)
+	}
+	col, _ := listColumn(t, dbms, "tasks", "Status")
+	require.Equal(t, []string{"Pending", "Active", "Satisfied", "Abandoned"}, col.Values)
+}
+
+func TestMigrateEnum(t *testing.T) {
+	cases := []struct {
+		name     string
+		values   []string
+		renames  map[string]string
+		expected map[string]string
+		migrated uint32
+		err      bool
+	}{
+		{
+			name:   "reorder",
+			values: []string{"Active", "Pending", "Satisfied", "Abandoned"},
+			expected: map[string]string{
+				"fix build":   "Active",
+				"add filters": "Satisfied",
+				"plan week":   "Abandoned",
+			},
+		},
+		{
+			name:    "rename",
+			values:  []string{"Pending", "Active", "Done", "Abandoned"},
+			renames: map[string]string{"Satisfied": "Done"},
+			expected: map[string]string{
+				"fix build":   "Active",
+				"add filters": "Done",
+				"plan week":   "Abandoned",
+			},
+			migrated: 1,
+		},
+		{
+			name:    "remove with replacement",
+			values:  []string{"Pending", "Active", "Satisfied"},
+			renames: map[string]string{"Abandoned": "Satisfied"},
+			expected: map[string]string{
+				"fix build":   "Active",
+				"add filters": "Satisfied",
+				"plan week":   "Satisfied",
+			},
+			migrated: 1,
+		},
+		{
+			name:   "remove value still in use",
+			values: []string{"Pending", "Active", "Satisfied"},
+			err:    true,
+		},
+		{
+			name:    "rename to value not in values",
+			values:  []string{"Pending", "Active", "Abandoned"},
+			renames: map[string]string{"Satisfied": "Done"},
+			err:     true,
+		},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			dbms := newTestDBMS(t, testSnapshot)
+			resp, err := dbms.MigrateEnum(context.Background(), &jqlpb.MigrateEnumRequest{
+				Table:   "tasks",
+				Column:  "Status",
+				Values:  tc.values,
+				Renames: tc.renames,
+			})
+			col, actual := listColumn(t, dbms, "tasks", "Status")
+			if tc.err {
+				require.Error(t, err)
+				// a failed migration leaves the table untouched
+				require.Equal(t, []string{"Pending", "Active", "Satisfied", "Abandoned"}, col.Values)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.migrated, resp.Migrated)
+			require.Equal(t, tc.values, col.Values)
+			require.Equal(t, tc.expected, actual)
+
+			// the schema is updated so the migrated values survive a reload
+			snapshot, err := dbms.GetSnapshot(context.Background(), &jqlpb.GetSnapshotRequest{})
+			require.NoError(t, err)
+			reloaded := newTestDBMS(t, string(snapshot.Snapshot))
+			_, actual = listColumn(t, reloaded, "tasks", "Status")
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestPolyforeignColumn(t *testing.T) {
+	dbms := newTestDBMS(t, `{
+		"_schemata": {
+			"tasks.Name": {"type": "string", "primary": true},
+			"nouns.Name": {"type": "string", "primary": true},
+			"assertions.Name": {"type": "string", "primary": true},
+			"assertions.Arg0": {"type": "polyforeign", "features": {"tables": ["nouns", "tasks"]}}
+		},
+		"tasks": {"fix build": {}},
+		"nouns": {},
+		"assertions": {"fix build has note": {"Arg0": "tasks fix build"}}
+	}`)
+	col, values := listColumn(t, dbms, "assertions", "Arg0")
+	require.Equal(t, jqlpb.EntryType_POLYFOREIGN, col.Type)
+	require.Equal(t, []string{"nouns", "tasks"}, col.ForeignTables)
+	require.Equal(t, map[string]string{"fix build has note": "tasks fix build"}, values)
+
+	_, err := dbms.WriteRow(context.Background(), &jqlpb.WriteRowRequest{
+		Table:      "assertions",
+		Pk:         "fix build has note",
+		Fields:     map[string]string{"Arg0": "assertions fix build has note"},
+		UpdateOnly: true,
+	})
+	require.Error(t, err)
+	_, err = dbms.WriteRow(context.Background(), &jqlpb.WriteRowRequest{
+		Table:      "assertions",
+		Pk:         "fix build has note",
+		Fields:     map[string]string{"Arg0": "vt.reminders 42"},
+		UpdateOnly: true,
+	})
+	require.Error(t, err)
+
+	mapper, err := osm.NewObjectStoreMapper("test.json")
+	require.NoError(t, err)
+	err = mapper.LoadSnapshot(strings.NewReader(`{
+		"_schemata": {
+			"assertions.Name": {"type": "string", "primary": true},
+			"assertions.Arg0": {"type": "polyforeign", "features": {"tables": ["nouns"]}}
+		},
+		"assertions": {}
+	}`))
+	require.Error(t, err)
+}
+
+func TestPolyforeignVirtualTables(t *testing.T) {
+	cases := []struct {
+		name   string
+		arg0   string
+		failed bool
+	}{
+		{
+			name: "unrestricted",
+			arg0: `{"type": "polyforeign"}`,
+		},
+		{
+			name: "declared",
+			arg0: `{"type": "polyforeign", "features": {"tables": ["tasks", "vt.reminders"]}}`,
+		},
+		{
+			name:   "not declared",
+			arg0:   `{"type": "polyforeign", "features": {"tables": ["tasks"]}}`,
+			failed: true,
+		},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			dbms := newTestDBMS(t, `{
+				"_schemata": {
+					"tasks.Name": {"type": "string", "primary": true},
+					"assertions.Name": {"type": "string", "primary": true},
+					"assertions.Arg0": `+tc.arg0+`
+				},
+				"tasks": {},
+				"assertions": {}
+			}`)
+			_, err := dbms.WriteRow(context.Background(), &jqlpb.WriteRowRequest{
+				Table:  "assertions",
+				Pk:     "reminder due",
+				Fields: map[string]string{"Arg0": "vt.reminders 42"},
+			})
+			if tc.failed {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			_, values := listColumn(t, dbms, "assertions", "Arg0")
+			require.Equal(t, "vt.reminders 42", values["reminder due"])
+		})
+	}
+}
+
+func TestWriteRowGeneratesPK(t *testing.T) {
+	dbms := newTestDBMS(t, `{
+		"_schemata": {
+			"assertions.ID": {"type": "id", "primary": true, "features": {"strategy": "sequence", "length": 3}},
+			"assertions.Relation": {"type": "string"}
+		},
+		"assertions": {"007": {"Relation": ".Do"}}
+	}`)
+	resp, err := dbms.WriteRow(context.Background(), &jqlpb.WriteRowRequest{
+		Table:  "assertions",
+		Fields: map[string]string{"Relation": ".Plan"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "008", resp.Pk)
+	_, values := listColumn(t, dbms, "assertions", "Relation")
+	require.Equal(t, map[string]string{"007": ".Do", "008": ".Plan"}, values)
+}
+
+func TestWriteRowDoesNotReuseSequence(t *testing.T) {
+	schemata := `{
+		"assertions.ID": {"type": "id", "primary": true, "features": {"strategy": "sequence", "length": 3}},
+		"assertions.Relation": {"type": "string"}
+	}`
+	assertions := `{"007": {"Relation": ".Do"}}`
+	// the files of each kind of database keyed by their path in the database
+	cases := map[string]map[string]string{
+		"db.json": {"": fmt.Sprintf(`{"_schemata": %s, "assertions": %s}`, schemata, assertions)},
+		"db.jql":  {"_schemata.json": schemata, "assertions.json": assertions},
+	}
+	for name, files := range cases {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			for file, contents := range files {
+				require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(path, file)), 0700))
+				require.NoError(t, os.WriteFile(filepath.Join(path, file), []byte(contents), 0600))
+			}
+			load := func() *LocalDBMS {
+				mapper, err := osm.NewObjectStoreMapper(path)
+				require.NoError(t, err)
+				require.NoError(t, mapper.Load())
+				dbms, err := NewLocalDBMS(mapper, path)
+				require.NoError(t, err)
+				return dbms
+			}
+			dbms := load()
+			ctx := context.Background()
+			resp, err := dbms.WriteRow(ctx, &jqlpb.WriteRowRequest{Table: "assertions", Fields: map[string]string{"Relation": ".Plan"}})
+			require.NoError(t, err)
+			require.Equal(t, "008", resp.Pk)
+			_, err = dbms.DeleteRow(ctx, &jqlpb.DeleteRowRequest{Table: "assertions", Pk: "008"})
+			require.NoError(t, err)
+			_, err = dbms.Persist(ctx, &jqlpb.PersistRequest{})
+			require.NoError(t, err)
+
+			// the sequence is stored with the schema so it survives a reload
+			resp, err = load().WriteRow(ctx, &jqlpb.WriteRowRequest{Table: "assertions", Fields: map[string]string{"Relation": ".Plan"}})
+			require.NoError(t, err)
+			require.Equal(t, "009", resp.Pk)
+		})
+	}
+}
+
+func TestWriteRowRequiresPK(t *testing.T) {
+	dbms := newTestDBMS(t, testSnapshot)
+	_, err := dbms.WriteRow(context.Background(), &jqlpb.WriteRowRequest{
+		Table:  "tasks",
+		Fields: map[string]string{"Status": "Pending"},
+	})
+	require.Error(t, err)
+	_, values := listColumn(t, dbms, "tasks", "Status")
+	require.Len(t, values, 3)
+}
+
+func TestWriteRowConstraints(t *testing.T) {
+	cases := []struct {
+		name    string
+		request *jqlpb.WriteRowRequest
+		err     bool
+	}{
+		{
+			name:    "valid insert",
+			request: &jqlpb.WriteRowRequest{Table: "tasks", Pk: "fix build", Fields: map[string]string{"Points": "3"}, InsertOnly: true},
+		},
+		{
+			name:    "insert below min",
+			request: &jqlpb.WriteRowRequest{Table: "tasks", Pk: "fix build", Fields: map[string]string{"Points": "0"}, InsertOnly: true},
+			err:     true,
+		},
+		{
+			name:    "update above max",
+			request: &jqlpb.WriteRowRequest{Table: "tasks", Pk: "fix tests", Fields: map[string]string{"Points": "21"}, UpdateOnly: true},
+			err:     true,
+		},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			dbms := newTestDBMS(t, `{
+				"_schemata": {
+					"tasks.Name": {"type": "string", "primary": true},
+					"tasks.Points": {"type": "int", "features": {"min": 1, "max": 13}}
+				},
+				"tasks": {"fix tests": {"Points": 5}}
+			}`)
+			_, err := dbms.WriteRow(context.Background(), tc.request)
+			_, points := listColumn(t, dbms, "tasks", "Points")
+			if tc.err {
+				require.Error(t, err)
+				require.Equal(t, map[string]string{"fix tests": "5"}, points)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.request.Fields["Points"], points[tc.request.Pk])
+		})
+	}
+}
+
+func TestIsUnsetTime(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		timezone string
+		expected bool
+	}{
+		{name: "utc epoch", input: "01 Jan 1970 00:00:00", expected: true},
+		{name: "pacific epoch", input: "31 Dec 1969 16:00:00", timezone: "America/Los_Angeles", expected: true},
+		{name: "pacific epoch in a utc column", input: "31 Dec 1969 16:00:00", expected: false},
+		{name: "utc epoch in a pacific column", input: "01 Jan 1970 00:00:00", timezone: "America/Los_Angeles", expected: false},
+		{name: "set time", input: "17 Oct 2026 09:30:00", expected: false},
+		{name: "not a time", input: "", expected: false},
+		{name: "unknown time zone", input: "01 Jan 1970 00:00:00", timezone: "Nowhere/Special", expected: false},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			column := &jqlpb.Column{Name: "End", Type: jqlpb.EntryType_TIME, Timezone: tc.timezone}
+			require.Equal(t, tc.expected, IsUnsetTime(tc.input, column))
+		})
+	}
+}
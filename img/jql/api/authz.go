@@ -0,0 +1,267 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ulmenhaus/env/proto/jql/jqlpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// AllTables is the table name in a role that grants access to every table
+	AllTables = "*"
+	// DefaultRole is the role granted to clients that match no principal
+	DefaultRole = "default"
+)
+
+// A Permission is the level of access a role has to a table
+type Permission string
+
+const (
+	PermissionNone      Permission = ""
+	PermissionRead      Permission = "r"
+	PermissionReadWrite Permission = "rw"
+)
+
+// allows returns true iff the permission grants the required permission
+func (p Permission) allows(required Permission) bool {
+	switch required {
+	case PermissionRead:
+		return p == PermissionRead || p == PermissionReadWrite
+	case PermissionReadWrite:
+		return p == PermissionReadWrite
+	}
+	return true
+}
+
+// A Role is a named set of per-table permissions
+type Role struct {
+	Tables map[string]Permission `json:"tables"`
+}
+
+// An AuthzPolicy maps clients to roles. Clients connecting over TLS are
+// identified by the subject of their certificate (either the full
+// distinguished name or just the common name) and clients connecting over a
+// Unix socket are identified by their UID. e.g.
+//
+//	{
+//	  "roles": {
+//	    "dashboard": {"tables": {"*": "r"}},
+//	    "automation": {"tables": {"*": "r", "tasks": "rw"}}
+//	  },
+//	  "subjects": {"CN=dashboard": ["dashboard"]},
+//	  "uids": {"1000": ["automation"]}
+//	}
+//
+// Clients that match neither are granted the "default" role if there is one
+// and are otherwise denied.
+type AuthzPolicy struct {
+	Roles    map[string]Role     `json:"roles"`
+	Subjects map[string][]string `json:"subjects"`
+	UIDs     map[string][]string `json:"uids"`
+}
+
+// LoadAuthzPolicy reads and validates the policy file at the given path
+func LoadAuthzPolicy(path string) (*AuthzPolicy, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	policy := &AuthzPolicy{}
+	err = json.Unmarshal(contents, policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse authz policy: %w", err)
+	}
+	return policy, policy.Validate()
+}
+
+// Validate checks that the policy only references defined roles and known
+// permissions
+func (p *AuthzPolicy) Validate() error {
+	for name, role := range p.Roles {
+		for table, perm := range role.Tables {
+			switch perm {
+			case PermissionNone, PermissionRead, PermissionReadWrite:
+			default:
+				return fmt.Errorf("role %s has unknown permission %q for table %s", name, perm, table)
+			}
+		}
+	}
+	for _, principals := range []map[string][]string{p.Subjects, p.UIDs} {
+		for principal, roles := range principals {
+			for _, role := range roles {
+				if _, ok := p.Roles[role]; !ok {
+					return fmt.Errorf("%s is assigned unknown role %s", principal, role)
+				}
+			}
+		}
+	}
+	for uid := range p.UIDs {
+		if _, err := strconv.ParseUint(uid, 10, 32); err != nil {
+			return fmt.Errorf("invalid uid: %s", uid)
+		}
+	}
+	return nil
+}
+
+// rolesFor returns the roles of the client that made the request
+func (p *AuthzPolicy) rolesFor(ctx context.Context) []string {
+	roles := []string{}
+	pr, ok := peer.FromContext(ctx)
+	if ok {
+		switch info := pr.AuthInfo.(type) {
+		case credentials.TLSInfo:
+			if len(info.State.PeerCertificates) > 0 {
+				subject := info.State.PeerCertificates[0].Subject
+				roles = append(roles, p.Subjects[subject.String()]...)
+				roles = append(roles, p.Subjects["CN="+subject.CommonName]...)
+			}
+		case UnixPeerInfo:
+			roles = append(roles, p.UIDs[strconv.FormatUint(uint64(info.UID), 10)]...)
+		}
+	}
+	if len(roles) == 0 {
+		if _, ok := p.Roles[DefaultRole]; ok {
+			roles = append(roles, DefaultRole)
+		}
+	}
+	return roles
+}
+
+// permission returns the highest permission the roles grant for the table
+func (p *AuthzPolicy) permission(roles []string, table string) Permission {
+	best := PermissionNone
+	for _, name := range roles {
+		role := p.Roles[name]
+		perm, ok := role.Tables[table]
+		if !ok {
+			perm = role.Tables[AllTables]
+		}
+		if perm.allows(best) && perm != best {
+			best = perm
+		}
+	}
+	return best
+}
+
+// resolvablePermission returns the lowest permission the roles grant for any
+// table the name could be resolved to. Backends may look up tables by prefix
+// so a name is checked against every table in the policy that it prefixes
+// and AllTables is checked against every table in the policy.
+func (p *AuthzPolicy) resolvablePermission(roles []string, table string) Permission {
+	lowest := p.permission(roles, table)
+	prefix := table
+	if table == AllTables {
+		prefix = ""
+	}
+	for _, role := range p.Roles {
+		for name := range role.Tables {
+			if name == AllTables || !strings.HasPrefix(name, prefix) {
+				continue
+			}
+			if perm := p.permission(roles, name); !perm.allows(lowest) {
+				lowest = perm
+			}
+		}
+	}
+	return lowest
+}
+
+// tableRequest is implemented by all requests that operate on a single table
+type tableRequest interface {
+	GetTable() string
+}
+
+// requiredPermissions maps each RPC to the permission it requires on the table
+// in the request. RPCs that operate on the whole database require the
+// permission on all tables.
+var requiredPermissions = map[string]Permission{
+	jqlpb.JQL_ListTables_FullMethodName:     PermissionNone,
+	jqlpb.JQL_ListRows_FullMethodName:       PermissionRead,
+	jqlpb.JQL_GetRow_FullMethodName:         PermissionRead,
+	jqlpb.JQL_WriteRow_FullMethodName:       PermissionReadWrite,
+	jqlpb.JQL_DeleteRow_FullMethodName:      PermissionReadWrite,
+	jqlpb.JQL_IncrementEntry_FullMethodName: PermissionReadWrite,
+	jqlpb.JQL_Persist_FullMethodName:        PermissionNone,
+	jqlpb.JQL_GetSnapshot_FullMethodName:    PermissionRead,
+	jqlpb.JQL_LoadSnapshot_FullMethodName:   PermissionReadWrite,
+	jqlpb.JQL_MigrateEnum_FullMethodName:    PermissionReadWrite,
+	jqlpb.JQL_Stats_FullMethodName:          PermissionNone,
+	jqlpb.JQL_WatchMutations_FullMethodName: PermissionRead,
+}
+
+// unauthenticatedMethods may be called by any client so that wrapper scripts
+// can wait for the daemon to be ready
+var unauthenticatedMethods = map[string]bool{
+	healthpb.Health_Check_FullMethodName: true,
+}
+
+// authorize returns an error if the client may not make the request
+func (p *AuthzPolicy) authorize(ctx context.Context, method string, req interface{}) ([]string, error) {
+	if unauthenticatedMethods[method] {
+		return nil, nil
+	}
+	roles := p.rolesFor(ctx)
+	if len(roles) == 0 {
+		return nil, status.Errorf(codes.PermissionDenied, "client has no roles")
+	}
+	required, ok := requiredPermissions[method]
+	if !ok {
+		return nil, status.Errorf(codes.PermissionDenied, "unknown method %s", method)
+	}
+	table := AllTables
+	if treq, ok := req.(tableRequest); ok {
+		table = treq.GetTable()
+	}
+	if !p.resolvablePermission(roles, table).allows(required) {
+		return nil, status.Errorf(codes.PermissionDenied, "%s requires %q access to %s", method, required, table)
+	}
+	return roles, nil
+}
+
+// UnaryInterceptor returns a gRPC interceptor that enforces the policy. Table
+// listings are filtered down to the tables the client can read.
+func (p *AuthzPolicy) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		roles, err := p.authorize(ctx, info.FullMethod, req)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if listing, ok := resp.(*jqlpb.ListTablesResponse); ok {
+			tables := []*jqlpb.TableMeta{}
+			for _, table := range listing.Tables {
+				if p.permission(roles, table.Name).allows(PermissionRead) {
+					tables = append(tables, table)
+				}
+			}
+			listing.Tables = tables
+		}
+		return resp, nil
+	}
+}
+
+// StreamInterceptor returns a gRPC interceptor that enforces the policy on
+// streaming RPCs, all of which operate on the whole database
+func (p *AuthzPolicy) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		_, err := p.authorize(ss.Context(), info.FullMethod, nil)
+		if err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,211 @@
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ulmenhaus/env/proto/jql/jqlpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
+)
+
+func testPolicy() *AuthzPolicy {
+	return &AuthzPolicy{
+		Roles: map[string]Role{
+			"dashboard":  {Tables: map[string]Permission{AllTables: PermissionRead}},
+			"automation": {Tables: map[string]Permission{AllTables: PermissionRead, "tasks": PermissionReadWrite, "secrets": PermissionNone}},
+			"admin":      {Tables: map[string]Permission{AllTables: PermissionReadWrite}},
+			"editor":     {Tables: map[string]Permission{AllTables: PermissionReadWrite, "tasks": PermissionRead}},
+		},
+		Subjects: map[string][]string{"CN=dashboard": {"dashboard"}, "CN=editor": {"editor"}},
+		UIDs:     map[string][]string{"1000": {"automation"}, "0": {"admin"}},
+	}
+}
+
+func certPeer(cn string) context.Context {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: cn}}
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}},
+	})
+}
+
+func uidPeer(uid uint32) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: UnixPeerInfo{UID: uid}})
+}
+
+func TestAuthorize(t *testing.T) {
+	cases := []struct {
+		name    string
+		ctx     context.Context
+		method  string
+		req     interface{}
+		allowed bool
+	}{
+		{
+			name:    "dashboard reads",
+			ctx:     certPeer("dashboard"),
+			method:  jqlpb.JQL_ListRows_FullMethodName,
+			req:     &jqlpb.ListRowsRequest{Table: "tasks"},
+			allowed: true,
+		},
+		{
+			name:   "dashboard cannot write",
+			ctx:    certPeer("dashboard"),
+			method: jqlpb.JQL_WriteRow_FullMethodName,
+			req:    &jqlpb.WriteRowRequest{Table: "tasks"},
+		},
+		{
+			name:   "dashboard cannot load snapshot",
+			ctx:    certPeer("dashboard"),
+			method: jqlpb.JQL_LoadSnapshot_FullMethodName,
+			req:    &jqlpb.LoadSnapshotRequest{},
+		},
+		{
+			name:    "automation writes granted table",
+			ctx:     uidPeer(1000),
+			method:  jqlpb.JQL_WriteRow_FullMethodName,
+			req:     &jqlpb.WriteRowRequest{Table: "tasks"},
+			allowed: true,
+		},
+		{
+			name:   "automation cannot write other tables",
+			ctx:    uidPeer(1000),
+			method: jqlpb.JQL_DeleteRow_FullMethodName,
+			req:    &jqlpb.DeleteRowRequest{Table: "log"},
+		},
+		{
+			name:   "automation cannot read revoked table",
+			ctx:    uidPeer(1000),
+			method: jqlpb.JQL_GetRow_FullMethodName,
+			req:    &jqlpb.GetRowRequest{Table: "secrets"},
+		},
+		{
+			name:   "automation cannot read revoked table by prefix",
+			ctx:    uidPeer(1000),
+			method: jqlpb.JQL_GetRow_FullMethodName,
+			req:    &jqlpb.GetRowRequest{Table: "secr"},
+		},
+		{
+			name:   "automation cannot read with an empty table",
+			ctx:    uidPeer(1000),
+			method: jqlpb.JQL_ListRows_FullMethodName,
+			req:    &jqlpb.ListRowsRequest{},
+		},
+		{
+			// the prefix could just as well resolve to a read-only table
+			// like "tasklog"
+			name:   "automation cannot write granted table by prefix",
+			ctx:    uidPeer(1000),
+			method: jqlpb.JQL_WriteRow_FullMethodName,
+			req:    &jqlpb.WriteRowRequest{Table: "tas"},
+		},
+		{
+			name:   "automation cannot snapshot with a revoked table",
+			ctx:    uidPeer(1000),
+			method: jqlpb.JQL_GetSnapshot_FullMethodName,
+			req:    &jqlpb.GetSnapshotRequest{},
+		},
+		{
+			name:   "automation cannot watch mutations with a revoked table",
+			ctx:    uidPeer(1000),
+			method: jqlpb.JQL_WatchMutations_FullMethodName,
+		},
+		{
+			name:   "editor cannot load snapshot over a read-only table",
+			ctx:    certPeer("editor"),
+			method: jqlpb.JQL_LoadSnapshot_FullMethodName,
+			req:    &jqlpb.LoadSnapshotRequest{},
+		},
+		{
+			name:    "editor snapshots",
+			ctx:     certPeer("editor"),
+			method:  jqlpb.JQL_GetSnapshot_FullMethodName,
+			req:     &jqlpb.GetSnapshotRequest{},
+			allowed: true,
+		},
+		{
+			name:    "dashboard watches mutations",
+			ctx:     certPeer("dashboard"),
+			method:  jqlpb.JQL_WatchMutations_FullMethodName,
+			allowed: true,
+		},
+		{
+			name:    "admin loads snapshot",
+			ctx:     uidPeer(0),
+			method:  jqlpb.JQL_LoadSnapshot_FullMethodName,
+			req:     &jqlpb.LoadSnapshotRequest{},
+			allowed: true,
+		},
+		{
+			name:   "unknown uid",
+			ctx:    uidPeer(4242),
+			method: jqlpb.JQL_ListTables_FullMethodName,
+			req:    &jqlpb.ListTablesRequest{},
+		},
+		{
+			name:    "health check without roles",
+			ctx:     uidPeer(4242),
+			method:  healthpb.Health_Check_FullMethodName,
+			req:     &healthpb.HealthCheckRequest{},
+			allowed: true,
+		},
+		{
+			name:   "no peer",
+			ctx:    context.Background(),
+			method: jqlpb.JQL_ListTables_FullMethodName,
+			req:    &jqlpb.ListTablesRequest{},
+		},
+	}
+	policy := testPolicy()
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			_, err := policy.authorize(tc.ctx, tc.method, tc.req)
+			if tc.allowed {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestAuthzFiltersTables(t *testing.T) {
+	policy := testPolicy()
+	policy.Roles[DefaultRole] = Role{Tables: map[string]Permission{"tasks": PermissionRead}}
+	interceptor := policy.UnaryInterceptor()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &jqlpb.ListTablesResponse{Tables: []*jqlpb.TableMeta{{Name: "log"}, {Name: "tasks"}}}, nil
+	}
+	resp, err := interceptor(context.Background(), &jqlpb.ListTablesRequest{}, &grpc.UnaryServerInfo{FullMethod: jqlpb.JQL_ListTables_FullMethodName}, handler)
+	require.NoError(t, err)
+	tables := resp.(*jqlpb.ListTablesResponse).Tables
+	require.Len(t, tables, 1)
+	require.Equal(t, "tasks", tables[0].Name)
+}
+
+func TestExactTables(t *testing.T) {
+	dbms := newTestDBMS(t, testSnapshot)
+	ctx := context.Background()
+	_, err := dbms.ListRows(ctx, &jqlpb.ListRowsRequest{Table: "tas"})
+	require.NoError(t, err)
+	dbms.ExactTables = true
+	_, err = dbms.ListRows(ctx, &jqlpb.ListRowsRequest{Table: "tas"})
+	require.Error(t, err)
+	_, err = dbms.ListRows(ctx, &jqlpb.ListRowsRequest{})
+	require.Error(t, err)
+	_, err = dbms.ListRows(ctx, &jqlpb.ListRowsRequest{Table: "tasks"})
+	require.NoError(t, err)
+}
+
+func TestAuthzPolicyValidate(t *testing.T) {
+	policy := testPolicy()
+	policy.UIDs["1001"] = []string{"superuser"}
+	require.Error(t, policy.Validate())
+}
@@ -0,0 +1,396 @@
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/ulmenhaus/env/proto/jql/jqlpb"
+	"google.golang.org/protobuf/proto"
+)
+
+// placeholderPattern matches placeholders like {pk} or {Status} in the
+// arguments of built-in actions
+var placeholderPattern = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// builtinActions are the actions a built-in macro can be composed of
+var builtinActions = map[string]func(*builtinMacro, context.Context, []string) error{
+	// set COLUMN=VALUE... sets fields of the selected row
+	"set": (*builtinMacro).set,
+	// copy TABLE [COLUMN=VALUE...] inserts a copy of the selected row into
+	// another table. Columns are copied by name and the assignments take
+	// precedence, including for the primary column of the new row.
+	"copy": (*builtinMacro).copy,
+	// next selects the next row of the view
+	"next": (*builtinMacro).next,
+	// prev selects the previous row of the view
+	"prev": (*builtinMacro).prev,
+	// open TABLE [COLUMN=VALUE...] opens the table filtered to rows whose
+	// columns equal the values
+	"open": (*builtinMacro).open,
+	// insert TABLE [COLUMN=VALUE...] inserts a new row into a table, e.g.
+	// an audit log. The pk is generated unless the primary column is
+	// assigned.
+	"insert": (*builtinMacro).insert,
+	// cascade [TABLE.]COLUMN FIELD... sets the fields of every row whose
+	// column refers to the selected row to the selected row's values. The
+	// table defaults to that of the selected row.
+	"cascade": (*builtinMacro).cascade,
+}
+
+// builtinMacro is the state of a running built-in macro
+type builtinMacro struct {
+	dbms JQL_DBMS
+	view MacroCurrentView
+	// old are the fields of the selected row before the write that fired a
+	// trigger and may be referred to as {old.COLUMN}
+	old map[string]string
+	// deleted is set when the selected row was deleted so that fields are
+	// taken from the old row
+	deleted bool
+}
+
+// RunBuiltinMacro runs a pipeline of built-in actions separated by pipes,
+// e.g. "set Status=Satisfied | next". Arguments are split the way a shell
+// would and may refer to the pk, table, or a column of the selected row as
+// {pk}, {table} or {COLUMN}. The returned interface contains the updated
+// current view like that of any other macro.
+func RunBuiltinMacro(ctx context.Context, dbms JQL_DBMS, pipeline string, currentView MacroCurrentView) (*MacroInterface, error) {
+	return runBuiltinActions(ctx, &builtinMacro{dbms: dbms, view: currentView}, pipeline)
+}
+
+func runBuiltinActions(ctx context.Context, macro *builtinMacro, pipeline string) (*MacroInterface, error) {
+	commands, err := parseBuiltinActions(pipeline)
+	if err != nil {
+		return nil, err
+	}
+	for _, command := range commands {
+		action := command[0]
+		args, err := macro.expand(ctx, command[1:])
+		if err != nil {
+			return nil, fmt.Errorf("Could not run %s: %s", action, err)
+		}
+		err = builtinActions[action](macro, ctx, args)
+		if err != nil {
+			return nil, fmt.Errorf("Could not run %s: %s", action, err)
+		}
+	}
+	return &MacroInterface{CurrentView: macro.view}, nil
+}
+
+// splitColumnRef splits a reference of the form [TABLE.]COLUMN into its table
+// and column. Table names may contain dots, e.g. for virtual or mounted tables,
+// but column names can't so the table ends at the last one.
+func splitColumnRef(ref, defaultTable string) (string, string) {
+	i := strings.LastIndex(ref, ".")
+	if i == -1 {
+		return defaultTable, ref
+	}
+	return ref[:i], ref[i+1:]
+}
+
+// parseBuiltinActions splits a pipeline into its actions and their arguments
+// and checks that every action exists
+func parseBuiltinActions(pipeline string) ([][]string, error) {
+	commands, err := splitPipeline(pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid macro actions: %s", err)
+	}
+	for _, args := range commands {
+		if len(args) == 0 {
+			return nil, fmt.Errorf("Invalid macro actions: empty action in %q", pipeline)
+		}
+		if _, ok := builtinActions[args[0]]; !ok {
+			return nil, fmt.Errorf("Invalid macro actions: unknown action %q", args[0])
+		}
+	}
+	return commands, nil
+}
+
+// expand replaces placeholders in the arguments with values of the selected
+// row
+func (m *builtinMacro) expand(ctx context.Context, args []string) ([]string, error) {
+	var fields map[string]string
+	expanded := []string{}
+	for _, arg := range args {
+		var err error
+		arg = placeholderPattern.ReplaceAllStringFunc(arg, func(placeholder string) string {
+			name := placeholder[1 : len(placeholder)-1]
+			switch name {
+			case "pk":
+				return m.view.PrimarySelection
+			case "table":
+				return m.view.Table
+			}
+			if column, ok := strings.CutPrefix(name, "old."); ok {
+				// there's no old row for inserts
+				return m.old[column]
+			}
+			if fields == nil && err == nil {
+				fields, err = m.selectedFields(ctx)
+			}
+			value, ok := fields[name]
+			if !ok && err == nil {
+				err = fmt.Errorf("no such column for placeholder %s", placeholder)
+			}
+			return value
+		})
+		if err != nil {
+			return nil, err
+		}
+		expanded = append(expanded, arg)
+	}
+	return expanded, nil
+}
+
+// selectedFields returns the formatted fields of the selected row
+func (m *builtinMacro) selectedFields(ctx context.Context) (map[string]string, error) {
+	if m.deleted {
+		return m.old, nil
+	}
+	if m.view.PrimarySelection == "" {
+		return nil, fmt.Errorf("no row is selected")
+	}
+	resp, err := m.dbms.GetRow(ctx, &jqlpb.GetRowRequest{Table: m.view.Table, Pk: m.view.PrimarySelection})
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]string{}
+	for i, column := range resp.Columns {
+		fields[column.Name] = resp.Row.Entries[i].Formatted
+	}
+	return fields, nil
+}
+
+// parseAssignments parses arguments of the form COLUMN=VALUE
+func parseAssignments(args []string) (map[string]string, error) {
+	assignments := map[string]string{}
+	for _, arg := range args {
+		column, value, ok := strings.Cut(arg, "=")
+		if !ok || column == "" {
+			return nil, fmt.Errorf("expected COLUMN=VALUE but got %q", arg)
+		}
+		assignments[column] = value
+	}
+	return assignments, nil
+}
+
+func (m *builtinMacro) set(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: set COLUMN=VALUE...")
+	}
+	fields, err := parseAssignments(args)
+	if err != nil {
+		return err
+	}
+	if m.view.PrimarySelection == "" {
+		return fmt.Errorf("no row is selected")
+	}
+	_, err = m.dbms.WriteRow(ctx, &jqlpb.WriteRowRequest{
+		Table:      m.view.Table,
+		Pk:         m.view.PrimarySelection,
+		Fields:     fields,
+		UpdateOnly: true,
+	})
+	return err
+}
+
+func (m *builtinMacro) copy(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: copy TABLE [COLUMN=VALUE...]")
+	}
+	assignments, err := parseAssignments(args[1:])
+	if err != nil {
+		return err
+	}
+	tables, err := GetTables(ctx, m.dbms)
+	if err != nil {
+		return err
+	}
+	target, ok := tables[args[0]]
+	if !ok {
+		return fmt.Errorf("no such table: %s", args[0])
+	}
+	selected, err := m.selectedFields(ctx)
+	if err != nil {
+		return err
+	}
+	pk := m.view.PrimarySelection
+	fields := map[string]string{}
+	for _, column := range target.Columns {
+		value, ok := assignments[column.Name]
+		if !ok {
+			value, ok = selected[column.Name]
+		}
+		if !ok {
+			continue
+		}
+		if column.Primary {
+			pk = value
+		} else {
+			fields[column.Name] = value
+		}
+	}
+	_, err = m.dbms.WriteRow(ctx, &jqlpb.WriteRowRequest{
+		Table:      target.Name,
+		Pk:         pk,
+		Fields:     fields,
+		InsertOnly: true,
+	})
+	return err
+}
+
+func (m *builtinMacro) next(ctx context.Context, args []string) error {
+	return m.move(args, 1)
+}
+
+func (m *builtinMacro) prev(ctx context.Context, args []string) error {
+	return m.move(args, -1)
+}
+
+// move selects the row offset from the selected one in the view, staying on
+// the first or last row
+func (m *builtinMacro) move(args []string, offset int) error {
+	if len(args) != 0 {
+		return fmt.Errorf("takes no arguments")
+	}
+	index := slices.Index(m.view.PKs, m.view.PrimarySelection)
+	if index == -1 {
+		return nil
+	}
+	index = max(0, min(len(m.view.PKs)-1, index+offset))
+	m.view.PrimarySelection = m.view.PKs[index]
+	return nil
+}
+
+func (m *builtinMacro) open(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: open TABLE [COLUMN=VALUE...]")
+	}
+	assignments, err := parseAssignments(args[1:])
+	if err != nil {
+		return err
+	}
+	condition := &jqlpb.Condition{}
+	for _, arg := range args[1:] {
+		// iterate over the arguments rather than the map so that filters
+		// are in a stable order
+		column, _, _ := strings.Cut(arg, "=")
+		condition.Requires = append(condition.Requires, &jqlpb.Filter{
+			Column: column,
+			Match:  &jqlpb.Filter_EqualMatch{EqualMatch: &jqlpb.EqualMatch{Value: assignments[column]}},
+		})
+	}
+	request := &jqlpb.ListRowsRequest{
+		Table:      args[0],
+		Conditions: []*jqlpb.Condition{condition},
+	}
+	encoded, err := proto.Marshal(request)
+	if err != nil {
+		return err
+	}
+	m.view = MacroCurrentView{
+		Table:          args[0],
+		EncodedRequest: hex.EncodeToString(encoded),
+	}
+	return nil
+}
+
+func (m *builtinMacro) insert(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: insert TABLE [COLUMN=VALUE...]")
+	}
+	assignments, err := parseAssignments(args[1:])
+	if err != nil {
+		return err
+	}
+	tables, err := GetTables(ctx, m.dbms)
+	if err != nil {
+		return err
+	}
+	target, ok := tables[args[0]]
+	if !ok {
+		return fmt.Errorf("no such table: %s", args[0])
+	}
+	pk := ""
+	fields := map[string]string{}
+	for column, value := range assignments {
+		if IndexOfField(target.Columns, column) == -1 {
+			return fmt.Errorf("no such column '%s' in table '%s'", column, target.Name)
+		}
+		if target.Columns[IndexOfField(target.Columns, column)].Primary {
+			pk = value
+		} else {
+			fields[column] = value
+		}
+	}
+	_, err = m.dbms.WriteRow(ctx, &jqlpb.WriteRowRequest{
+		Table:      target.Name,
+		Pk:         pk,
+		Fields:     fields,
+		InsertOnly: true,
+	})
+	return err
+}
+
+func (m *builtinMacro) cascade(ctx context.Context, args []string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("usage: cascade [TABLE.]COLUMN FIELD...")
+	}
+	table, column := splitColumnRef(args[0], m.view.Table)
+	selected, err := m.selectedFields(ctx)
+	if err != nil {
+		return err
+	}
+	fields := map[string]string{}
+	for _, field := range args[1:] {
+		value, ok := selected[field]
+		if !ok {
+			return fmt.Errorf("no such column '%s' in table '%s'", field, m.view.Table)
+		}
+		fields[field] = value
+	}
+	resp, err := m.dbms.ListRows(ctx, &jqlpb.ListRowsRequest{
+		Table: table,
+		Conditions: []*jqlpb.Condition{{
+			Requires: []*jqlpb.Filter{{
+				Column: column,
+				Match:  &jqlpb.Filter_EqualMatch{EqualMatch: &jqlpb.EqualMatch{Value: m.view.PrimarySelection}},
+			}},
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	primary := GetPrimary(resp.Columns)
+	for _, row := range resp.Rows {
+		// only write children that differ so that unchanged rows don't
+		// fire their own triggers
+		changed := map[string]string{}
+		for field, value := range fields {
+			index := IndexOfField(resp.Columns, field)
+			if index == -1 {
+				return fmt.Errorf("no such column '%s' in table '%s'", field, table)
+			}
+			if row.Entries[index].Formatted != value {
+				changed[field] = value
+			}
+		}
+		if len(changed) == 0 {
+			continue
+		}
+		_, err := m.dbms.WriteRow(ctx, &jqlpb.WriteRowRequest{
+			Table:      table,
+			Pk:         row.Entries[primary].Formatted,
+			Fields:     changed,
+			UpdateOnly: true,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
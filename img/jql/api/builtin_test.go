@@ -0,0 +1,176 @@
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ulmenhaus/env/proto/jql/jqlpb"
+	"google.golang.org/protobuf/proto"
+)
+
+const testBuiltinSnapshot = `{
+	"_schemata": {
+		"tasks.Name": {"type": "string", "primary": true},
+		"tasks.Status": {"type": "enum", "features": {"values": "Pending, Active, Satisfied, Abandoned"}},
+		"tasks.Notes": {"type": "string"},
+		"archive.Name": {"type": "string", "primary": true},
+		"archive.Status": {"type": "enum", "features": {"values": "Pending, Active, Satisfied, Abandoned"}},
+		"archive.Reason": {"type": "string"}
+	},
+	"tasks": {
+		"fix build": {"Status": "Active", "Notes": "flaky"},
+		"add filters": {"Status": "Pending"},
+		"plan week": {"Status": "Abandoned"}
+	},
+	"archive": {}
+}`
+
+func TestRunBuiltinMacro(t *testing.T) {
+	view := MacroCurrentView{
+		Table:            "tasks",
+		PKs:              []string{"add filters", "fix build", "plan week"},
+		PrimarySelection: "fix build",
+	}
+	cases := []struct {
+		name      string
+		actions   string
+		selection string
+		table     string
+		rows      map[string]map[string]string
+		request   *jqlpb.ListRowsRequest
+		err       bool
+	}{
+		{
+			name:      "set and advance",
+			actions:   "set Status=Satisfied | next",
+			selection: "plan week",
+			table:     "tasks",
+			rows: map[string]map[string]string{
+				"tasks": {"fix build": "Satisfied"},
+			},
+		},
+		{
+			name:      "set with quotes and placeholders",
+			actions:   `set 'Notes=was {Status}: {Notes}'|prev`,
+			selection: "add filters",
+			table:     "tasks",
+			rows: map[string]map[string]string{
+				"tasks": {"fix build": "Active"},
+			},
+		},
+		{
+			name:      "copy into another table",
+			actions:   `copy archive "Reason=moved from {table}" | set Status=Abandoned`,
+			selection: "fix build",
+			table:     "tasks",
+			rows: map[string]map[string]string{
+				"archive": {"fix build": "Active"},
+				"tasks":   {"fix build": "Abandoned"},
+			},
+		},
+		{
+			name:      "copy with a new pk",
+			actions:   `copy archive "Name={pk} (copy)"`,
+			selection: "fix build",
+			table:     "tasks",
+			rows: map[string]map[string]string{
+				"archive": {"fix build (copy)": "Active"},
+			},
+		},
+		{
+			name:    "open a query",
+			actions: "open tasks Status=Pending",
+			table:   "tasks",
+			request: &jqlpb.ListRowsRequest{
+				Table: "tasks",
+				Conditions: []*jqlpb.Condition{{
+					Requires: []*jqlpb.Filter{{
+						Column: "Status",
+						Match:  &jqlpb.Filter_EqualMatch{EqualMatch: &jqlpb.EqualMatch{Value: "Pending"}},
+					}},
+				}},
+			},
+		},
+		{
+			name:    "unknown action",
+			actions: "set Status=Satisfied | explode",
+			err:     true,
+		},
+		{
+			name:    "empty action",
+			actions: "set Status=Satisfied || next",
+			err:     true,
+		},
+		{
+			name:    "invalid assignment",
+			actions: "set Satisfied",
+			err:     true,
+		},
+		{
+			name:    "unknown placeholder",
+			actions: "set Notes={Owner}",
+			err:     true,
+		},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			dbms := newTestDBMS(t, testBuiltinSnapshot)
+			ctx := context.Background()
+			output, err := RunBuiltinMacro(ctx, dbms, tc.actions, view)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.table, output.CurrentView.Table)
+			require.Equal(t, tc.selection, output.CurrentView.PrimarySelection)
+			for table, statuses := range tc.rows {
+				_, values := listColumn(t, dbms, table, "Status")
+				for pk, status := range statuses {
+					require.Equal(t, status, values[pk])
+				}
+			}
+			if tc.request != nil {
+				encoded, err := hex.DecodeString(output.CurrentView.EncodedRequest)
+				require.NoError(t, err)
+				request := &jqlpb.ListRowsRequest{}
+				require.NoError(t, proto.Unmarshal(encoded, request))
+				require.True(t, proto.Equal(tc.request, request))
+			}
+		})
+	}
+}
+
+func TestRunBuiltinMacroPlaceholders(t *testing.T) {
+	dbms := newTestDBMS(t, testBuiltinSnapshot)
+	ctx := context.Background()
+	view := MacroCurrentView{Table: "tasks", PKs: []string{"fix build"}, PrimarySelection: "fix build"}
+	_, err := RunBuiltinMacro(ctx, dbms, `set 'Notes=was {Status}: {Notes}'`, view)
+	require.NoError(t, err)
+	_, notes := listColumn(t, dbms, "tasks", "Notes")
+	require.Equal(t, "was Active: flaky", notes["fix build"])
+}
+
+func TestSplitColumnRef(t *testing.T) {
+	cases := []struct {
+		name   string
+		ref    string
+		table  string
+		column string
+	}{
+		{name: "column", ref: "Parent", table: "tasks", column: "Parent"},
+		{name: "table", ref: "nouns.Parent", table: "nouns", column: "Parent"},
+		{name: "virtual table", ref: "vt.reminders.Task", table: "vt.reminders", column: "Task"},
+		{name: "mounted table", ref: "projects.tasks.Parent", table: "projects.tasks", column: "Parent"},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			table, column := splitColumnRef(tc.ref, "tasks")
+			require.Equal(t, tc.table, table)
+			require.Equal(t, tc.column, column)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"github.com/ulmenhaus/env/proto/jql/jqlpb"
+)
+
+// ImportCSV upserts the rows of a CSV document into the given table. The first
+// record of the document is a header naming the columns of the table and must
+// include its primary column. Values are parsed the same way as if they had been
+// entered into the UI. It returns the number of rows written.
+func ImportCSV(ctx context.Context, dbms JQL_DBMS, table string, r io.Reader) (int, error) {
+	resp, err := dbms.ListRows(ctx, &jqlpb.ListRowsRequest{
+		Table: table,
+		Limit: 1,
+	})
+	if err != nil {
+		return 0, err
+	}
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read csv header: %s", err)
+	}
+	primary := -1
+	for i, name := range header {
+		col := IndexOfField(resp.Columns, name)
+		if col == -1 {
+			return 0, fmt.Errorf("unknown column in csv header: %s", name)
+		}
+		if col == GetPrimary(resp.Columns) {
+			primary = i
+		}
+	}
+	if primary == -1 {
+		return 0, fmt.Errorf("csv header must include the primary column of %s", resp.Table)
+	}
+	written := 0
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return written, nil
+		} else if err != nil {
+			return written, err
+		}
+		pk := record[primary]
+		fields := map[string]string{}
+		for i, value := range record {
+			if i != primary {
+				fields[header[i]] = value
+			}
+		}
+		// Upserting is a no-op for existing rows so they're updated instead.
+		// New rows are inserted with all of their fields at once so that
+		// required columns are satisfied.
+		_, err = dbms.GetRow(ctx, &jqlpb.GetRowRequest{
+			Table: resp.Table,
+			Pk:    pk,
+		})
+		if err != nil && !IsNotExistError(err) {
+			return written, fmt.Errorf("line %d: %s", line, err)
+		}
+		_, err = dbms.WriteRow(ctx, &jqlpb.WriteRowRequest{
+			Table:      resp.Table,
+			Pk:         pk,
+			Fields:     fields,
+			InsertOnly: err != nil,
+			UpdateOnly: err == nil,
+		})
+		if err != nil {
+			return written, fmt.Errorf("line %d: %s", line, err)
+		}
+		written++
+	}
+}
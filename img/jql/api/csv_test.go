@@ -0,0 +1,68 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportCSV(t *testing.T) {
+	cases := []struct {
+		name     string
+		csv      string
+		written  int
+		failed   bool
+		expected map[string]string
+	}{
+		{
+			name:    "insert and update",
+			csv:     "Name,Status\nfix build,Satisfied\nwrite docs,Pending\n",
+			written: 2,
+			expected: map[string]string{
+				"fix build":  "Satisfied",
+				"write docs": "Pending",
+			},
+		},
+		{
+			name:    "missing required value",
+			csv:     "Name,Status\nwrite docs,\n",
+			written: 0,
+			failed:  true,
+			expected: map[string]string{
+				"fix build": "Active",
+			},
+		},
+		{
+			name:    "required column not in header",
+			csv:     "Name\nwrite docs\n",
+			written: 0,
+			failed:  true,
+			expected: map[string]string{
+				"fix build": "Active",
+			},
+		},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			dbms := newTestDBMS(t, `{
+				"_schemata": {
+					"tasks.Name": {"type": "string", "primary": true},
+					"tasks.Status": {"type": "string", "features": {"required": true}}
+				},
+				"tasks": {"fix build": {"Status": "Active"}}
+			}`)
+			written, err := ImportCSV(context.Background(), dbms, "tasks", strings.NewReader(tc.csv))
+			if tc.failed {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.written, written)
+			_, actual := listColumn(t, dbms, "tasks", "Status")
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
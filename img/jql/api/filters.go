@@ -2,6 +2,8 @@ package api
 
 import (
 	"fmt"
+	"regexp"
+	"regexp/syntax"
 	"strings"
 
 	"github.com/ulmenhaus/env/img/jql/types"
@@ -12,19 +14,68 @@ type filterShim struct {
 	filter *jqlpb.Filter
 	colix  int
 	asMap  map[string]bool
+	re     *regexp.Regexp
+	bound  types.Entry
 }
 
 func xor(a, b bool) bool {
 	return (a && !b) || (!a && b)
 }
 
-func (f *filterShim) init() {
+func (f *filterShim) init(t *types.Table) error {
 	switch match := f.filter.Match.(type) {
+	case *jqlpb.Filter_LessThanMatch:
+		return f.initBound(t, match.LessThanMatch.Value)
+	case *jqlpb.Filter_GreatherThanMatch:
+		return f.initBound(t, match.GreatherThanMatch.Value)
 	case *jqlpb.Filter_InMatch:
 		// TODO really inefficient to construct this map every time. Should only be necessary
 		// on writes.
 		f.asMap = slice2map(match.InMatch.Values)
+	case *jqlpb.Filter_RegexMatch:
+		// The shim lives for the duration of a single request so the regex is only
+		// compiled once regardless of the number of rows it's applied to
+		re, err := regexp.Compile(match.RegexMatch.Value)
+		if err != nil {
+			return fmt.Errorf("invalid regex for %s: %s", f.filter.Column, err)
+		}
+		f.re = re
+	}
+	return nil
+}
+
+// initBound parses the bound of a range filter using the type of the filtered column
+// so that inputs like relative dates are only evaluated once per request. Range
+// filters without a column never match, as before they were supported.
+func (f *filterShim) initBound(t *types.Table, value string) error {
+	if f.colix < 0 {
+		return nil
+	}
+	column := t.Columns[f.colix]
+	zero, err := t.Constructors[column](nil, t.Features(column))
+	if err != nil {
+		return err
+	}
+	bound, err := zero.Reverse("", value)
+	if err != nil {
+		return fmt.Errorf("invalid bound for %s: %s", f.filter.Column, err)
 	}
+	f.bound = bound
+	return nil
+}
+
+// anyEntry returns true iff the predicate holds for the filtered column or, if the
+// filter applies to all columns, for any column in the row
+func (f *filterShim) anyEntry(e []types.Entry, pred func(string) bool) bool {
+	if f.colix >= 0 {
+		return pred(e[f.colix].Format(""))
+	}
+	for i := 0; i < len(e); i++ {
+		if pred(e[i].Format("")) {
+			return true
+		}
+	}
+	return false
 }
 
 func (f *filterShim) Applies(e []types.Entry) bool {
@@ -33,6 +84,16 @@ func (f *filterShim) Applies(e []types.Entry) bool {
 		return xor(e[f.colix].Format("user-input") == match.EqualMatch.Value, f.filter.Negated)
 	case *jqlpb.Filter_InMatch:
 		return f.asMap[e[f.colix].Format("user-input")]
+	case *jqlpb.Filter_LessThanMatch:
+		if f.bound == nil {
+			return false
+		}
+		return xor(e[f.colix].Compare(f.bound), f.filter.Negated)
+	case *jqlpb.Filter_GreatherThanMatch:
+		if f.bound == nil {
+			return false
+		}
+		return xor(f.bound.Compare(e[f.colix]), f.filter.Negated)
 	case *jqlpb.Filter_ContainsMatch:
 		cm := match.ContainsMatch
 		// NOTE exact match + col < 0 not implemented and will cause a panic
@@ -52,12 +113,16 @@ func (f *filterShim) Applies(e []types.Entry) bool {
 			return false
 		}
 		return strings.Contains(strings.ToLower(e[f.colix].Format("")), strings.ToLower(cm.Value))
-
+	case *jqlpb.Filter_RegexMatch:
+		return xor(f.anyEntry(e, f.re.MatchString), f.filter.Negated)
+	case *jqlpb.Filter_PrefixMatch:
+		prefix := match.PrefixMatch.Value
+		return xor(f.anyEntry(e, func(s string) bool { return strings.HasPrefix(s, prefix) }), f.filter.Negated)
 	}
 	return false
 }
 
-func newFilterShim(f *jqlpb.Filter, t *types.Table) *filterShim {
+func newFilterShim(f *jqlpb.Filter, t *types.Table) (*filterShim, error) {
 	switch match := f.Match.(type) {
 	case *jqlpb.Filter_PathToMatch:
 		return shimForPathToMatch(f, match, t)
@@ -66,12 +131,11 @@ func newFilterShim(f *jqlpb.Filter, t *types.Table) *filterShim {
 			filter: f,
 			colix:  t.IndexOfField(f.GetColumn()),
 		}
-		shim.init()
-		return shim
+		return shim, shim.init(t)
 	}
 }
 
-func shimForPathToMatch(f *jqlpb.Filter, match *jqlpb.Filter_PathToMatch, t *types.Table) *filterShim {
+func shimForPathToMatch(f *jqlpb.Filter, match *jqlpb.Filter_PathToMatch, t *types.Table) (*filterShim, error) {
 	edges := map[string][]string{}
 	colix := t.IndexOfField(f.GetColumn())
 	for pk, row := range t.Entries {
@@ -116,6 +180,17 @@ func PrimarySuggestion(f *jqlpb.Filter) (string, bool) {
 		return "", false
 	case *jqlpb.Filter_ContainsMatch:
 		return "", false
+	case *jqlpb.Filter_PrefixMatch:
+		if f.Negated {
+			return "", false
+		}
+		return match.PrefixMatch.Value, true
+	case *jqlpb.Filter_RegexMatch:
+		if f.Negated {
+			return "", false
+		}
+		prefix := regexPrefix(match.RegexMatch.Value)
+		return prefix, prefix != ""
 	}
 	return "", false
 }
@@ -131,6 +206,18 @@ func Description(f *jqlpb.Filter) string {
 		return fmt.Sprintf("%s %s \"%s\"", f.Column, op, strings.Replace(match.EqualMatch.Value, "\"", "\\\"", -1))
 	case *jqlpb.Filter_InMatch:
 		return fmt.Sprintf("%s in (%s)", f.Column, strings.Join(match.InMatch.Values, ", "))
+	case *jqlpb.Filter_LessThanMatch:
+		op := "<"
+		if f.Negated {
+			op = ">="
+		}
+		return fmt.Sprintf("%s %s \"%s\"", f.Column, op, strings.Replace(match.LessThanMatch.Value, "\"", "\\\"", -1))
+	case *jqlpb.Filter_GreatherThanMatch:
+		op := ">"
+		if f.Negated {
+			op = "<="
+		}
+		return fmt.Sprintf("%s %s \"%s\"", f.Column, op, strings.Replace(match.GreatherThanMatch.Value, "\"", "\\\"", -1))
 	case *jqlpb.Filter_ContainsMatch:
 		return fmt.Sprintf("%s contains \"%s\"", f.Column, strings.Replace(match.ContainsMatch.Value, "\"", "\\\"", -1))
 	case *jqlpb.Filter_PathToMatch:
@@ -139,6 +226,18 @@ func Description(f *jqlpb.Filter) string {
 			direction = "ancestors"
 		}
 		return fmt.Sprintf("%s %s of \"%s\"", f.Column, direction, strings.Replace(match.PathToMatch.Value, "\"", "\\\"", -1))
+	case *jqlpb.Filter_RegexMatch:
+		op := "matches"
+		if f.Negated {
+			op = "does not match"
+		}
+		return fmt.Sprintf("%s %s /%s/", f.Column, op, match.RegexMatch.Value)
+	case *jqlpb.Filter_PrefixMatch:
+		op := "starts with"
+		if f.Negated {
+			op = "does not start with"
+		}
+		return fmt.Sprintf("%s %s \"%s\"", f.Column, op, strings.Replace(match.PrefixMatch.Value, "\"", "\\\"", -1))
 	}
 	return ""
 }
@@ -157,10 +256,49 @@ func Example(columns []*jqlpb.Column, f *jqlpb.Filter) (int, string) {
 		return col, match.InMatch.Values[0]
 	case *jqlpb.Filter_ContainsMatch:
 		return col, match.ContainsMatch.Value
+	case *jqlpb.Filter_PrefixMatch:
+		if f.Negated {
+			return -1, ""
+		}
+		return col, match.PrefixMatch.Value
+	case *jqlpb.Filter_RegexMatch:
+		if f.Negated {
+			return -1, ""
+		}
+		// Only a regex that matches a single literal has an example we can be
+		// sure of
+		re, err := regexp.Compile(match.RegexMatch.Value)
+		if err != nil {
+			return -1, ""
+		}
+		literal, complete := re.LiteralPrefix()
+		if !complete {
+			return -1, ""
+		}
+		return col, literal
 	}
 	return 0, ""
 }
 
+// regexPrefix returns the literal prefix that any string matching the provided
+// regex must begin with, or an empty string if there is none
+func regexPrefix(expr string) string {
+	re, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return ""
+	}
+	re = re.Simplify()
+	// A literal is only a prefix if the regex is anchored to the start of the text
+	if re.Op != syntax.OpConcat || len(re.Sub) < 2 || re.Sub[0].Op != syntax.OpBeginText {
+		return ""
+	}
+	lit := re.Sub[1]
+	if lit.Op != syntax.OpLiteral || lit.Flags&syntax.FoldCase != 0 {
+		return ""
+	}
+	return string(lit.Rune)
+}
+
 func slice2map(slice []string) map[string]bool {
 	m := map[string]bool{}
 	for _, s := range slice {
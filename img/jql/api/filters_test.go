@@ -0,0 +1,187 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ulmenhaus/env/img/jql/types"
+	"github.com/ulmenhaus/env/proto/jql/jqlpb"
+)
+
+func newTestTable() *types.Table {
+	columns := []string{"Description", "Status", "Tags"}
+	entries := map[string][]types.Entry{
+		"[ENV] fix build":   {types.String("[ENV] fix build"), types.String("Active"), types.Tags{"ci", "env"}},
+		"[ENV] add filters": {types.String("[ENV] add filters"), types.String("Pending"), types.Tags{"env"}},
+		"Plan for Monday":   {types.String("Plan for Monday"), types.String("Active"), types.Tags{"planning"}},
+		"nouns jql":         {types.String("nouns jql"), types.String("Satisfied"), types.Tags{"environment"}},
+	}
+	constructors := map[string]types.FieldValueConstructor{
+		"Description": types.NewString,
+		"Status":      types.NewString,
+		"Tags":        types.NewTags,
+	}
+	meta := map[string]*types.ColumnMeta{
+		"Description": {Type: jqlpb.EntryType_STRING},
+		"Status":      {Type: jqlpb.EntryType_STRING},
+		"Tags":        {Type: jqlpb.EntryType_TAGS},
+	}
+	return types.NewTable(columns, entries, "Description", constructors, map[string]map[string]interface{}{}, meta)
+}
+
+func TestFilterShim(t *testing.T) {
+	cases := []struct {
+		name     string
+		filter   *jqlpb.Filter
+		expected []string
+	}{
+		{
+			name: "prefix match",
+			filter: &jqlpb.Filter{
+				Column: "Description",
+				Match:  &jqlpb.Filter_PrefixMatch{PrefixMatch: &jqlpb.PrefixMatch{Value: "[ENV] "}},
+			},
+			expected: []string{"[ENV] add filters", "[ENV] fix build"},
+		},
+		{
+			name: "negated prefix match",
+			filter: &jqlpb.Filter{
+				Negated: true,
+				Column:  "Description",
+				Match:   &jqlpb.Filter_PrefixMatch{PrefixMatch: &jqlpb.PrefixMatch{Value: "[ENV] "}},
+			},
+			expected: []string{"Plan for Monday", "nouns jql"},
+		},
+		{
+			name: "regex match",
+			filter: &jqlpb.Filter{
+				Column: "Description",
+				Match:  &jqlpb.Filter_RegexMatch{RegexMatch: &jqlpb.RegexMatch{Value: `^(nouns|Plan for) `}},
+			},
+			expected: []string{"Plan for Monday", "nouns jql"},
+		},
+		{
+			name: "regex match on any field",
+			filter: &jqlpb.Filter{
+				Column: "Any field",
+				Match:  &jqlpb.Filter_RegexMatch{RegexMatch: &jqlpb.RegexMatch{Value: `^(Pending|Satisfied)$`}},
+			},
+			expected: []string{"[ENV] add filters", "nouns jql"},
+		},
+		{
+			name: "less than match",
+			filter: &jqlpb.Filter{
+				Column: "Status",
+				Match:  &jqlpb.Filter_LessThanMatch{LessThanMatch: &jqlpb.LessThanMatch{Value: "Pending"}},
+			},
+			expected: []string{"Plan for Monday", "[ENV] fix build"},
+		},
+		{
+			name: "greater than match",
+			filter: &jqlpb.Filter{
+				Column: "Status",
+				Match:  &jqlpb.Filter_GreatherThanMatch{GreatherThanMatch: &jqlpb.GreaterThanMatch{Value: "Pending"}},
+			},
+			expected: []string{"nouns jql"},
+		},
+		{
+			name: "range match without a column",
+			filter: &jqlpb.Filter{
+				Match: &jqlpb.Filter_LessThanMatch{LessThanMatch: &jqlpb.LessThanMatch{Value: "Pending"}},
+			},
+			expected: []string{},
+		},
+		{
+			name: "tag membership",
+			filter: &jqlpb.Filter{
+				Column: "Tags",
+				Match:  &jqlpb.Filter_ContainsMatch{ContainsMatch: &jqlpb.ContainsMatch{Value: "env", Exact: true}},
+			},
+			expected: []string{"[ENV] add filters", "[ENV] fix build"},
+		},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			table := newTestTable()
+			shim, err := newFilterShim(tc.filter, table)
+			require.NoError(t, err)
+			actual := []string{}
+			for pk, row := range table.Entries {
+				if shim.Applies(row) {
+					actual = append(actual, pk)
+				}
+			}
+			sort.Strings(actual)
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestRangeFilterOnEmptyTable(t *testing.T) {
+	table := newTestTable()
+	table.Entries = map[string][]types.Entry{}
+	shim, err := newFilterShim(&jqlpb.Filter{
+		Column: "Status",
+		Match:  &jqlpb.Filter_LessThanMatch{LessThanMatch: &jqlpb.LessThanMatch{Value: "Pending"}},
+	}, table)
+	require.NoError(t, err)
+	require.True(t, shim.Applies([]types.Entry{types.String("Plan for Monday"), types.String("Active"), types.Tags{}}))
+}
+
+func TestInvalidRegex(t *testing.T) {
+	_, err := newFilterShim(&jqlpb.Filter{
+		Column: "Description",
+		Match:  &jqlpb.Filter_RegexMatch{RegexMatch: &jqlpb.RegexMatch{Value: `[ENV`}},
+	}, newTestTable())
+	require.Error(t, err)
+}
+
+func TestFilterSuggestions(t *testing.T) {
+	cases := []struct {
+		name        string
+		filter      *jqlpb.Filter
+		description string
+		suggestion  string
+		suggests    bool
+	}{
+		{
+			name: "prefix",
+			filter: &jqlpb.Filter{
+				Column: "Description",
+				Match:  &jqlpb.Filter_PrefixMatch{PrefixMatch: &jqlpb.PrefixMatch{Value: "Plan for"}},
+			},
+			description: `Description starts with "Plan for"`,
+			suggestion:  "Plan for",
+			suggests:    true,
+		},
+		{
+			name: "anchored regex",
+			filter: &jqlpb.Filter{
+				Column: "Description",
+				Match:  &jqlpb.Filter_RegexMatch{RegexMatch: &jqlpb.RegexMatch{Value: `^\[ENV\] .*`}},
+			},
+			description: `Description matches /^\[ENV\] .*/`,
+			suggestion:  "[ENV] ",
+			suggests:    true,
+		},
+		{
+			name: "unanchored regex",
+			filter: &jqlpb.Filter{
+				Negated: true,
+				Column:  "Description",
+				Match:   &jqlpb.Filter_RegexMatch{RegexMatch: &jqlpb.RegexMatch{Value: `jql`}},
+			},
+			description: `Description does not match /jql/`,
+		},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			require.Equal(t, tc.description, Description(tc.filter))
+			suggestion, suggests := PrimarySuggestion(tc.filter)
+			require.Equal(t, tc.suggestion, suggestion)
+			require.Equal(t, tc.suggests, suggests)
+		})
+	}
+}
@@ -0,0 +1,399 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ulmenhaus/env/proto/jql/jqlpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// HTTPGateway exposes the JQL service over HTTP with JSON bodies. Every RPC
+// is available at POST /rpc/{method} with the protojson encoding of its
+// request as the body. The more common RPCs also have RESTful routes:
+//
+//	GET    /tables                              ListTables
+//	GET    /tables/{table}/rows?filter=Col=val  ListRows
+//	POST   /tables/{table}/rows                 WriteRow with a generated pk
+//	GET    /tables/{table}/rows/{pk}            GetRow
+//	PUT    /tables/{table}/rows/{pk}            WriteRow inserting or updating
+//	PATCH  /tables/{table}/rows/{pk}            WriteRow with update_only
+//	DELETE /tables/{table}/rows/{pk}            DeleteRow
+//	POST   /tables/{table}/rows/{pk}/increment  IncrementEntry
+//	POST   /persist                             Persist
+//	GET    /stats                               Stats
+//
+// Writes take the fields of the row as a JSON object of strings. Requests other
+// than GETs must have a JSON content type so that browsers can't forge them
+// from other origins. Requests go through the same interceptors as gRPC
+// requests and are identified by their client certificate when served over
+// TLS. Snapshot RPCs are only available to clients with a certificate.
+type HTTPGateway struct {
+	server      jqlpb.JQLServer
+	interceptor grpc.UnaryServerInterceptor
+	mux         *http.ServeMux
+}
+
+// NewHTTPGateway returns a gateway to the provided server that applies the
+// interceptors to every request in order
+func NewHTTPGateway(server jqlpb.JQLServer, interceptors ...grpc.UnaryServerInterceptor) *HTTPGateway {
+	g := &HTTPGateway{
+		server:      server,
+		interceptor: chainInterceptors(interceptors),
+		mux:         http.NewServeMux(),
+	}
+	g.mux.HandleFunc("POST /rpc/{method}", g.handleRPC)
+	g.mux.HandleFunc("GET /tables", g.handleListTables)
+	g.mux.HandleFunc("GET /tables/{table}/rows", g.handleListRows)
+	g.mux.HandleFunc("POST /tables/{table}/rows", g.handleWriteRow(false))
+	g.mux.HandleFunc("GET /tables/{table}/rows/{pk}", g.handleGetRow)
+	g.mux.HandleFunc("PUT /tables/{table}/rows/{pk}", g.handlePutRow)
+	g.mux.HandleFunc("PATCH /tables/{table}/rows/{pk}", g.handleWriteRow(true))
+	g.mux.HandleFunc("DELETE /tables/{table}/rows/{pk}", g.handleDeleteRow)
+	g.mux.HandleFunc("POST /tables/{table}/rows/{pk}/increment", g.handleIncrement)
+	g.mux.HandleFunc("POST /persist", g.handlePersist)
+	g.mux.HandleFunc("GET /stats", g.handleStats)
+	return g
+}
+
+// ServeHTTP implements http.Handler
+func (g *HTTPGateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if mediaType != "application/json" {
+			writeHTTPStatus(w, http.StatusUnsupportedMediaType, "content type must be application/json")
+			return
+		}
+	}
+	g.mux.ServeHTTP(w, r)
+}
+
+// authenticated returns true iff the client presented a verified certificate
+func authenticated(r *http.Request) bool {
+	return r.TLS != nil && len(r.TLS.VerifiedChains) > 0
+}
+
+// requestContext returns the context of the request with the client as its
+// peer so that interceptors can identify it the same way as a gRPC client
+func requestContext(r *http.Request) context.Context {
+	pr := &peer.Peer{}
+	if addr, err := net.ResolveTCPAddr("tcp", r.RemoteAddr); err == nil {
+		pr.Addr = addr
+	}
+	if r.TLS != nil {
+		pr.AuthInfo = credentials.TLSInfo{State: *r.TLS}
+	}
+	return peer.NewContext(r.Context(), pr)
+}
+
+// chainInterceptors combines the interceptors into one that applies them in
+// order, the same way grpc.ChainUnaryInterceptor does for a server
+func chainInterceptors(interceptors []grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		next := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(ctx, req, info, inner)
+			}
+		}
+		return next(ctx, req)
+	}
+}
+
+// rpc describes how to construct the request for and invoke a single method
+type rpc struct {
+	newRequest func() proto.Message
+	invoke     func(s jqlpb.JQLServer, ctx context.Context, req proto.Message) (proto.Message, error)
+}
+
+func newRPC[Req proto.Message, Resp proto.Message](newRequest func() Req, invoke func(jqlpb.JQLServer, context.Context, Req) (Resp, error)) rpc {
+	return rpc{
+		newRequest: func() proto.Message { return newRequest() },
+		invoke: func(s jqlpb.JQLServer, ctx context.Context, req proto.Message) (proto.Message, error) {
+			return invoke(s, ctx, req.(Req))
+		},
+	}
+}
+
+var rpcs = map[string]rpc{
+	"ListTables":     newRPC(func() *jqlpb.ListTablesRequest { return &jqlpb.ListTablesRequest{} }, jqlpb.JQLServer.ListTables),
+	"ListRows":       newRPC(func() *jqlpb.ListRowsRequest { return &jqlpb.ListRowsRequest{} }, jqlpb.JQLServer.ListRows),
+	"GetRow":         newRPC(func() *jqlpb.GetRowRequest { return &jqlpb.GetRowRequest{} }, jqlpb.JQLServer.GetRow),
+	"WriteRow":       newRPC(func() *jqlpb.WriteRowRequest { return &jqlpb.WriteRowRequest{} }, jqlpb.JQLServer.WriteRow),
+	"DeleteRow":      newRPC(func() *jqlpb.DeleteRowRequest { return &jqlpb.DeleteRowRequest{} }, jqlpb.JQLServer.DeleteRow),
+	"IncrementEntry": newRPC(func() *jqlpb.IncrementEntryRequest { return &jqlpb.IncrementEntryRequest{} }, jqlpb.JQLServer.IncrementEntry),
+	"Persist":        newRPC(func() *jqlpb.PersistRequest { return &jqlpb.PersistRequest{} }, jqlpb.JQLServer.Persist),
+	"GetSnapshot":    newRPC(func() *jqlpb.GetSnapshotRequest { return &jqlpb.GetSnapshotRequest{} }, jqlpb.JQLServer.GetSnapshot),
+	"LoadSnapshot":   newRPC(func() *jqlpb.LoadSnapshotRequest { return &jqlpb.LoadSnapshotRequest{} }, jqlpb.JQLServer.LoadSnapshot),
+	"MigrateEnum":    newRPC(func() *jqlpb.MigrateEnumRequest { return &jqlpb.MigrateEnumRequest{} }, jqlpb.JQLServer.MigrateEnum),
+	"Stats":          newRPC(func() *jqlpb.StatsRequest { return &jqlpb.StatsRequest{} }, jqlpb.JQLServer.Stats),
+}
+
+// authenticatedRPCs are the RPCs that can read or replace the whole database
+// and so are only available to clients with a certificate
+var authenticatedRPCs = map[string]bool{
+	"GetSnapshot":  true,
+	"LoadSnapshot": true,
+}
+
+// invoke invokes the named method through the interceptors
+func (g *HTTPGateway) invoke(r *http.Request, method string, req proto.Message) (interface{}, error) {
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return rpcs[method].invoke(g.server, ctx, req.(proto.Message))
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     g.server,
+		FullMethod: fmt.Sprintf("/%s/%s", jqlpb.JQL_ServiceDesc.ServiceName, method),
+	}
+	return g.interceptor(requestContext(r), req, info, handler)
+}
+
+// call invokes the named method through the interceptors and writes the
+// response or error
+func (g *HTTPGateway) call(w http.ResponseWriter, r *http.Request, method string, req proto.Message) {
+	resp, err := g.invoke(r, method, req)
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	body, err := protojson.Marshal(resp.(proto.Message))
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+// writeHTTPError writes the error with a status code corresponding to its
+// gRPC code
+func writeHTTPError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		code = http.StatusBadRequest
+	case codes.NotFound:
+		code = http.StatusNotFound
+	case codes.PermissionDenied:
+		code = http.StatusForbidden
+	case codes.Unauthenticated:
+		code = http.StatusUnauthorized
+	case codes.Unknown:
+		if IsNotExistError(err) {
+			code = http.StatusNotFound
+		}
+	}
+	writeHTTPStatus(w, code, status.Convert(err).Message())
+}
+
+// writeHTTPStatus writes the status code with the message as the error
+func writeHTTPStatus(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
+
+func badRequest(w http.ResponseWriter, format string, args ...interface{}) {
+	writeHTTPError(w, status.Errorf(codes.InvalidArgument, format, args...))
+}
+
+func (g *HTTPGateway) handleRPC(w http.ResponseWriter, r *http.Request) {
+	method := r.PathValue("method")
+	desc, ok := rpcs[method]
+	if !ok {
+		writeHTTPError(w, status.Errorf(codes.NotFound, "unknown method: %s", method))
+		return
+	}
+	if authenticatedRPCs[method] && !authenticated(r) {
+		writeHTTPError(w, status.Errorf(codes.Unauthenticated, "%s requires a client certificate", method))
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		badRequest(w, "failed to read body: %s", err)
+		return
+	}
+	req := desc.newRequest()
+	if len(body) > 0 {
+		if err := protojson.Unmarshal(body, req); err != nil {
+			badRequest(w, "invalid %s request: %s", method, err)
+			return
+		}
+	}
+	g.call(w, r, method, req)
+}
+
+func (g *HTTPGateway) handleListTables(w http.ResponseWriter, r *http.Request) {
+	g.call(w, r, "ListTables", &jqlpb.ListTablesRequest{})
+}
+
+func (g *HTTPGateway) handleListRows(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := &jqlpb.ListRowsRequest{
+		Table:   r.PathValue("table"),
+		OrderBy: query.Get("order_by"),
+		Dec:     query.Get("dec") == "true",
+	}
+	for _, param := range []struct {
+		name string
+		dst  *uint32
+	}{{"offset", &req.Offset}, {"limit", &req.Limit}} {
+		if s := query.Get(param.name); s != "" {
+			n, err := strconv.ParseUint(s, 10, 32)
+			if err != nil {
+				badRequest(w, "invalid %s: %s", param.name, s)
+				return
+			}
+			*param.dst = uint32(n)
+		}
+	}
+	filters := []*jqlpb.Filter{}
+	for _, s := range query["filter"] {
+		filter, err := ParseHTTPFilter(s)
+		if err != nil {
+			badRequest(w, "%s", err)
+			return
+		}
+		filters = append(filters, filter)
+	}
+	if len(filters) > 0 {
+		req.Conditions = []*jqlpb.Condition{{Requires: filters}}
+	}
+	g.call(w, r, "ListRows", req)
+}
+
+// ParseHTTPFilter parses a filter given as a query parameter. Filters take the
+// form Column=value, Column!=value, Column<value, Column>value, or
+// Column~regex. More complex queries can be made with POST /rpc/ListRows.
+func ParseHTTPFilter(s string) (*jqlpb.Filter, error) {
+	i := strings.IndexAny(s, "=!<>~")
+	if i <= 0 {
+		return nil, fmt.Errorf("invalid filter: %s", s)
+	}
+	filter := &jqlpb.Filter{Column: s[:i]}
+	op, value := s[i:i+1], s[i+1:]
+	if op == "!" {
+		if !strings.HasPrefix(value, "=") {
+			return nil, fmt.Errorf("invalid filter: %s", s)
+		}
+		op, value = "=", value[1:]
+		filter.Negated = true
+	}
+	switch op {
+	case "=":
+		filter.Match = &jqlpb.Filter_EqualMatch{EqualMatch: &jqlpb.EqualMatch{Value: value}}
+	case "<":
+		filter.Match = &jqlpb.Filter_LessThanMatch{LessThanMatch: &jqlpb.LessThanMatch{Value: value}}
+	case ">":
+		filter.Match = &jqlpb.Filter_GreatherThanMatch{GreatherThanMatch: &jqlpb.GreaterThanMatch{Value: value}}
+	case "~":
+		filter.Match = &jqlpb.Filter_RegexMatch{RegexMatch: &jqlpb.RegexMatch{Value: value}}
+	}
+	return filter, nil
+}
+
+func (g *HTTPGateway) handleGetRow(w http.ResponseWriter, r *http.Request) {
+	g.call(w, r, "GetRow", &jqlpb.GetRowRequest{
+		Table: r.PathValue("table"),
+		Pk:    r.PathValue("pk"),
+	})
+}
+
+func (g *HTTPGateway) handleWriteRow(updateOnly bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		fields, ok := decodeFields(w, r)
+		if !ok {
+			return
+		}
+		g.call(w, r, "WriteRow", &jqlpb.WriteRowRequest{
+			Table:      r.PathValue("table"),
+			Pk:         r.PathValue("pk"),
+			Fields:     fields,
+			UpdateOnly: updateOnly,
+		})
+	}
+}
+
+// handlePutRow inserts the row if it doesn't exist and otherwise updates it
+// since an upsert of an existing row leaves it unchanged
+func (g *HTTPGateway) handlePutRow(w http.ResponseWriter, r *http.Request) {
+	fields, ok := decodeFields(w, r)
+	if !ok {
+		return
+	}
+	req := &jqlpb.WriteRowRequest{
+		Table:  r.PathValue("table"),
+		Pk:     r.PathValue("pk"),
+		Fields: fields,
+	}
+	_, err := g.invoke(r, "GetRow", &jqlpb.GetRowRequest{Table: req.Table, Pk: req.Pk})
+	switch {
+	case err == nil:
+		req.UpdateOnly = true
+	case IsNotExistError(err):
+		req.InsertOnly = true
+	default:
+		writeHTTPError(w, err)
+		return
+	}
+	g.call(w, r, "WriteRow", req)
+}
+
+// decodeFields decodes the fields of a write from the body or writes an error
+// and returns false if they're invalid
+func decodeFields(w http.ResponseWriter, r *http.Request) (map[string]string, bool) {
+	fields := map[string]string{}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&fields); err != nil {
+			badRequest(w, "fields must be a JSON object of strings: %s", err)
+			return nil, false
+		}
+	}
+	return fields, true
+}
+
+func (g *HTTPGateway) handleDeleteRow(w http.ResponseWriter, r *http.Request) {
+	g.call(w, r, "DeleteRow", &jqlpb.DeleteRowRequest{
+		Table: r.PathValue("table"),
+		Pk:    r.PathValue("pk"),
+	})
+}
+
+func (g *HTTPGateway) handleIncrement(w http.ResponseWriter, r *http.Request) {
+	body := struct {
+		Column string `json:"column"`
+		Amount int32  `json:"amount"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		badRequest(w, "invalid increment: %s", err)
+		return
+	}
+	g.call(w, r, "IncrementEntry", &jqlpb.IncrementEntryRequest{
+		Table:  r.PathValue("table"),
+		Pk:     r.PathValue("pk"),
+		Column: body.Column,
+		Amount: body.Amount,
+	})
+}
+
+func (g *HTTPGateway) handlePersist(w http.ResponseWriter, r *http.Request) {
+	g.call(w, r, "Persist", &jqlpb.PersistRequest{})
+}
+
+func (g *HTTPGateway) handleStats(w http.ResponseWriter, r *http.Request) {
+	g.call(w, r, "Stats", &jqlpb.StatsRequest{})
+}
@@ -0,0 +1,197 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ulmenhaus/env/proto/jql/jqlpb"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestHTTPGateway(t *testing.T) {
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		// contentType defaults to application/json
+		contentType string
+		code        int
+		response    proto.Message
+		check       func(t *testing.T, resp proto.Message)
+	}{
+		{
+			name:     "list tables",
+			method:   http.MethodGet,
+			path:     "/tables",
+			code:     http.StatusOK,
+			response: &jqlpb.ListTablesResponse{},
+			check: func(t *testing.T, resp proto.Message) {
+				require.Len(t, resp.(*jqlpb.ListTablesResponse).Tables, 1)
+			},
+		},
+		{
+			name:     "list rows with filter",
+			method:   http.MethodGet,
+			path:     "/tables/tasks/rows?filter=Status!%3DActive&order_by=Name",
+			code:     http.StatusOK,
+			response: &jqlpb.ListRowsResponse{},
+			check: func(t *testing.T, resp proto.Message) {
+				rows := resp.(*jqlpb.ListRowsResponse).Rows
+				require.Len(t, rows, 2)
+				require.Equal(t, "add filters", rows[0].Entries[0].Formatted)
+			},
+		},
+		{
+			name:     "put row",
+			method:   http.MethodPut,
+			path:     "/tables/tasks/rows/write%20docs",
+			body:     `{"Status": "Pending"}`,
+			code:     http.StatusOK,
+			response: &jqlpb.WriteRowResponse{},
+			check: func(t *testing.T, resp proto.Message) {
+				require.Equal(t, "write docs", resp.(*jqlpb.WriteRowResponse).Pk)
+			},
+		},
+		{
+			name:     "get row",
+			method:   http.MethodGet,
+			path:     "/tables/tasks/rows/fix%20build",
+			code:     http.StatusOK,
+			response: &jqlpb.GetRowResponse{},
+			check: func(t *testing.T, resp proto.Message) {
+				row := resp.(*jqlpb.GetRowResponse)
+				require.Equal(t, "Active", row.Row.Entries[IndexOfField(row.Columns, "Status")].Formatted)
+			},
+		},
+		{
+			name:   "get missing row",
+			method: http.MethodGet,
+			path:   "/tables/tasks/rows/nope",
+			code:   http.StatusNotFound,
+		},
+		{
+			name:     "generic rpc",
+			method:   http.MethodPost,
+			path:     "/rpc/ListRows",
+			body:     `{"table": "tasks", "conditions": [{"requires": [{"column": "Name", "prefixMatch": {"value": "fix"}}]}]}`,
+			code:     http.StatusOK,
+			response: &jqlpb.ListRowsResponse{},
+			check: func(t *testing.T, resp proto.Message) {
+				require.Len(t, resp.(*jqlpb.ListRowsResponse).Rows, 1)
+			},
+		},
+		{
+			name:   "unknown rpc",
+			method: http.MethodPost,
+			path:   "/rpc/DropDatabase",
+			code:   http.StatusNotFound,
+		},
+		{
+			name:   "snapshot without client certificate",
+			method: http.MethodPost,
+			path:   "/rpc/GetSnapshot",
+			code:   http.StatusUnauthorized,
+		},
+		{
+			name:        "text body",
+			method:      http.MethodPost,
+			path:        "/rpc/LoadSnapshot",
+			body:        `{}`,
+			contentType: "text/plain",
+			code:        http.StatusUnsupportedMediaType,
+		},
+		{
+			name:        "form body",
+			method:      http.MethodPut,
+			path:        "/tables/tasks/rows/write%20docs",
+			body:        `Status=Pending`,
+			contentType: "application/x-www-form-urlencoded",
+			code:        http.StatusUnsupportedMediaType,
+		},
+		{
+			name:   "invalid filter",
+			method: http.MethodGet,
+			path:   "/tables/tasks/rows?filter=Status",
+			code:   http.StatusBadRequest,
+		},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			gateway := NewHTTPGateway(NewDBMSShim(newTestDBMS(t, testSnapshot)))
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			if tc.contentType == "" {
+				tc.contentType = "application/json"
+			}
+			req.Header.Set("Content-Type", tc.contentType)
+			rec := httptest.NewRecorder()
+			gateway.ServeHTTP(rec, req)
+			body, err := io.ReadAll(rec.Body)
+			require.NoError(t, err)
+			require.Equal(t, tc.code, rec.Code, string(body))
+			if tc.response != nil {
+				require.NoError(t, protojson.Unmarshal(body, tc.response))
+				tc.check(t, tc.response)
+			}
+		})
+	}
+}
+
+func TestHTTPPutRow(t *testing.T) {
+	dbms := newTestDBMS(t, testSnapshot)
+	gateway := NewHTTPGateway(NewDBMSShim(dbms))
+	for _, pk := range []string{"fix%20build", "write%20docs"} {
+		req := httptest.NewRequest(http.MethodPut, "/tables/tasks/rows/"+pk, strings.NewReader(`{"Status": "Satisfied"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		gateway.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	}
+	_, statuses := listColumn(t, dbms, "tasks", "Status")
+	require.Equal(t, "Satisfied", statuses["fix build"])
+	require.Equal(t, "Satisfied", statuses["write docs"])
+}
+
+func TestParseHTTPFilter(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected *jqlpb.Filter
+		err      bool
+	}{
+		{
+			input:    "Status=Active",
+			expected: &jqlpb.Filter{Column: "Status", Match: &jqlpb.Filter_EqualMatch{EqualMatch: &jqlpb.EqualMatch{Value: "Active"}}},
+		},
+		{
+			input:    "Status!=Active",
+			expected: &jqlpb.Filter{Column: "Status", Negated: true, Match: &jqlpb.Filter_EqualMatch{EqualMatch: &jqlpb.EqualMatch{Value: "Active"}}},
+		},
+		{
+			input:    "Start>2026-10-01",
+			expected: &jqlpb.Filter{Column: "Start", Match: &jqlpb.Filter_GreatherThanMatch{GreatherThanMatch: &jqlpb.GreaterThanMatch{Value: "2026-10-01"}}},
+		},
+		{
+			input:    "Name~^fix.*=",
+			expected: &jqlpb.Filter{Column: "Name", Match: &jqlpb.Filter_RegexMatch{RegexMatch: &jqlpb.RegexMatch{Value: "^fix.*="}}},
+		},
+		{input: "=Active", err: true},
+		{input: "Status!Active", err: true},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.input), func(t *testing.T) {
+			filter, err := ParseHTTPFilter(tc.input)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, proto.Equal(tc.expected, filter), filter.String())
+		})
+	}
+}
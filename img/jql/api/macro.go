@@ -4,15 +4,62 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io/ioutil"
+	"net"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/ulmenhaus/env/proto/jql/jqlpb"
+	"google.golang.org/grpc"
 )
 
+// macroPayloadSize is the largest message the ephemeral server of a v3 macro
+// will send or receive
+const macroPayloadSize = 100000000 // 100 Mb
+
+const (
+	// DefaultMacroTimeout is how long a macro may run when the caller
+	// doesn't set a deadline
+	DefaultMacroTimeout = time.Minute
+	// macroKillDelay is how long a macro has to exit after being
+	// interrupted before it's killed
+	macroKillDelay = 5 * time.Second
+)
+
+// A MacroProtocol is the interface through which a macro reads and modifies
+// the database
+type MacroProtocol int
+
+const (
+	// MacroV1 macros receive a snapshot of the whole database and return
+	// the modified snapshot
+	MacroV1 MacroProtocol = iota + 1
+	// MacroV2 macros receive a snapshot when the database is local and the
+	// address of the daemon otherwise
+	MacroV2
+	// MacroV3 macros always receive the address of a gRPC server and only
+	// return the current view. When the database is local it's served
+	// from an ephemeral Unix socket while the macro runs.
+	MacroV3
+)
+
+// ParseMacroProtocol parses a protocol of the form "v1", "v2" or "v3"
+func ParseMacroProtocol(s string) (MacroProtocol, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "v1", "1":
+		return MacroV1, nil
+	case "v2", "2":
+		return MacroV2, nil
+	case "v3", "3":
+		return MacroV3, nil
+	}
+	return 0, fmt.Errorf("Unknown macro protocol: %s", s)
+}
+
 type MacroResponseFilter struct {
 	Field     string `json:"field"`
 	Formatted string `json:"formatted"`
@@ -31,9 +78,13 @@ type MacroCurrentView struct {
 	OrderDec        bool                `json:"order_dec"`
 	GroupBy         string              `json:"group_by"`
 	GroupBySelected string              `json:"group_by_selected"`
+	// SelectedPKs are the pks of the rows the user selected for a bulk
+	// operation, if any
+	SelectedPKs []string `json:"selected_pks,omitempty"`
 }
 
 type MacroInterface struct {
+	Version     int              `json:"version"`
 	Snapshot    string           `json:"snapshot"`
 	Address     string           `json:"address"`
 	TLSCert     string           `json:"tls_cert"`
@@ -42,12 +93,56 @@ type MacroInterface struct {
 	CurrentView MacroCurrentView `json:"current_view"`
 }
 
-func RunMacro(ctx context.Context, dbms JQL_DBMS, command string, currentView MacroCurrentView, v2 bool) (*MacroInterface, error) {
+// A MacroError is returned when a macro fails to run
+type MacroError struct {
+	Err error
+	// LogPath is the path of the macro's log
+	LogPath string
+	// Log is what was logged for the failed run
+	Log string
+}
+
+func (e *MacroError) Error() string {
+	if e.LogPath == "" {
+		return fmt.Sprintf("Could not run macro: %s", e.Err)
+	}
+	return fmt.Sprintf("Could not run macro: %s -- log at %s", e.Err, e.LogPath)
+}
+
+func (e *MacroError) Unwrap() error {
+	return e.Err
+}
+
+// RunMacro runs the command as a macro using the provided protocol. The
+// command is split into arguments the way a shell would. Cancelling the
+// context interrupts the macro and DefaultMacroTimeout applies if the context
+// has no deadline. Every run is logged under the database directory.
+func RunMacro(ctx context.Context, dbms JQL_DBMS, command string, currentView MacroCurrentView, protocol MacroProtocol) (*MacroInterface, error) {
+	args, err := splitCommand(command)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid macro command: %s", err)
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("Invalid macro command: no program provided")
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultMacroTimeout)
+		defer cancel()
+	}
 	var stdout, stderr bytes.Buffer
 	input := MacroInterface{
+		Version:     int(protocol),
 		CurrentView: currentView,
 	}
-	if v2 {
+	switch protocol {
+	case MacroV1:
+		snapResp, err := dbms.GetSnapshot(ctx, &jqlpb.GetSnapshotRequest{})
+		if err != nil {
+			return nil, fmt.Errorf("Could not create snapshot: %s", err)
+		}
+		input.Snapshot = string(snapResp.Snapshot)
+	case MacroV2:
 		switch typed := dbms.(type) {
 		case *LocalDBMS:
 			snapResp, err := dbms.GetSnapshot(ctx, &jqlpb.GetSnapshotRequest{})
@@ -56,39 +151,56 @@ func RunMacro(ctx context.Context, dbms JQL_DBMS, command string, currentView Ma
 			}
 			input.Snapshot = string(snapResp.Snapshot)
 		case *RemoteDBMS:
-			input.Address = typed.Address
-			input.TLSCert = typed.TLSCert
-			input.TLSKey = typed.TLSKey
-			input.TLSCA = typed.TLSCA
+			input.setRemote(typed)
 		default:
 			return nil, fmt.Errorf("Unknown dbms type for v2 macro: %T", dbms)
 		}
-	} else {
-		snapResp, err := dbms.GetSnapshot(ctx, &jqlpb.GetSnapshotRequest{})
-		if err != nil {
-			return nil, fmt.Errorf("Could not create snapshot: %s", err)
+	case MacroV3:
+		switch typed := dbms.(type) {
+		case *LocalDBMS:
+			address, stop, err := serveMacro(typed)
+			if err != nil {
+				return nil, fmt.Errorf("Could not serve database to macro: %s", err)
+			}
+			defer stop()
+			input.Address = address
+		case *RemoteDBMS:
+			input.setRemote(typed)
+		default:
+			return nil, fmt.Errorf("Unknown dbms type for v3 macro: %T", dbms)
 		}
-		input.Snapshot = string(snapResp.Snapshot)
+	default:
+		return nil, fmt.Errorf("Unknown macro protocol: %d", protocol)
 	}
 	inputEncoded, err := json.Marshal(input)
 	if err != nil {
 		return nil, fmt.Errorf("Could not marshal input: %s", err)
 	}
-	parts := strings.Split(command, " ")
-	cmd := exec.Command(parts[0], parts[1:]...)
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	// give the macro a chance to clean up before it's killed
+	cmd.Cancel = func() error {
+		return cmd.Process.Signal(os.Interrupt)
+	}
+	cmd.WaitDelay = macroKillDelay
 	cmd.Stdin = bytes.NewBuffer(inputEncoded)
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
+	start := time.Now()
 	err = cmd.Run()
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		err = fmt.Errorf("timed out after %s", time.Since(start).Round(time.Second))
+	case errors.Is(ctx.Err(), context.Canceled):
+		err = fmt.Errorf("cancelled")
+	}
+	entry := formatMacroLogEntry(command, start, err, stdout.Bytes(), stderr.Bytes())
+	logPath, logErr := appendMacroLog(macroLogDir(dbms), macroLogName(args), entry)
 	if err != nil {
-		// TODO log stderr
-		writeErr := ioutil.WriteFile("/tmp/error.log", stderr.Bytes(), os.ModePerm)
-		if writeErr != nil {
-			return nil, fmt.Errorf("Could not run macro or store stderr: %s", err)
+		if logErr != nil {
+			return nil, &MacroError{Err: fmt.Errorf("%s (could not write log: %s)", err, logErr), Log: entry}
 		}
-		return nil, fmt.Errorf("Could not run macro: %s -- error at /tmp/error.log", err)
+		return nil, &MacroError{Err: err, LogPath: logPath, Log: entry}
 	}
-	var newDB []byte
 	var output MacroInterface
 
 	// TODO change to three valued "Output" field: file, stdout, none
@@ -96,27 +208,133 @@ func RunMacro(ctx context.Context, dbms JQL_DBMS, command string, currentView Ma
 	if err != nil {
 		return nil, fmt.Errorf("Could not unmarshal macro output: %s", err)
 	}
-	newDB = []byte(output.Snapshot)
 
-	if v2 {
-		switch dbms.(type) {
-		case *LocalDBMS:
-			_, err = dbms.LoadSnapshot(ctx, &jqlpb.LoadSnapshotRequest{
-				Snapshot: newDB,
-			})
-			if err != nil {
-				return nil, fmt.Errorf("Could not load database from macro: %s", err)
-			}
-		default:
-			// TODO pass remove info
-		}
-	} else {
+	// v3 macros and v2 macros of remote databases modify the database
+	// directly so there's no snapshot to load
+	_, local := dbms.(*LocalDBMS)
+	if protocol == MacroV1 || (protocol == MacroV2 && local) {
 		_, err = dbms.LoadSnapshot(ctx, &jqlpb.LoadSnapshotRequest{
-			Snapshot: newDB,
+			Snapshot: []byte(output.Snapshot),
 		})
 		if err != nil {
 			return nil, fmt.Errorf("Could not load database from macro: %s", err)
 		}
 	}
+	// TODO pass remove info for v2 macros of remote databases
 	return &output, nil
 }
+
+// splitCommand splits a command into arguments the way a shell would,
+// honoring single quotes, double quotes and backslash escapes
+func splitCommand(command string) ([]string, error) {
+	commands, err := tokenize(command, false)
+	if err != nil {
+		return nil, err
+	}
+	return commands[0], nil
+}
+
+// splitPipeline splits a pipeline of commands separated by unquoted pipes
+// into the arguments of each command
+func splitPipeline(pipeline string) ([][]string, error) {
+	return tokenize(pipeline, true)
+}
+
+// tokenize splits the input into arguments the way a shell would. When pipes
+// is set an unquoted pipe also ends the current command.
+func tokenize(input string, pipes bool) ([][]string, error) {
+	commands := [][]string{}
+	args := []string{}
+	var current strings.Builder
+	inArg := false
+	var quote rune
+	escaped := false
+	endArg := func() {
+		if inArg {
+			args = append(args, current.String())
+			current.Reset()
+			inArg = false
+		}
+	}
+	for _, ch := range input {
+		switch {
+		case escaped:
+			// within double quotes a backslash only escapes characters
+			// that are special within them
+			if quote == '"' && !strings.ContainsRune("\\\"$`", ch) {
+				current.WriteRune('\\')
+			}
+			current.WriteRune(ch)
+			escaped = false
+		case quote == '\'':
+			if ch == '\'' {
+				quote = 0
+			} else {
+				current.WriteRune(ch)
+			}
+		case ch == '\\':
+			escaped = true
+			inArg = true
+		case quote == '"':
+			if ch == '"' {
+				quote = 0
+			} else {
+				current.WriteRune(ch)
+			}
+		case ch == '\'' || ch == '"':
+			quote = ch
+			inArg = true
+		case ch == ' ' || ch == '\t' || ch == '\n':
+			endArg()
+		case ch == '|' && pipes:
+			endArg()
+			commands = append(commands, args)
+			args = []string{}
+		default:
+			current.WriteRune(ch)
+			inArg = true
+		}
+	}
+	if escaped {
+		return nil, fmt.Errorf("trailing backslash")
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	endArg()
+	return append(commands, args), nil
+}
+
+func (i *MacroInterface) setRemote(dbms *RemoteDBMS) {
+	i.Address = dbms.Address
+	i.TLSCert = dbms.TLSCert
+	i.TLSKey = dbms.TLSKey
+	i.TLSCA = dbms.TLSCA
+}
+
+// serveMacro serves the database over gRPC on a Unix socket that only the
+// current user can access and returns the address of the socket. The caller
+// must call stop once the macro has finished.
+func serveMacro(dbms *LocalDBMS) (string, func(), error) {
+	dir, err := os.MkdirTemp("", "jql-macro-")
+	if err != nil {
+		return "", nil, err
+	}
+	path := filepath.Join(dir, "jql.sock")
+	lis, err := net.Listen("unix", path)
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", nil, err
+	}
+	server := grpc.NewServer(
+		grpc.MaxSendMsgSize(macroPayloadSize),
+		grpc.MaxRecvMsgSize(macroPayloadSize),
+	)
+	jqlpb.RegisterJQLServer(server, NewDBMSShim(dbms))
+	go server.Serve(lis)
+	stop := func() {
+		server.Stop()
+		os.RemoveAll(dir)
+	}
+	return "unix://" + path, stop, nil
+}
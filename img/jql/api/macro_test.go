@@ -0,0 +1,241 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ulmenhaus/env/proto/jql/jqlpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestParseMacroProtocol(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		expected MacroProtocol
+		err      bool
+	}{
+		{name: "v1", input: "v1", expected: MacroV1},
+		{name: "bare number", input: "2", expected: MacroV2},
+		{name: "upper case", input: "V3", expected: MacroV3},
+		{name: "unknown", input: "v4", err: true},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			protocol, err := ParseMacroProtocol(tc.input)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, protocol)
+		})
+	}
+}
+
+func TestRunMacro(t *testing.T) {
+	cases := []struct {
+		name     string
+		protocol MacroProtocol
+		snapshot bool
+		address  bool
+	}{
+		{name: "v1", protocol: MacroV1, snapshot: true},
+		{name: "v2", protocol: MacroV2, snapshot: true},
+		{name: "v3", protocol: MacroV3, address: true},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			dbms := newTestDBMSAt(t, filepath.Join(t.TempDir(), "test.json"), testSnapshot)
+			view := MacroCurrentView{Table: "tasks", PrimarySelection: "fix build"}
+			// cat returns its input so the macro leaves everything as is
+			output, err := RunMacro(context.Background(), dbms, "cat", view, tc.protocol)
+			require.NoError(t, err)
+			require.Equal(t, int(tc.protocol), output.Version)
+			require.Equal(t, view, output.CurrentView)
+			require.Equal(t, tc.snapshot, output.Snapshot != "")
+			require.Equal(t, tc.address, output.Address != "")
+			if tc.address {
+				// the socket is removed once the macro has finished
+				_, err := os.Stat(strings.TrimPrefix(output.Address, "unix://"))
+				require.True(t, os.IsNotExist(err))
+			}
+			_, err = dbms.GetRow(context.Background(), &jqlpb.GetRowRequest{Table: "tasks", Pk: "fix build"})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestServeMacro(t *testing.T) {
+	dbms := newTestDBMS(t, testSnapshot)
+	address, stop, err := serveMacro(dbms)
+	require.NoError(t, err)
+	defer stop()
+
+	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	client := jqlpb.NewJQLClient(conn)
+	ctx := context.Background()
+	_, err = client.WriteRow(ctx, &jqlpb.WriteRowRequest{Table: "tasks", Pk: "ship v3", Fields: map[string]string{"Status": "Active"}})
+	require.NoError(t, err)
+
+	// writes by the macro are applied directly to the local database
+	resp, err := dbms.GetRow(ctx, &jqlpb.GetRowRequest{Table: "tasks", Pk: "ship v3"})
+	require.NoError(t, err)
+	require.Equal(t, "Active", resp.Row.Entries[IndexOfField(resp.Columns, "Status")].Formatted)
+}
+
+func TestSplitCommand(t *testing.T) {
+	cases := []struct {
+		name     string
+		command  string
+		expected []string
+		err      bool
+	}{
+		{name: "plain", command: "jql-timedb-setpk --v2", expected: []string{"jql-timedb-setpk", "--v2"}},
+		{name: "repeated spaces", command: "  a   b ", expected: []string{"a", "b"}},
+		{name: "single quotes", command: `notify 'hello "world"'`, expected: []string{"notify", `hello "world"`}},
+		{name: "double quotes", command: `notify "it's \"done\"" \\n`, expected: []string{"notify", `it's "done"`, `\n`}},
+		{name: "escaped space", command: `open my\ file`, expected: []string{"open", "my file"}},
+		{name: "empty argument", command: `run ""`, expected: []string{"run", ""}},
+		{name: "literal backslash in double quotes", command: `echo "a\b"`, expected: []string{"echo", `a\b`}},
+		{name: "unterminated quote", command: `echo "hi`, err: true},
+		{name: "trailing backslash", command: `echo hi\`, err: true},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			args, err := splitCommand(tc.command)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, args)
+		})
+	}
+}
+
+func TestRunMacroFailure(t *testing.T) {
+	cases := []struct {
+		name    string
+		command string
+		timeout time.Duration
+		cancel  bool
+		message string
+		logged  string
+	}{
+		{
+			name:    "non-zero exit",
+			command: `sh -c 'echo "went wrong" >&2; exit 3'`,
+			message: "exit status 3",
+			logged:  "went wrong",
+		},
+		{
+			name:    "timeout",
+			command: "sleep 10",
+			timeout: 100 * time.Millisecond,
+			message: "timed out",
+		},
+		{
+			name:    "cancelled",
+			command: "sleep 10",
+			cancel:  true,
+			message: "cancelled",
+		},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			dir := t.TempDir()
+			dbms := newTestDBMSAt(t, filepath.Join(dir, "test.json"), testSnapshot)
+			ctx := context.Background()
+			if tc.timeout != 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tc.timeout)
+				defer cancel()
+			}
+			if tc.cancel {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithCancel(ctx)
+				time.AfterFunc(100*time.Millisecond, cancel)
+			}
+			start := time.Now()
+			_, err := RunMacro(ctx, dbms, tc.command, MacroCurrentView{}, MacroV3)
+			require.True(t, time.Since(start) < 5*time.Second)
+
+			var macroErr *MacroError
+			require.True(t, errors.As(err, &macroErr))
+			require.Contains(t, err.Error(), tc.message)
+			require.Equal(t, filepath.Join(dir, macroLogDirName), filepath.Dir(macroErr.LogPath))
+			require.Contains(t, macroErr.Log, tc.logged)
+			contents, err := os.ReadFile(macroErr.LogPath)
+			require.NoError(t, err)
+			require.Contains(t, string(contents), macroErr.Log)
+		})
+	}
+}
+
+func TestAppendMacroLog(t *testing.T) {
+	dir := t.TempDir()
+	entry := strings.Repeat("x", maxMacroLogSize/2) + "\n"
+	// each rotation happens once the log has grown past the max size
+	for i := 0; i < 2*(macroLogBackups+2); i++ {
+		path, err := appendMacroLog(dir, "/usr/bin/my macro", entry)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(dir, "my_macro.log"), path)
+	}
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	names := []string{}
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	require.Equal(t, []string{"my_macro.log", "my_macro.log.1", "my_macro.log.2", "my_macro.log.3"}, names)
+}
+
+func TestMacroLogName(t *testing.T) {
+	cases := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{name: "program", args: []string{"/usr/local/bin/archive"}, expected: "archive"},
+		{name: "script", args: []string{"python3", "/home/me/macros/archive.py"}, expected: "python3-archive.py"},
+		{name: "flags before script", args: []string{"python3", "-u", "archive.py", "--dry-run"}, expected: "python3-archive.py"},
+		{name: "only flags", args: []string{"archive", "--dry-run"}, expected: "archive"},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			require.Equal(t, tc.expected, macroLogName(tc.args))
+		})
+	}
+}
+
+func TestSplitPipeline(t *testing.T) {
+	cases := []struct {
+		name     string
+		pipeline string
+		expected [][]string
+	}{
+		{name: "single", pipeline: "next", expected: [][]string{{"next"}}},
+		{name: "without spaces", pipeline: "set A=1|next", expected: [][]string{{"set", "A=1"}, {"next"}}},
+		{name: "quoted pipe", pipeline: "set 'A=a | b' | next", expected: [][]string{{"set", "A=a | b"}, {"next"}}},
+		{name: "escaped pipe", pipeline: `set A=\|`, expected: [][]string{{"set", "A=|"}}},
+		{name: "empty command", pipeline: "next ||", expected: [][]string{{"next"}, {}, {}}},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			commands, err := splitPipeline(tc.pipeline)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, commands)
+		})
+	}
+}
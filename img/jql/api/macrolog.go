@@ -0,0 +1,116 @@
+package api
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// macroLogDirName is the name of the directory next to the database
+	// that contains macro logs
+	macroLogDirName = ".jql-macros"
+	// maxMacroLogSize is the size at which a macro's log is rotated
+	maxMacroLogSize = 1 << 20 // 1 Mb
+	// macroLogBackups is the number of rotated logs kept for each macro
+	macroLogBackups = 3
+	// maxLoggedOutput is the most of a macro's stdout that is logged for a
+	// single run since v1 macros return the whole database
+	maxLoggedOutput = 64 << 10 // 64 Kb
+)
+
+var unsafeLogChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// macroLogDir returns the directory of macro logs for the database. It's next
+// to the database for local databases and in the user's cache directory
+// otherwise.
+func macroLogDir(dbms JQL_DBMS) string {
+	if local, ok := dbms.(*LocalDBMS); ok && local.path != "" {
+		return filepath.Join(filepath.Dir(local.path), macroLogDirName)
+	}
+	cache, err := os.UserCacheDir()
+	if err != nil {
+		cache = os.TempDir()
+	}
+	return filepath.Join(cache, "jql", "macros")
+}
+
+// formatMacroLogEntry formats the output of a single run of a macro
+func formatMacroLogEntry(command string, start time.Time, err error, stdout, stderr []byte) string {
+	result := "ok"
+	if err != nil {
+		result = err.Error()
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "==> %s %s (%s in %s)\n", start.Format(time.RFC3339), command, result, time.Since(start).Round(time.Millisecond))
+	if len(stderr) > 0 {
+		fmt.Fprintf(&b, "--- stderr\n%s", stderr)
+		if !strings.HasSuffix(string(stderr), "\n") {
+			b.WriteString("\n")
+		}
+	}
+	if len(stdout) > 0 {
+		b.WriteString("--- stdout\n")
+		if len(stdout) > maxLoggedOutput {
+			fmt.Fprintf(&b, "%s\n[truncated %d bytes]\n", stdout[:maxLoggedOutput], len(stdout)-maxLoggedOutput)
+		} else {
+			b.Write(stdout)
+			if !strings.HasSuffix(string(stdout), "\n") {
+				b.WriteString("\n")
+			}
+		}
+	}
+	return b.String()
+}
+
+// macroLogName returns the name of the log of the command given its
+// arguments. It includes the first argument that isn't a flag, if any, so
+// that e.g. "python3 a.py" and "python3 b.py" are logged separately.
+func macroLogName(args []string) string {
+	name := filepath.Base(args[0])
+	for _, arg := range args[1:] {
+		if !strings.HasPrefix(arg, "-") {
+			return name + "-" + filepath.Base(arg)
+		}
+	}
+	return name
+}
+
+// appendMacroLog appends the entry to the named log of the macro, rotating the log
+// once it's too large, and returns the path of the log
+func appendMacroLog(dir, name, entry string) (string, error) {
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, unsafeLogChars.ReplaceAllString(filepath.Base(name), "_")+".log")
+	info, err := os.Stat(path)
+	if err == nil && info.Size() >= maxMacroLogSize {
+		err = rotateMacroLog(path)
+		if err != nil {
+			return path, err
+		}
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return path, err
+	}
+	defer f.Close()
+	_, err = f.WriteString(entry)
+	return path, err
+}
+
+// rotateMacroLog moves the log to path.1, path.1 to path.2, &c. dropping the
+// oldest backup
+func rotateMacroLog(path string) error {
+	for i := macroLogBackups - 1; i >= 1; i-- {
+		err := os.Rename(fmt.Sprintf("%s.%d", path, i), fmt.Sprintf("%s.%d", path, i+1))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return os.Rename(path, path+".1")
+}
@@ -0,0 +1,170 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/ulmenhaus/env/img/jql/storage"
+	"github.com/ulmenhaus/env/proto/jql/jqlpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// mutationBuffer is the number of mutations that may be queued for a watcher
+// before it's considered to have fallen behind and is disconnected
+const mutationBuffer = 4096
+
+// mutationFeed fans out mutations to everyone watching a database
+type mutationFeed struct {
+	mu       sync.Mutex
+	watchers map[chan *jqlpb.Mutation]bool
+}
+
+func newMutationFeed() *mutationFeed {
+	return &mutationFeed{
+		watchers: map[chan *jqlpb.Mutation]bool{},
+	}
+}
+
+// subscribe returns a channel of mutations whose first message is a resync so
+// that the watcher knows when it's safe to load a snapshot
+func (f *mutationFeed) subscribe() chan *jqlpb.Mutation {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ch := make(chan *jqlpb.Mutation, mutationBuffer)
+	ch <- &jqlpb.Mutation{Resync: true}
+	f.watchers[ch] = true
+	return ch
+}
+
+func (f *mutationFeed) unsubscribe(ch chan *jqlpb.Mutation) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.watchers[ch] {
+		delete(f.watchers, ch)
+		close(ch)
+	}
+}
+
+// publish sends the mutations produced by the provided function to every
+// watcher. The function is called with the feed locked so that mutations are
+// delivered in the order in which they were produced.
+func (f *mutationFeed) publish(mutations func() []*jqlpb.Mutation) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.watchers) == 0 {
+		return
+	}
+	for _, mutation := range mutations() {
+		for ch := range f.watchers {
+			select {
+			case ch <- mutation:
+			default:
+				log.Printf("disconnecting mutation watcher that fell behind")
+				delete(f.watchers, ch)
+				close(ch)
+			}
+		}
+	}
+}
+
+// publishRows notifies watchers of the current state of the given rows
+func (s *LocalDBMS) publishRows(table string, pks ...string) {
+	s.feed.publish(func() []*jqlpb.Mutation {
+		mutations := []*jqlpb.Mutation{}
+		seen := map[string]bool{}
+		for _, pk := range pks {
+			if pk == "" || seen[pk] {
+				continue
+			}
+			seen[pk] = true
+			encoded, ok, err := s.OSM.EncodedRow(table, pk)
+			if err != nil {
+				continue
+			}
+			mutation := &jqlpb.Mutation{Table: table, Pk: pk, Deleted: !ok}
+			if ok {
+				mutation.Row, err = json.Marshal(encoded)
+				if err != nil {
+					mutation = &jqlpb.Mutation{Resync: true}
+				}
+			}
+			mutations = append(mutations, mutation)
+		}
+		return mutations
+	})
+}
+
+// applyEncodedRow mirrors a row written to another database and notifies
+// watchers of it
+func (s *LocalDBMS) applyEncodedRow(table, pk string, encoded storage.EncodedEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := s.OSM.ApplyEncodedRow(table, pk, encoded)
+	if err != nil {
+		return err
+	}
+	s.publishRows(table, pk)
+	return nil
+}
+
+// publishResync notifies watchers that they should reload the whole database
+func (s *LocalDBMS) publishResync() {
+	s.feed.publish(func() []*jqlpb.Mutation {
+		return []*jqlpb.Mutation{{Resync: true}}
+	})
+}
+
+func (s *LocalDBMS) WatchMutations(ctx context.Context, in *jqlpb.WatchMutationsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[jqlpb.Mutation], error) {
+	ch := s.feed.subscribe()
+	go func() {
+		<-ctx.Done()
+		s.feed.unsubscribe(ch)
+	}()
+	return &localMutationStream{ctx: ctx, mutations: ch}, nil
+}
+
+// localMutationStream adapts a subscription to the mutation feed to the
+// client side of a gRPC stream
+type localMutationStream struct {
+	ctx       context.Context
+	mutations chan *jqlpb.Mutation
+}
+
+func (s *localMutationStream) Recv() (*jqlpb.Mutation, error) {
+	select {
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	case mutation, ok := <-s.mutations:
+		if !ok {
+			return nil, fmt.Errorf("mutation watcher fell behind")
+		}
+		return mutation, nil
+	}
+}
+
+func (s *localMutationStream) Header() (metadata.MD, error) { return nil, nil }
+func (s *localMutationStream) Trailer() metadata.MD         { return nil }
+func (s *localMutationStream) CloseSend() error             { return nil }
+func (s *localMutationStream) Context() context.Context     { return s.ctx }
+func (s *localMutationStream) SendMsg(m any) error {
+	return fmt.Errorf("mutation streams are receive-only")
+}
+
+func (s *localMutationStream) RecvMsg(m any) error {
+	mutation, err := s.Recv()
+	if err != nil {
+		return err
+	}
+	dst, ok := m.(*jqlpb.Mutation)
+	if !ok {
+		return fmt.Errorf("can only receive mutations, not %T", m)
+	}
+	proto.Reset(dst)
+	proto.Merge(dst, mutation)
+	return nil
+}
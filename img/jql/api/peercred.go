@@ -0,0 +1,61 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// UnixPeerInfo is the auth info of a client connected over a Unix socket
+type UnixPeerInfo struct {
+	credentials.CommonAuthInfo
+	UID uint32
+}
+
+// AuthType implements credentials.AuthInfo
+func (UnixPeerInfo) AuthType() string {
+	return "unix-peer"
+}
+
+// unixPeerCredentials identifies clients of a Unix socket by the credentials
+// of the peer process
+type unixPeerCredentials struct{}
+
+// UnixPeerCredentials returns transport credentials for a server listening on a
+// Unix socket that record the UID of each client in a UnixPeerInfo
+func UnixPeerCredentials() credentials.TransportCredentials {
+	return unixPeerCredentials{}
+}
+
+func (unixPeerCredentials) ClientHandshake(ctx context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return nil, nil, fmt.Errorf("unix peer credentials are server-only")
+}
+
+func (unixPeerCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	unixConn, ok := conn.(*net.UnixConn)
+	if !ok {
+		return nil, nil, fmt.Errorf("unix peer credentials require a unix socket")
+	}
+	uid, err := peerUID(unixConn)
+	if err != nil {
+		return nil, nil, err
+	}
+	return conn, UnixPeerInfo{
+		CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.NoSecurity},
+		UID:            uid,
+	}, nil
+}
+
+func (unixPeerCredentials) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{SecurityProtocol: "unix-peer"}
+}
+
+func (c unixPeerCredentials) Clone() credentials.TransportCredentials {
+	return c
+}
+
+func (unixPeerCredentials) OverrideServerName(string) error {
+	return nil
+}
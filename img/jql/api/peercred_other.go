@@ -0,0 +1,13 @@
+//go:build !linux
+
+package api
+
+import (
+	"fmt"
+	"net"
+)
+
+// peerUID returns the UID of the process on the other end of the socket
+func peerUID(conn *net.UnixConn) (uint32, error) {
+	return 0, fmt.Errorf("unix peer credentials are not supported on this platform")
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// persistPollInterval is how often the auto persister checks whether the
+// database should be persisted. It's only changed by tests.
+var persistPollInterval = time.Second
+
+// A PersistPolicy determines when a daemon persists its database without
+// being asked to by a client. Zero values disable the respective trigger.
+type PersistPolicy struct {
+	// Debounce persists once no rows have been modified for this long
+	Debounce time.Duration
+	// Interval persists dirty rows at least this often
+	Interval time.Duration
+	// MaxDirtyRows persists as soon as this many rows are dirty
+	MaxDirtyRows int
+}
+
+// Enabled returns true iff any trigger is set
+func (p PersistPolicy) Enabled() bool {
+	return p.Debounce > 0 || p.Interval > 0 || p.MaxDirtyRows > 0
+}
+
+// due returns true iff the database should be persisted now given when rows
+// were last modified, when it was last persisted, and how many rows are dirty
+func (p PersistPolicy) due(now, lastUpdate, lastStore time.Time, dirty int) bool {
+	if dirty == 0 {
+		return false
+	}
+	if p.MaxDirtyRows > 0 && dirty >= p.MaxDirtyRows {
+		return true
+	}
+	if p.Debounce > 0 && now.Sub(lastUpdate) >= p.Debounce {
+		return true
+	}
+	return p.Interval > 0 && now.Sub(lastStore) >= p.Interval
+}
+
+// AutoPersist persists the database according to the policy until the context
+// is cancelled
+func (s *LocalDBMS) AutoPersist(ctx context.Context, policy PersistPolicy) {
+	if !policy.Enabled() {
+		return
+	}
+	started := time.Now()
+	ticker := time.NewTicker(persistPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			err := s.persistIfDue(now, started, policy)
+			if err != nil {
+				log.Printf("failed to auto persist: %v", err)
+			}
+		}
+	}
+}
+
+// persistIfDue persists the database if the policy says it's due. Handlers
+// may be writing concurrently so the tables are read locked throughout.
+func (s *LocalDBMS) persistIfDue(now, started time.Time, policy PersistPolicy) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	dirty := 0
+	for _, count := range s.OSM.DirtyRows() {
+		dirty += count
+	}
+	lastStore, _ := s.OSM.LastStore()
+	if lastStore.Before(started) {
+		lastStore = started
+	}
+	if !policy.due(now, s.OSM.LastUpdate(), lastStore, dirty) {
+		return nil
+	}
+	return s.OSM.StoreEntries()
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ulmenhaus/env/img/jql/osm"
+	"github.com/ulmenhaus/env/proto/jql/jqlpb"
+)
+
+func TestPersistPolicyDue(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name       string
+		policy     PersistPolicy
+		lastUpdate time.Duration
+		lastStore  time.Duration
+		dirty      int
+		expected   bool
+	}{
+		{
+			name:       "nothing dirty",
+			policy:     PersistPolicy{Debounce: 5 * time.Second},
+			lastUpdate: time.Minute,
+			lastStore:  time.Hour,
+		},
+		{
+			name:       "still writing",
+			policy:     PersistPolicy{Debounce: 5 * time.Second},
+			lastUpdate: 2 * time.Second,
+			lastStore:  time.Hour,
+			dirty:      3,
+		},
+		{
+			name:       "debounce elapsed",
+			policy:     PersistPolicy{Debounce: 5 * time.Second},
+			lastUpdate: 5 * time.Second,
+			lastStore:  time.Hour,
+			dirty:      3,
+			expected:   true,
+		},
+		{
+			name:       "interval elapsed while still writing",
+			policy:     PersistPolicy{Debounce: 5 * time.Second, Interval: 10 * time.Minute},
+			lastUpdate: time.Second,
+			lastStore:  11 * time.Minute,
+			dirty:      3,
+			expected:   true,
+		},
+		{
+			name:       "interval not elapsed",
+			policy:     PersistPolicy{Interval: 10 * time.Minute},
+			lastUpdate: time.Hour,
+			lastStore:  time.Minute,
+			dirty:      3,
+		},
+		{
+			name:       "too many dirty rows",
+			policy:     PersistPolicy{Debounce: 5 * time.Second, MaxDirtyRows: 100},
+			lastUpdate: 0,
+			lastStore:  time.Second,
+			dirty:      100,
+			expected:   true,
+		},
+		{
+			name:       "disabled",
+			lastUpdate: time.Hour,
+			lastStore:  time.Hour,
+			dirty:      1000,
+		},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			due := tc.policy.due(now, now.Add(-tc.lastUpdate), now.Add(-tc.lastStore), tc.dirty)
+			require.Equal(t, tc.expected, due)
+		})
+	}
+}
+
+func TestAutoPersistWhileWriting(t *testing.T) {
+	interval := persistPollInterval
+	persistPollInterval = time.Millisecond
+	t.Cleanup(func() { persistPollInterval = interval })
+	path := filepath.Join(t.TempDir(), "test.json")
+	dbms := newTestDBMSAt(t, path, testSnapshot)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		dbms.AutoPersist(ctx, PersistPolicy{MaxDirtyRows: 1})
+	}()
+	written := 0
+	for start := time.Now(); time.Since(start) < 50*time.Millisecond; written++ {
+		_, err := dbms.WriteRow(ctx, &jqlpb.WriteRowRequest{
+			Table:  "tasks",
+			Pk:     fmt.Sprintf("task %d", written),
+			Fields: map[string]string{"Status": "Pending"},
+		})
+		require.NoError(t, err)
+	}
+	cancel()
+	<-done
+	lastStore, _ := dbms.OSM.LastStore()
+	require.False(t, lastStore.IsZero())
+	_, err := dbms.Persist(context.Background(), &jqlpb.PersistRequest{})
+	require.NoError(t, err)
+
+	mapper, err := osm.NewObjectStoreMapper(path)
+	require.NoError(t, err)
+	require.NoError(t, mapper.Load())
+	require.Len(t, mapper.GetDB().Tables["tasks"].Entries, written+3)
+}
+
+func TestFailedPersistKeepsDirtyRows(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "missing")
+	dbms := newTestDBMSAt(t, filepath.Join(dir, "tasks.json"), testSnapshot)
+	ctx := context.Background()
+	_, err := dbms.WriteRow(ctx, &jqlpb.WriteRowRequest{Table: "tasks", Pk: "new task", Fields: map[string]string{"Status": "Pending"}})
+	require.NoError(t, err)
+	dirty := dbms.OSM.DirtyRows()["tasks"]
+	require.NotZero(t, dirty)
+
+	_, err = dbms.Persist(ctx, &jqlpb.PersistRequest{})
+	require.Error(t, err)
+	require.Equal(t, dirty, dbms.OSM.DirtyRows()["tasks"])
+
+	require.NoError(t, os.Mkdir(dir, 0700))
+	_, err = dbms.Persist(ctx, &jqlpb.PersistRequest{})
+	require.NoError(t, err)
+	require.Zero(t, dbms.OSM.DirtyRows()["tasks"])
+}
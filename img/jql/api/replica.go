@@ -0,0 +1,190 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ulmenhaus/env/img/jql/osm"
+	"github.com/ulmenhaus/env/img/jql/storage"
+	"github.com/ulmenhaus/env/proto/jql/jqlpb"
+	"google.golang.org/grpc"
+)
+
+const (
+	// replicaPath determines the storage format of a replica's in-memory
+	// database. Replicas are never persisted.
+	replicaPath = "replica.json"
+	// maxReplicaBackoff is the longest a replica waits before reconnecting to
+	// its primary
+	maxReplicaBackoff = 30 * time.Second
+)
+
+// A Replica is a read-only copy of a primary database that is kept up to date
+// by watching the primary's mutations. Reads are served from the copy and
+// writes are forwarded to the primary, so a client may not immediately read
+// its own writes.
+type Replica struct {
+	jqlpb.UnimplementedJQLServer
+	local   *LocalDBMS
+	reads   jqlpb.JQLServer
+	primary JQL_DBMS
+}
+
+// NewReplica returns a replica of the provided primary. Bootstrap must be
+// called before it serves any requests.
+func NewReplica(primary JQL_DBMS) (*Replica, error) {
+	mapper, err := osm.NewObjectStoreMapper(replicaPath)
+	if err != nil {
+		return nil, err
+	}
+	local, err := NewLocalDBMS(mapper, "")
+	if err != nil {
+		return nil, err
+	}
+	return &Replica{
+		local:   local,
+		reads:   NewDBMSShim(local),
+		primary: primary,
+	}, nil
+}
+
+// RequireExactTables disables looking up tables by prefix for reads
+func (r *Replica) RequireExactTables() {
+	r.local.ExactTables = true
+}
+
+// Bootstrap starts watching the primary and loads its snapshot. The returned
+// stream should be passed to Run.
+func (r *Replica) Bootstrap(ctx context.Context) (grpc.ServerStreamingClient[jqlpb.Mutation], error) {
+	stream, err := r.primary.WatchMutations(ctx, &jqlpb.WatchMutationsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	// the primary starts every stream with a resync once it's subscribed
+	// so that no mutation is missed between the snapshot and the stream
+	mutation, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	if !mutation.Resync {
+		return nil, fmt.Errorf("expected primary to start with a resync")
+	}
+	return stream, r.resync(ctx)
+}
+
+// Run applies mutations from the stream until the context is cancelled,
+// reconnecting to the primary whenever the stream breaks
+func (r *Replica) Run(ctx context.Context, stream grpc.ServerStreamingClient[jqlpb.Mutation]) {
+	backoff := time.Second
+	for {
+		err := r.follow(ctx, stream)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("lost mutation stream from primary: %v", err)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			stream, err = r.Bootstrap(ctx)
+			if err == nil {
+				log.Printf("resynced with primary")
+				backoff = time.Second
+				break
+			}
+			log.Printf("failed to resync with primary: %v", err)
+			backoff = min(2*backoff, maxReplicaBackoff)
+		}
+	}
+}
+
+func (r *Replica) follow(ctx context.Context, stream grpc.ServerStreamingClient[jqlpb.Mutation]) error {
+	for {
+		mutation, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if err := r.apply(ctx, mutation); err != nil {
+			return err
+		}
+	}
+}
+
+func (r *Replica) resync(ctx context.Context) error {
+	resp, err := r.primary.GetSnapshot(ctx, &jqlpb.GetSnapshotRequest{})
+	if err != nil {
+		return err
+	}
+	_, err = r.local.LoadSnapshot(ctx, &jqlpb.LoadSnapshotRequest{Snapshot: resp.Snapshot})
+	return err
+}
+
+// apply mirrors a single mutation of the primary
+func (r *Replica) apply(ctx context.Context, mutation *jqlpb.Mutation) error {
+	if mutation.Resync {
+		return r.resync(ctx)
+	}
+	var encoded storage.EncodedEntry
+	if !mutation.Deleted {
+		decoder := json.NewDecoder(bytes.NewReader(mutation.Row))
+		if err := decoder.Decode(&encoded); err != nil {
+			return fmt.Errorf("failed to decode %s row %s: %w", mutation.Table, mutation.Pk, err)
+		}
+	}
+	// replicas can be chained so the mutation is passed along
+	return r.local.applyEncodedRow(mutation.Table, mutation.Pk, encoded)
+}
+
+func (r *Replica) ListTables(ctx context.Context, in *jqlpb.ListTablesRequest) (*jqlpb.ListTablesResponse, error) {
+	return r.reads.ListTables(ctx, in)
+}
+
+func (r *Replica) ListRows(ctx context.Context, in *jqlpb.ListRowsRequest) (*jqlpb.ListRowsResponse, error) {
+	return r.reads.ListRows(ctx, in)
+}
+
+func (r *Replica) GetRow(ctx context.Context, in *jqlpb.GetRowRequest) (*jqlpb.GetRowResponse, error) {
+	return r.reads.GetRow(ctx, in)
+}
+
+func (r *Replica) WriteRow(ctx context.Context, in *jqlpb.WriteRowRequest) (*jqlpb.WriteRowResponse, error) {
+	return r.primary.WriteRow(ctx, in)
+}
+
+func (r *Replica) DeleteRow(ctx context.Context, in *jqlpb.DeleteRowRequest) (*jqlpb.DeleteRowResponse, error) {
+	return r.primary.DeleteRow(ctx, in)
+}
+
+func (r *Replica) IncrementEntry(ctx context.Context, in *jqlpb.IncrementEntryRequest) (*jqlpb.IncrementEntryResponse, error) {
+	return r.primary.IncrementEntry(ctx, in)
+}
+
+func (r *Replica) Persist(ctx context.Context, in *jqlpb.PersistRequest) (*jqlpb.PersistResponse, error) {
+	return r.primary.Persist(ctx, in)
+}
+
+func (r *Replica) GetSnapshot(ctx context.Context, in *jqlpb.GetSnapshotRequest) (*jqlpb.GetSnapshotResponse, error) {
+	return r.reads.GetSnapshot(ctx, in)
+}
+
+func (r *Replica) LoadSnapshot(ctx context.Context, in *jqlpb.LoadSnapshotRequest) (*jqlpb.LoadSnapshotResponse, error) {
+	return r.primary.LoadSnapshot(ctx, in)
+}
+
+func (r *Replica) MigrateEnum(ctx context.Context, in *jqlpb.MigrateEnumRequest) (*jqlpb.MigrateEnumResponse, error) {
+	return r.primary.MigrateEnum(ctx, in)
+}
+
+func (r *Replica) Stats(ctx context.Context, in *jqlpb.StatsRequest) (*jqlpb.StatsResponse, error) {
+	return r.reads.Stats(ctx, in)
+}
+
+func (r *Replica) WatchMutations(in *jqlpb.WatchMutationsRequest, srv grpc.ServerStreamingServer[jqlpb.Mutation]) error {
+	return r.reads.WatchMutations(in, srv)
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ulmenhaus/env/proto/jql/jqlpb"
+)
+
+// eventually fails the test if the condition doesn't hold within a second
+func eventually(t *testing.T, condition func() bool) {
+	deadline := time.Now().Add(time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met before deadline")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestReplica(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	primary := newTestDBMS(t, testSnapshot)
+	replica, err := NewReplica(primary)
+	require.NoError(t, err)
+	stream, err := replica.Bootstrap(ctx)
+	require.NoError(t, err)
+	go replica.Run(ctx, stream)
+
+	replicated := func(expected map[string]string) {
+		eventually(t, func() bool {
+			resp, err := replica.ListRows(ctx, &jqlpb.ListRowsRequest{Table: "tasks"})
+			if err != nil {
+				return false
+			}
+			actual := map[string]string{}
+			statusix := IndexOfField(resp.Columns, "Status")
+			for _, row := range resp.Rows {
+				actual[row.Entries[GetPrimary(resp.Columns)].Formatted] = row.Entries[statusix].Formatted
+			}
+			return len(actual) == len(expected) && func() bool {
+				for pk, status := range expected {
+					if actual[pk] != status {
+						return false
+					}
+				}
+				return true
+			}()
+		})
+	}
+	replicated(map[string]string{"fix build": "Active", "add filters": "Satisfied", "plan week": "Abandoned"})
+
+	// writes to the replica are forwarded to the primary
+	_, err = replica.WriteRow(ctx, &jqlpb.WriteRowRequest{Table: "tasks", Pk: "write docs", Fields: map[string]string{"Status": "Pending"}})
+	require.NoError(t, err)
+	_, err = primary.GetRow(ctx, &jqlpb.GetRowRequest{Table: "tasks", Pk: "write docs"})
+	require.NoError(t, err)
+
+	_, err = primary.WriteRow(ctx, &jqlpb.WriteRowRequest{Table: "tasks", Pk: "fix build", UpdateOnly: true, Fields: map[string]string{"Name": "fix ci"}})
+	require.NoError(t, err)
+	_, err = primary.DeleteRow(ctx, &jqlpb.DeleteRowRequest{Table: "tasks", Pk: "plan week"})
+	require.NoError(t, err)
+	_, err = primary.IncrementEntry(ctx, &jqlpb.IncrementEntryRequest{Table: "tasks", Pk: "write docs", Column: "Status", Amount: 1})
+	require.NoError(t, err)
+	replicated(map[string]string{"fix ci": "Active", "add filters": "Satisfied", "write docs": "Active"})
+
+	// schema changes are replicated by reloading the snapshot
+	_, err = primary.MigrateEnum(ctx, &jqlpb.MigrateEnumRequest{
+		Table:   "tasks",
+		Column:  "Status",
+		Values:  []string{"Pending", "Active", "Done"},
+		Renames: map[string]string{"Satisfied": "Done", "Abandoned": "Done"},
+	})
+	require.NoError(t, err)
+	replicated(map[string]string{"fix ci": "Active", "add filters": "Done", "write docs": "Active"})
+}
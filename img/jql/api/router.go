@@ -0,0 +1,251 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/ulmenhaus/env/img/jql/types"
+	"github.com/ulmenhaus/env/proto/jql/jqlpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// VirtualPrefix is the prefix of tables served by a virtual gateway
+const VirtualPrefix = types.VirtualPrefix
+
+// A Mount serves every table whose name starts with Prefix from Backend
+type Mount struct {
+	Prefix  string
+	Backend jqlpb.JQLServer
+	// StripPrefix is set when the backend names its tables without the
+	// prefix, e.g. when a separate jql database is mounted under
+	// "projects.". Foreign tables in column metadata are rewritten to
+	// include the prefix but polyforeign entries are passed through as is.
+	StripPrefix bool
+}
+
+// external returns the name under which the router exposes a table of the
+// mount's backend
+func (m *Mount) external(table string) string {
+	if m.StripPrefix {
+		return m.Prefix + table
+	}
+	return table
+}
+
+// externalColumns rewrites references to tables of the mount's backend
+func (m *Mount) externalColumns(columns []*jqlpb.Column) {
+	if !m.StripPrefix {
+		return
+	}
+	for _, column := range columns {
+		switch column.Type {
+		case jqlpb.EntryType_FOREIGN, jqlpb.EntryType_FOREIGNS:
+			column.ForeignTable = m.external(column.ForeignTable)
+		case jqlpb.EntryType_POLYFOREIGN:
+			for i, value := range column.Values {
+				column.Values[i] = m.external(value)
+			}
+		}
+	}
+}
+
+// Router is a layer on top of the LocalDBMS that provides gRPC handles for
+// exposing the DBMS as a daemon. Tables are routed to the mount with the
+// longest matching prefix and otherwise to the default backend.
+type Router struct {
+	jqlpb.UnimplementedJQLServer
+	api jqlpb.JQLServer
+	// mounts are ordered from longest to shortest prefix and end with the
+	// default backend
+	mounts []Mount
+}
+
+// NewRouter returns a router serving virtual tables from the virtual gateway
+// and all other tables from api
+func NewRouter(api jqlpb.JQLServer, virtualGateway jqlpb.JQLServer) *Router {
+	router, _ := NewMountRouter(api, Mount{Prefix: VirtualPrefix, Backend: virtualGateway})
+	return router
+}
+
+// NewMountRouter returns a router serving tables from the provided mounts and
+// any table that doesn't match a mount from api
+func NewMountRouter(api jqlpb.JQLServer, mounts ...Mount) (*Router, error) {
+	seen := map[string]bool{}
+	for _, mount := range mounts {
+		if mount.Prefix == "" {
+			return nil, fmt.Errorf("mounts must have a prefix")
+		}
+		if seen[mount.Prefix] {
+			return nil, fmt.Errorf("multiple mounts with prefix %s", mount.Prefix)
+		}
+		seen[mount.Prefix] = true
+	}
+	mounts = append(slices.Clone(mounts), Mount{Backend: api})
+	sort.SliceStable(mounts, func(i, j int) bool {
+		return len(mounts[i].Prefix) > len(mounts[j].Prefix)
+	})
+	return &Router{
+		api:    api,
+		mounts: mounts,
+	}, nil
+}
+
+// route returns the mount serving the table along with the name of the table
+// in the mount's backend
+func (s *Router) route(table string) (*Mount, string) {
+	for i := range s.mounts {
+		mount := &s.mounts[i]
+		if !strings.HasPrefix(table, mount.Prefix) {
+			continue
+		}
+		if mount.StripPrefix {
+			return mount, strings.TrimPrefix(table, mount.Prefix)
+		}
+		return mount, table
+	}
+	// unreachable since the default backend has an empty prefix
+	return nil, table
+}
+
+// ListTables merges the tables of every backend. Tables shadowed by a mount
+// with a longer prefix are left out as are the tables of mounts that are
+// unavailable, e.g. a virtual gateway that isn't running.
+func (s *Router) ListTables(ctx context.Context, in *jqlpb.ListTablesRequest) (*jqlpb.ListTablesResponse, error) {
+	merged := &jqlpb.ListTablesResponse{}
+	for i := range s.mounts {
+		mount := &s.mounts[i]
+		resp, err := mount.Backend.ListTables(ctx, in)
+		if status.Code(err) == codes.Unimplemented {
+			continue
+		} else if err != nil {
+			log.Printf("skipping tables of %q mount: %v", mount.Prefix, err)
+			continue
+		}
+		for _, table := range resp.Tables {
+			table.Name = mount.external(table.Name)
+			if routed, _ := s.route(table.Name); routed != mount {
+				continue
+			}
+			mount.externalColumns(table.Columns)
+			merged.Tables = append(merged.Tables, table)
+		}
+	}
+	sort.Slice(merged.Tables, func(i, j int) bool { return merged.Tables[i].Name < merged.Tables[j].Name })
+	return merged, nil
+}
+
+func (s *Router) ListRows(ctx context.Context, in *jqlpb.ListRowsRequest) (*jqlpb.ListRowsResponse, error) {
+	mount, table := s.route(in.Table)
+	req := in
+	if table != in.Table {
+		req = proto.Clone(in).(*jqlpb.ListRowsRequest)
+		req.Table = table
+	}
+	resp, err := mount.Backend.ListRows(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Table = mount.external(resp.Table)
+	mount.externalColumns(resp.Columns)
+	return resp, nil
+}
+
+func (s *Router) GetRow(ctx context.Context, in *jqlpb.GetRowRequest) (*jqlpb.GetRowResponse, error) {
+	mount, table := s.route(in.Table)
+	req := in
+	if table != in.Table {
+		req = proto.Clone(in).(*jqlpb.GetRowRequest)
+		req.Table = table
+	}
+	resp, err := mount.Backend.GetRow(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Table = mount.external(resp.Table)
+	mount.externalColumns(resp.Columns)
+	return resp, nil
+}
+
+func (s *Router) WriteRow(ctx context.Context, in *jqlpb.WriteRowRequest) (*jqlpb.WriteRowResponse, error) {
+	mount, table := s.route(in.Table)
+	req := in
+	if table != in.Table {
+		req = proto.Clone(in).(*jqlpb.WriteRowRequest)
+		req.Table = table
+	}
+	return mount.Backend.WriteRow(ctx, req)
+}
+
+func (s *Router) DeleteRow(ctx context.Context, in *jqlpb.DeleteRowRequest) (*jqlpb.DeleteRowResponse, error) {
+	mount, table := s.route(in.Table)
+	req := in
+	if table != in.Table {
+		req = proto.Clone(in).(*jqlpb.DeleteRowRequest)
+		req.Table = table
+	}
+	return mount.Backend.DeleteRow(ctx, req)
+}
+
+func (s *Router) IncrementEntry(ctx context.Context, in *jqlpb.IncrementEntryRequest) (*jqlpb.IncrementEntryResponse, error) {
+	mount, table := s.route(in.Table)
+	req := in
+	if table != in.Table {
+		req = proto.Clone(in).(*jqlpb.IncrementEntryRequest)
+		req.Table = table
+	}
+	return mount.Backend.IncrementEntry(ctx, req)
+}
+
+// Persist persists every backend that supports persistence
+func (s *Router) Persist(ctx context.Context, in *jqlpb.PersistRequest) (*jqlpb.PersistResponse, error) {
+	var errs []error
+	for i := range s.mounts {
+		mount := &s.mounts[i]
+		_, err := mount.Backend.Persist(ctx, in)
+		if err != nil && status.Code(err) != codes.Unimplemented {
+			errs = append(errs, fmt.Errorf("failed to persist %q mount: %w", mount.Prefix, err))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return &jqlpb.PersistResponse{}, nil
+}
+
+// GetSnapshot returns the snapshot of the default backend only
+func (s *Router) GetSnapshot(ctx context.Context, in *jqlpb.GetSnapshotRequest) (*jqlpb.GetSnapshotResponse, error) {
+	return s.api.GetSnapshot(ctx, in)
+}
+
+// LoadSnapshot loads the snapshot into the default backend only
+func (s *Router) LoadSnapshot(ctx context.Context, in *jqlpb.LoadSnapshotRequest) (*jqlpb.LoadSnapshotResponse, error) {
+	return s.api.LoadSnapshot(ctx, in)
+}
+
+func (s *Router) MigrateEnum(ctx context.Context, in *jqlpb.MigrateEnumRequest) (*jqlpb.MigrateEnumResponse, error) {
+	mount, table := s.route(in.Table)
+	req := in
+	if table != in.Table {
+		req = proto.Clone(in).(*jqlpb.MigrateEnumRequest)
+		req.Table = table
+	}
+	return mount.Backend.MigrateEnum(ctx, req)
+}
+
+// Stats returns the request stats of the default backend only
+func (s *Router) Stats(ctx context.Context, in *jqlpb.StatsRequest) (*jqlpb.StatsResponse, error) {
+	return s.api.Stats(ctx, in)
+}
+
+// WatchMutations watches the default backend only
+func (s *Router) WatchMutations(in *jqlpb.WatchMutationsRequest, srv grpc.ServerStreamingServer[jqlpb.Mutation]) error {
+	return s.api.WatchMutations(in, srv)
+}
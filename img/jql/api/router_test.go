@@ -0,0 +1,222 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ulmenhaus/env/img/jql/osm"
+	"github.com/ulmenhaus/env/proto/jql/jqlpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testProjectsSnapshot = `{
+	"_schemata": {
+		"projects.Name": {"type": "string", "primary": true},
+		"notes.Name": {"type": "string", "primary": true},
+		"notes.Project": {"type": "foreign.projects"}
+	},
+	"projects": {
+		"env": {}
+	},
+	"notes": {
+		"router": {"Project": "env"}
+	}
+}`
+
+// virtualTables is a stand-in for a virtual gateway that names its tables
+// with the virtual prefix and doesn't support persistence
+type virtualTables struct {
+	jqlpb.UnimplementedJQLServer
+}
+
+func (v *virtualTables) ListTables(ctx context.Context, in *jqlpb.ListTablesRequest) (*jqlpb.ListTablesResponse, error) {
+	return &jqlpb.ListTablesResponse{
+		Tables: []*jqlpb.TableMeta{{Name: "vt.items"}, {Name: "stray"}},
+	}, nil
+}
+
+func (v *virtualTables) ListRows(ctx context.Context, in *jqlpb.ListRowsRequest) (*jqlpb.ListRowsResponse, error) {
+	return &jqlpb.ListRowsResponse{Table: in.Table, Total: 1}, nil
+}
+
+func newTestRouter(t *testing.T) (*Router, *LocalDBMS, *LocalDBMS) {
+	projects := newTestDBMS(t, testProjectsSnapshot)
+	tasks := newTestDBMS(t, testSnapshot)
+	router, err := NewMountRouter(NewDBMSShim(tasks),
+		Mount{Prefix: "projects.", Backend: NewDBMSShim(projects), StripPrefix: true},
+		Mount{Prefix: VirtualPrefix, Backend: &virtualTables{}},
+	)
+	require.NoError(t, err)
+	return router, tasks, projects
+}
+
+func TestNewMountRouter(t *testing.T) {
+	cases := []struct {
+		name   string
+		mounts []Mount
+		err    bool
+	}{
+		{
+			name:   "distinct prefixes",
+			mounts: []Mount{{Prefix: "a."}, {Prefix: "a.b."}},
+		},
+		{
+			name:   "empty prefix",
+			mounts: []Mount{{Prefix: ""}},
+			err:    true,
+		},
+		{
+			name:   "duplicate prefix",
+			mounts: []Mount{{Prefix: "a."}, {Prefix: "a."}},
+			err:    true,
+		},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			_, err := NewMountRouter(&virtualTables{}, tc.mounts...)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestRouterListTables(t *testing.T) {
+	router, _, _ := newTestRouter(t)
+	resp, err := router.ListTables(context.Background(), &jqlpb.ListTablesRequest{})
+	require.NoError(t, err)
+	names := []string{}
+	for _, table := range resp.Tables {
+		names = append(names, table.Name)
+		if table.Name == "projects.notes" {
+			project := table.Columns[IndexOfField(table.Columns, "Project")]
+			require.Equal(t, "projects.projects", project.ForeignTable)
+		}
+	}
+	// the stray virtual table is shadowed by the default backend
+	require.Equal(t, []string{"projects.notes", "projects.projects", "tasks", "vt.items"}, names)
+}
+
+// unavailableTables is a stand-in for a virtual gateway that isn't running
+type unavailableTables struct {
+	jqlpb.UnimplementedJQLServer
+}
+
+func (u *unavailableTables) ListTables(ctx context.Context, in *jqlpb.ListTablesRequest) (*jqlpb.ListTablesResponse, error) {
+	return nil, status.Error(codes.Unavailable, "connection refused")
+}
+
+func TestRouterListTablesSkipsUnavailableMounts(t *testing.T) {
+	tasks := newTestDBMS(t, testSnapshot)
+	router, err := NewMountRouter(NewDBMSShim(tasks), Mount{Prefix: VirtualPrefix, Backend: &unavailableTables{}})
+	require.NoError(t, err)
+	resp, err := router.ListTables(context.Background(), &jqlpb.ListTablesRequest{})
+	require.NoError(t, err)
+	names := []string{}
+	for _, table := range resp.Tables {
+		names = append(names, table.Name)
+	}
+	require.Equal(t, []string{"tasks"}, names)
+}
+
+func TestRouterListRows(t *testing.T) {
+	cases := []struct {
+		name    string
+		table   string
+		total   uint32
+		foreign string
+		err     bool
+	}{
+		{
+			name:  "default backend",
+			table: "tasks",
+			total: 3,
+		},
+		{
+			name:    "stripped mount",
+			table:   "projects.notes",
+			total:   1,
+			foreign: "projects.projects",
+		},
+		{
+			name:  "virtual mount",
+			table: "vt.items",
+			total: 1,
+		},
+		{
+			name:  "mounted table under default name",
+			table: "notes",
+			err:   true,
+		},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			router, _, _ := newTestRouter(t)
+			resp, err := router.ListRows(context.Background(), &jqlpb.ListRowsRequest{Table: tc.table})
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.table, resp.Table)
+			require.Equal(t, tc.total, resp.Total)
+			if tc.foreign != "" {
+				project := resp.Columns[IndexOfField(resp.Columns, "Project")]
+				require.Equal(t, tc.foreign, project.ForeignTable)
+			}
+		})
+	}
+}
+
+func TestRouterWriteRow(t *testing.T) {
+	router, tasks, projects := newTestRouter(t)
+	ctx := context.Background()
+	_, err := router.WriteRow(ctx, &jqlpb.WriteRowRequest{Table: "projects.projects", Pk: "jql", Fields: map[string]string{}})
+	require.NoError(t, err)
+
+	resp, err := router.GetRow(ctx, &jqlpb.GetRowRequest{Table: "projects.projects", Pk: "jql"})
+	require.NoError(t, err)
+	require.Equal(t, "projects.projects", resp.Table)
+	_, err = projects.GetRow(ctx, &jqlpb.GetRowRequest{Table: "projects", Pk: "jql"})
+	require.NoError(t, err)
+	_, err = tasks.GetRow(ctx, &jqlpb.GetRowRequest{Table: "projects", Pk: "jql"})
+	require.Error(t, err)
+}
+
+func TestRouterPersist(t *testing.T) {
+	dir := t.TempDir()
+	backends := []*LocalDBMS{}
+	for _, tc := range []struct{ path, snapshot string }{
+		{"tasks.json", testSnapshot},
+		{"projects.json", testProjectsSnapshot},
+	} {
+		path := filepath.Join(dir, tc.path)
+		mapper, err := osm.NewObjectStoreMapper(path)
+		require.NoError(t, err)
+		require.NoError(t, mapper.LoadSnapshot(strings.NewReader(tc.snapshot)))
+		dbms, err := NewLocalDBMS(mapper, path)
+		require.NoError(t, err)
+		backends = append(backends, dbms)
+	}
+	router, err := NewMountRouter(NewDBMSShim(backends[0]),
+		Mount{Prefix: "projects.", Backend: NewDBMSShim(backends[1]), StripPrefix: true},
+		Mount{Prefix: VirtualPrefix, Backend: &virtualTables{}},
+	)
+	require.NoError(t, err)
+
+	// the virtual mount doesn't implement Persist and is skipped
+	_, err = router.Persist(context.Background(), &jqlpb.PersistRequest{})
+	require.NoError(t, err)
+	for _, path := range []string{"tasks.json", "projects.json"} {
+		_, err := os.Stat(filepath.Join(dir, path))
+		require.NoError(t, err)
+	}
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ulmenhaus/env/proto/jql/jqlpb"
+	"google.golang.org/grpc"
+)
+
+// rpcStats are the accumulated counts and latencies of a single RPC
+type rpcStats struct {
+	count   uint64
+	errors  uint64
+	total   time.Duration
+	longest time.Duration
+}
+
+// RequestStats records the count and latency of every RPC served by the
+// daemon and logs each request
+type RequestStats struct {
+	mu       sync.Mutex
+	byMethod map[string]*rpcStats
+	logger   *slog.Logger
+}
+
+// NewRequestStats returns a RequestStats that logs requests to the provided
+// logger
+func NewRequestStats(logger *slog.Logger) *RequestStats {
+	return &RequestStats{
+		byMethod: map[string]*rpcStats{},
+		logger:   logger,
+	}
+}
+
+func (s *RequestStats) record(method string, duration time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats, ok := s.byMethod[method]
+	if !ok {
+		stats = &rpcStats{}
+		s.byMethod[method] = stats
+	}
+	stats.count++
+	if err != nil {
+		stats.errors++
+	}
+	stats.total += duration
+	if duration > stats.longest {
+		stats.longest = duration
+	}
+}
+
+// RPCs returns the stats of every RPC that has been served ordered by method
+func (s *RequestStats) RPCs() []*jqlpb.RPCStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rpcs := []*jqlpb.RPCStats{}
+	for method, stats := range s.byMethod {
+		rpcs = append(rpcs, &jqlpb.RPCStats{
+			Method:        method,
+			Count:         stats.count,
+			Errors:        stats.errors,
+			MeanLatencyMs: float64(stats.total) / float64(stats.count) / float64(time.Millisecond),
+			MaxLatencyMs:  float64(stats.longest) / float64(time.Millisecond),
+		})
+	}
+	slices.SortFunc(rpcs, func(a, b *jqlpb.RPCStats) int { return strings.Compare(a.Method, b.Method) })
+	return rpcs
+}
+
+// UnaryInterceptor returns a gRPC interceptor that logs and records every
+// request. Responses to the Stats RPC are populated with the recorded RPCs.
+func (s *RequestStats) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		duration := time.Since(start)
+		s.record(info.FullMethod, duration, err)
+
+		attrs := []any{"method", info.FullMethod, "duration", duration}
+		if treq, ok := req.(tableRequest); ok {
+			attrs = append(attrs, "table", treq.GetTable())
+		}
+		if err != nil {
+			s.logger.Error("request failed", append(attrs, "error", err)...)
+			return nil, err
+		}
+		s.logger.Info("request", attrs...)
+		if stats, ok := resp.(*jqlpb.StatsResponse); ok {
+			stats.Rpcs = s.RPCs()
+		}
+		return resp, nil
+	}
+}
+
+// StreamInterceptor returns a gRPC interceptor that logs and records every
+// streaming request once the stream ends
+func (s *RequestStats) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		s.logger.Info("stream opened", "method", info.FullMethod)
+		err := handler(srv, ss)
+		duration := time.Since(start)
+		s.record(info.FullMethod, duration, err)
+		if err != nil {
+			s.logger.Error("stream failed", "method", info.FullMethod, "duration", duration, "error", err)
+			return err
+		}
+		s.logger.Info("stream closed", "method", info.FullMethod, "duration", duration)
+		return nil
+	}
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ulmenhaus/env/proto/jql/jqlpb"
+	"google.golang.org/grpc"
+)
+
+func TestStats(t *testing.T) {
+	dbms := newTestDBMS(t, testSnapshot)
+	_, err := dbms.WriteRow(context.Background(), &jqlpb.WriteRowRequest{
+		Table:  "tasks",
+		Pk:     "write docs",
+		Fields: map[string]string{"Status": "Pending"},
+	})
+	require.NoError(t, err)
+
+	stats := NewRequestStats(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	interceptor := stats.UnaryInterceptor()
+	failing := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, errors.New("no such table")
+	}
+	_, err = interceptor(context.Background(), &jqlpb.ListRowsRequest{Table: "nope"}, &grpc.UnaryServerInfo{FullMethod: jqlpb.JQL_ListRows_FullMethodName}, failing)
+	require.Error(t, err)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return dbms.Stats(ctx, req.(*jqlpb.StatsRequest))
+	}
+	resp, err := interceptor(context.Background(), &jqlpb.StatsRequest{}, &grpc.UnaryServerInfo{FullMethod: jqlpb.JQL_Stats_FullMethodName}, handler)
+	require.NoError(t, err)
+
+	statsResp := resp.(*jqlpb.StatsResponse)
+	require.Len(t, statsResp.Tables, 1)
+	require.Equal(t, "tasks", statsResp.Tables[0].Name)
+	require.Equal(t, uint32(4), statsResp.Tables[0].Rows)
+	require.Equal(t, uint32(1), statsResp.Tables[0].DirtyRows)
+	require.Equal(t, int64(0), statsResp.LastPersistUnixMs)
+
+	require.Len(t, statsResp.Rpcs, 2)
+	require.Equal(t, jqlpb.JQL_ListRows_FullMethodName, statsResp.Rpcs[0].Method)
+	require.Equal(t, uint64(1), statsResp.Rpcs[0].Count)
+	require.Equal(t, uint64(1), statsResp.Rpcs[0].Errors)
+	require.Equal(t, jqlpb.JQL_Stats_FullMethodName, statsResp.Rpcs[1].Method)
+	require.Equal(t, uint64(0), statsResp.Rpcs[1].Errors)
+}
@@ -0,0 +1,263 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os/exec"
+	"slices"
+	"time"
+
+	"github.com/ulmenhaus/env/img/jql/types"
+)
+
+const (
+	// TriggerInsert fires when a row is inserted
+	TriggerInsert = "insert"
+	// TriggerUpdate fires when a row is updated
+	TriggerUpdate = "update"
+	// TriggerDelete fires when a row is deleted
+	TriggerDelete = "delete"
+
+	// triggersFeature is the feature of a column containing its triggers
+	triggersFeature = "triggers"
+	// triggerTimeout is how long an external trigger command may run
+	triggerTimeout = 10 * time.Second
+	// maxTriggerDepth is how many triggers may fire as a result of a single
+	// write before the write fails
+	maxTriggerDepth = 16
+)
+
+// A Trigger runs either a pipeline of built-in actions or an external command
+// when a row is written. Triggers are declared in the "triggers" feature of a
+// column, e.g.
+//
+//	"tasks.Status": {"type": "enum", "features": {"triggers": [
+//		{"on": ["update"], "action": "set Modified=now | cascade Parent Status"}
+//	]}}
+//
+// Triggers declared on the primary column fire on every update of the row
+// while triggers declared on any other column fire on updates only when that
+// column changes. Actions act on the written row and may refer to its fields
+// before the write as {old.COLUMN}. Commands receive the event, table, pk, and
+// the old and new row as JSON on stdin. Writes made by a trigger to the row
+// that fired it don't fire triggers again.
+//
+// Triggers fire after the write is applied and a trigger that fails doesn't
+// fail the write. Its failure is logged instead. A write fails before it's
+// applied only if the triggers of the table are invalid, or include commands
+// and command triggers are disabled.
+type Trigger struct {
+	On      []string `json:"on"`
+	Action  string   `json:"action,omitempty"`
+	Command string   `json:"command,omitempty"`
+
+	// column is the column that declares the trigger or empty if it's the
+	// primary column
+	column string
+}
+
+// TriggerEvent is the input of an external trigger command
+type TriggerEvent struct {
+	Event string            `json:"event"`
+	Table string            `json:"table"`
+	PK    string            `json:"pk"`
+	Old   map[string]string `json:"old"`
+	New   map[string]string `json:"new"`
+}
+
+// tableTriggers returns the triggers declared on the columns of the table
+func tableTriggers(table *types.Table) ([]*Trigger, error) {
+	triggers := []*Trigger{}
+	for i, column := range table.Columns {
+		declared, ok := table.Features(column)[triggersFeature]
+		if !ok {
+			continue
+		}
+		// features are decoded from JSON so round trip them to get typed
+		// triggers
+		encoded, err := json.Marshal(declared)
+		if err != nil {
+			return nil, err
+		}
+		var columnTriggers []*Trigger
+		if err := json.Unmarshal(encoded, &columnTriggers); err != nil {
+			return nil, fmt.Errorf("invalid triggers for %s: %s", column, err)
+		}
+		for _, trigger := range columnTriggers {
+			if err := trigger.validate(); err != nil {
+				return nil, fmt.Errorf("invalid trigger for %s: %s", column, err)
+			}
+			if i != table.Primary() {
+				trigger.column = column
+			}
+		}
+		triggers = append(triggers, columnTriggers...)
+	}
+	return triggers, nil
+}
+
+func (t *Trigger) validate() error {
+	if (t.Action == "") == (t.Command == "") {
+		return fmt.Errorf("exactly one of action and command must be set")
+	}
+	if t.Action != "" {
+		if _, err := parseBuiltinActions(t.Action); err != nil {
+			return err
+		}
+	}
+	if len(t.On) == 0 {
+		return fmt.Errorf("no events to fire on")
+	}
+	for _, event := range t.On {
+		if event != TriggerInsert && event != TriggerUpdate && event != TriggerDelete {
+			return fmt.Errorf("unknown event: %s", event)
+		}
+	}
+	return nil
+}
+
+// fires returns true iff the trigger should fire for the event
+func (t *Trigger) fires(event string, old, new map[string]string) bool {
+	if !slices.Contains(t.On, event) {
+		return false
+	}
+	if event == TriggerUpdate && t.column != "" {
+		return old[t.column] != new[t.column]
+	}
+	return true
+}
+
+// triggerChainKey is the context key of the rows whose triggers are running
+type triggerChainKey struct{}
+
+// rowFields returns the formatted fields of a row or nil if it doesn't exist
+func rowFields(table *types.Table, pk string) map[string]string {
+	row, ok := table.Entries[pk]
+	if !ok {
+		return nil
+	}
+	fields := map[string]string{}
+	for i, column := range table.Columns {
+		fields[column] = row[i].Format("")
+	}
+	return fields
+}
+
+// checkTriggers returns an error if the triggers of the table can't fire so
+// that writes to it fail before they're applied
+func (s *LocalDBMS) checkTriggers(table *types.Table) error {
+	triggers, err := tableTriggers(table)
+	if err != nil {
+		return err
+	}
+	for _, trigger := range triggers {
+		if trigger.Command != "" && !s.CommandTriggers {
+			return fmt.Errorf("command triggers are disabled")
+		}
+	}
+	return nil
+}
+
+// fireTriggers runs the triggers of the table for a write of the row given
+// its fields before the write. The write has already been applied so
+// failures are logged rather than returned.
+func (s *LocalDBMS) fireTriggers(ctx context.Context, name string, table *types.Table, pk string, old map[string]string) {
+	s.mu.RLock()
+	new := rowFields(table, pk)
+	triggers, err := tableTriggers(table)
+	s.mu.RUnlock()
+	if err != nil {
+		log.Printf("failed to fire triggers of %s: %s", name, err)
+		return
+	}
+	var event string
+	switch {
+	case old == nil && new == nil:
+		return
+	case old == nil:
+		event = TriggerInsert
+	case new == nil:
+		event = TriggerDelete
+	default:
+		event = TriggerUpdate
+	}
+	if len(triggers) == 0 {
+		return
+	}
+	chain, _ := ctx.Value(triggerChainKey{}).([]string)
+	row := name + " " + pk
+	if slices.Contains(chain, row) {
+		return
+	}
+	if len(chain) >= maxTriggerDepth {
+		log.Printf("not firing triggers of %s %s since they're nested more than %d deep", name, pk, maxTriggerDepth)
+		return
+	}
+	ctx = context.WithValue(ctx, triggerChainKey{}, append(slices.Clone(chain), row))
+	for _, trigger := range triggers {
+		if !trigger.fires(event, old, new) {
+			continue
+		}
+		if trigger.Action != "" {
+			err = s.runTriggerAction(ctx, trigger, name, pk, old, new == nil)
+		} else if !s.CommandTriggers {
+			err = fmt.Errorf("command triggers are disabled")
+		} else {
+			err = s.runTriggerCommand(ctx, trigger, TriggerEvent{
+				Event: event,
+				Table: name,
+				PK:    pk,
+				Old:   old,
+				New:   new,
+			})
+		}
+		if err != nil {
+			log.Printf("%s trigger of %s %s failed: %s", event, name, pk, err)
+		}
+	}
+}
+
+func (s *LocalDBMS) runTriggerAction(ctx context.Context, trigger *Trigger, name, pk string, old map[string]string, deleted bool) error {
+	_, err := runBuiltinActions(ctx, &builtinMacro{
+		dbms:    s,
+		view:    MacroCurrentView{Table: name, PKs: []string{pk}, PrimarySelection: pk},
+		old:     old,
+		deleted: deleted,
+	}, trigger.Action)
+	return err
+}
+
+func (s *LocalDBMS) runTriggerCommand(ctx context.Context, trigger *Trigger, event TriggerEvent) error {
+	args, err := splitCommand(trigger.Command)
+	if err != nil {
+		return fmt.Errorf("invalid command: %s", err)
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("invalid command: no program provided")
+	}
+	input, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, triggerTimeout)
+	defer cancel()
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	start := time.Now()
+	err = cmd.Run()
+	entry := formatMacroLogEntry(trigger.Command, start, err, stdout.Bytes(), stderr.Bytes())
+	logPath, logErr := appendMacroLog(macroLogDir(s), macroLogName(args), entry)
+	if err != nil {
+		if logErr != nil {
+			return fmt.Errorf("%s (could not write log: %s)", err, logErr)
+		}
+		return fmt.Errorf("%s -- log at %s", err, logPath)
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ulmenhaus/env/proto/jql/jqlpb"
+)
+
+const testTriggersSnapshot = `{
+	"_schemata": {
+		"tasks.Name": {"type": "string", "primary": true, "features": {"triggers": [
+			{"on": ["delete"], "action": "insert audit 'Entry=deleted {pk} ({old.Status})'"}
+		]}},
+		"tasks.Status": {"type": "enum", "features": {"values": "Pending, Active, Satisfied, Abandoned", "triggers": [
+			{"on": ["update"], "action": "set 'Notes=was {old.Status}' | cascade Parent Status"}
+		]}},
+		"tasks.Notes": {"type": "string"},
+		"tasks.Parent": {"type": "foreign.tasks"},
+		"audit.ID": {"type": "id", "primary": true, "features": {"strategy": "sequence", "length": 3}},
+		"audit.Entry": {"type": "string"}
+	},
+	"tasks": {
+		"launch": {"Status": "Active"},
+		"design": {"Status": "Active", "Parent": "launch"},
+		"sketch": {"Status": "Pending", "Parent": "design"},
+		"unrelated": {"Status": "Pending"}
+	},
+	"audit": {}
+}`
+
+func TestTriggers(t *testing.T) {
+	cases := []struct {
+		name     string
+		write    func(context.Context, *LocalDBMS) error
+		statuses map[string]string
+		notes    map[string]string
+		audit    []string
+	}{
+		{
+			name: "update cascades to descendants",
+			write: func(ctx context.Context, dbms *LocalDBMS) error {
+				_, err := dbms.WriteRow(ctx, &jqlpb.WriteRowRequest{Table: "tasks", Pk: "launch", Fields: map[string]string{"Status": "Satisfied"}, UpdateOnly: true})
+				return err
+			},
+			statuses: map[string]string{"launch": "Satisfied", "design": "Satisfied", "sketch": "Satisfied", "unrelated": "Pending"},
+			notes:    map[string]string{"launch": "was Active", "design": "was Active", "sketch": "was Pending", "unrelated": ""},
+		},
+		{
+			name: "update of another column",
+			write: func(ctx context.Context, dbms *LocalDBMS) error {
+				_, err := dbms.WriteRow(ctx, &jqlpb.WriteRowRequest{Table: "tasks", Pk: "launch", Fields: map[string]string{"Notes": "soon"}, UpdateOnly: true})
+				return err
+			},
+			statuses: map[string]string{"launch": "Active", "design": "Active"},
+			notes:    map[string]string{"launch": "soon", "design": ""},
+		},
+		{
+			name: "increment",
+			write: func(ctx context.Context, dbms *LocalDBMS) error {
+				_, err := dbms.IncrementEntry(ctx, &jqlpb.IncrementEntryRequest{Table: "tasks", Pk: "unrelated", Column: "Status", Amount: 1})
+				return err
+			},
+			statuses: map[string]string{"unrelated": "Active"},
+			notes:    map[string]string{"unrelated": "was Pending"},
+		},
+		{
+			name: "delete",
+			write: func(ctx context.Context, dbms *LocalDBMS) error {
+				_, err := dbms.DeleteRow(ctx, &jqlpb.DeleteRowRequest{Table: "tasks", Pk: "unrelated"})
+				return err
+			},
+			audit: []string{"deleted unrelated (Pending)"},
+		},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			dbms := newTestDBMS(t, testTriggersSnapshot)
+			ctx := context.Background()
+			require.NoError(t, tc.write(ctx, dbms))
+			_, statuses := listColumn(t, dbms, "tasks", "Status")
+			for pk, status := range tc.statuses {
+				require.Equal(t, status, statuses[pk], pk)
+			}
+			_, notes := listColumn(t, dbms, "tasks", "Notes")
+			for pk, note := range tc.notes {
+				require.Equal(t, note, notes[pk], pk)
+			}
+			_, audit := listColumn(t, dbms, "audit", "Entry")
+			entries := []string{}
+			for _, entry := range audit {
+				entries = append(entries, entry)
+			}
+			if tc.audit == nil {
+				tc.audit = []string{}
+			}
+			require.Equal(t, tc.audit, entries)
+		})
+	}
+}
+
+func TestTriggerCommand(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "event.json")
+	snapshot := fmt.Sprintf(`{
+		"_schemata": {
+			"tasks.Name": {"type": "string", "primary": true, "features": {"triggers": [
+				{"on": ["insert", "update"], "command": "sh -c 'cat > %s'"}
+			]}},
+			"tasks.Status": {"type": "string"}
+		},
+		"tasks": {"fix build": {"Status": "Active"}}
+	}`, out)
+	dbms := newTestDBMSAt(t, filepath.Join(dir, "test.json"), snapshot)
+	dbms.CommandTriggers = true
+	ctx := context.Background()
+	_, err := dbms.WriteRow(ctx, &jqlpb.WriteRowRequest{Table: "tasks", Pk: "fix build", Fields: map[string]string{"Status": "Done"}, UpdateOnly: true})
+	require.NoError(t, err)
+
+	contents, err := os.ReadFile(out)
+	require.NoError(t, err)
+	var event TriggerEvent
+	require.NoError(t, json.Unmarshal(contents, &event))
+	require.Equal(t, TriggerEvent{
+		Event: TriggerUpdate,
+		Table: "tasks",
+		PK:    "fix build",
+		Old:   map[string]string{"Name": "fix build", "Status": "Active"},
+		New:   map[string]string{"Name": "fix build", "Status": "Done"},
+	}, event)
+}
+
+func TestInvalidTriggers(t *testing.T) {
+	cases := []struct {
+		name     string
+		triggers string
+	}{
+		{name: "no events", triggers: `[{"action": "next"}]`},
+		{name: "unknown event", triggers: `[{"on": ["upsert"], "action": "next"}]`},
+		{name: "action and command", triggers: `[{"on": ["insert"], "action": "next", "command": "true"}]`},
+		{name: "unknown action", triggers: `[{"on": ["insert"], "action": "explode"}]`},
+		{name: "command triggers disabled", triggers: `[{"on": ["insert"], "command": "true"}]`},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			dir := t.TempDir()
+			snapshot := fmt.Sprintf(`{
+				"_schemata": {
+					"tasks.Name": {"type": "string", "primary": true, "features": {"triggers": %s}}
+				},
+				"tasks": {}
+			}`, tc.triggers)
+			dbms := newTestDBMSAt(t, filepath.Join(dir, "test.json"), snapshot)
+			_, err := dbms.WriteRow(context.Background(), &jqlpb.WriteRowRequest{Table: "tasks", Pk: "fix build"})
+			require.Error(t, err)
+			// the write fails before it's applied
+			_, names := listColumn(t, dbms, "tasks", "Name")
+			require.Empty(t, names)
+		})
+	}
+}
+
+func TestFailingTriggers(t *testing.T) {
+	cases := []struct {
+		name     string
+		triggers string
+	}{
+		{name: "failing command", triggers: `[{"on": ["insert"], "command": "false"}]`},
+		{name: "failing action", triggers: `[{"on": ["insert"], "action": "set Missing=1"}]`},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			dir := t.TempDir()
+			snapshot := fmt.Sprintf(`{
+				"_schemata": {
+					"tasks.Name": {"type": "string", "primary": true, "features": {"triggers": %s}}
+				},
+				"tasks": {}
+			}`, tc.triggers)
+			dbms := newTestDBMSAt(t, filepath.Join(dir, "test.json"), snapshot)
+			dbms.CommandTriggers = true
+			// the write is applied so it succeeds even though the
+			// trigger fails
+			_, err := dbms.WriteRow(context.Background(), &jqlpb.WriteRowRequest{Table: "tasks", Pk: "fix build"})
+			require.NoError(t, err)
+			_, names := listColumn(t, dbms, "tasks", "Name")
+			require.Equal(t, map[string]string{"fix build": "fix build"}, names)
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+
+	"github.com/ulmenhaus/env/proto/jql/jqlpb"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// ViewsTable is the name of the standard table containing saved views.
+	// Like the macros table it must be declared in the schemata, e.g.
+	//
+	//	"_views.Name": {"type": "string", "primary": true},
+	//	"_views.Table": {"type": "string"},
+	//	"_views.Request": {"type": "string"},
+	//	"_views.Layout": {"type": "string"}
+	ViewsTable = "_views"
+	// ViewNameCol is the name of the column of the views table containing
+	// the name of the view
+	ViewNameCol = "Name"
+	// ViewTableCol is the name of the column of the views table containing
+	// the table the view is of
+	ViewTableCol = "Table"
+	// ViewRequestCol is the name of the column of the views table containing
+	// the ListRowsRequest of the view encoded the same way as for --query
+	ViewRequestCol = "Request"
+	// ViewLayoutCol is the name of the column of the views table containing
+	// the layout of the columns of the view
+	ViewLayoutCol = "Layout"
+
+	// LayoutsTable is the name of the standard table containing the column
+	// layout of each table, e.g.
+	//
+	//	"_layouts.Table": {"type": "string", "primary": true},
+	//	"_layouts.Layout": {"type": "string"}
+	LayoutsTable = "_layouts"
+	// LayoutCol is the name of the column of the layouts table containing the
+	// layout of the table
+	LayoutCol = "Layout"
+)
+
+// A View is a named request along with how to display its columns
+type View struct {
+	Name    string
+	Table   string
+	Request *jqlpb.ListRowsRequest
+	Layout  string
+}
+
+// EncodeRequest encodes a request as base64 so that it may be passed as
+// --query or stored in a view
+func EncodeRequest(request *jqlpb.ListRowsRequest) (string, error) {
+	data, err := proto.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// DecodeRequest decodes a request encoded by EncodeRequest
+func DecodeRequest(encoded string) (*jqlpb.ListRowsRequest, error) {
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode request: %w", err)
+	}
+	request := &jqlpb.ListRowsRequest{}
+	if err := proto.Unmarshal(data, request); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal request: %w", err)
+	}
+	return request, nil
+}
+
+// GetView returns the view with the given name
+func GetView(ctx context.Context, dbms JQL_DBMS, name string) (*View, error) {
+	resp, err := dbms.GetRow(ctx, &jqlpb.GetRowRequest{Table: ViewsTable, Pk: name})
+	if err != nil {
+		return nil, fmt.Errorf("Could not get view '%s': %w", name, err)
+	}
+	fields := map[string]string{}
+	for i, column := range resp.Columns {
+		fields[column.Name] = resp.Row.Entries[i].Formatted
+	}
+	request, err := DecodeRequest(fields[ViewRequestCol])
+	if err != nil {
+		return nil, fmt.Errorf("Invalid view '%s': %w", name, err)
+	}
+	view := &View{
+		Name:    name,
+		Table:   fields[ViewTableCol],
+		Request: request,
+		Layout:  fields[ViewLayoutCol],
+	}
+	// the table column takes precedence so that a view can be pointed at a
+	// renamed table without re-encoding its request
+	if view.Table == "" {
+		view.Table = request.Table
+	}
+	request.Table = view.Table
+	return view, nil
+}
+
+// SaveView writes the view to the views table replacing any existing view
+// with the same name. Pagination of the request isn't saved.
+func SaveView(ctx context.Context, dbms JQL_DBMS, view *View) error {
+	if view.Name == "" {
+		return fmt.Errorf("views must have a name")
+	}
+	request := proto.Clone(view.Request).(*jqlpb.ListRowsRequest)
+	request.Table = view.Table
+	request.Offset = 0
+	request.Limit = 0
+	encoded, err := EncodeRequest(request)
+	if err != nil {
+		return err
+	}
+	fields := map[string]string{
+		ViewTableCol:   view.Table,
+		ViewRequestCol: encoded,
+		ViewLayoutCol:  view.Layout,
+	}
+	_, err = dbms.GetRow(ctx, &jqlpb.GetRowRequest{Table: ViewsTable, Pk: view.Name})
+	exists := err == nil
+	_, err = dbms.WriteRow(ctx, &jqlpb.WriteRowRequest{
+		Table:      ViewsTable,
+		Pk:         view.Name,
+		Fields:     fields,
+		InsertOnly: !exists,
+		UpdateOnly: exists,
+	})
+	if err != nil {
+		return fmt.Errorf("Could not save view '%s' (is the %s table declared?): %w", view.Name, ViewsTable, err)
+	}
+	return nil
+}
+
+// ListViews returns the names of all saved views in sorted order
+func ListViews(ctx context.Context, dbms JQL_DBMS) ([]string, error) {
+	resp, err := dbms.ListRows(ctx, &jqlpb.ListRowsRequest{Table: ViewsTable})
+	if err != nil {
+		return nil, fmt.Errorf("Could not list views: %w", err)
+	}
+	primary := GetPrimary(resp.Columns)
+	names := []string{}
+	for _, row := range resp.Rows {
+		names = append(names, row.Entries[primary].Formatted)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// GetLayout returns the column layout saved for the table or an empty layout
+// if there is none
+func GetLayout(ctx context.Context, dbms JQL_DBMS, table string) (string, error) {
+	resp, err := dbms.GetRow(ctx, &jqlpb.GetRowRequest{Table: LayoutsTable, Pk: table})
+	if err != nil && IsNotExistError(err) {
+		return "", nil
+	} else if err != nil {
+		return "", fmt.Errorf("Could not get layout of '%s' (is the %s table declared?): %w", table, LayoutsTable, err)
+	}
+	index := IndexOfField(resp.Columns, LayoutCol)
+	if index == -1 {
+		return "", fmt.Errorf("%s has no %s column", LayoutsTable, LayoutCol)
+	}
+	return resp.Row.Entries[index].Formatted, nil
+}
+
+// SaveLayout saves the column layout of the table replacing any existing one
+func SaveLayout(ctx context.Context, dbms JQL_DBMS, table, layout string) error {
+	_, err := dbms.GetRow(ctx, &jqlpb.GetRowRequest{Table: LayoutsTable, Pk: table})
+	exists := err == nil
+	_, err = dbms.WriteRow(ctx, &jqlpb.WriteRowRequest{
+		Table:      LayoutsTable,
+		Pk:         table,
+		Fields:     map[string]string{LayoutCol: layout},
+		InsertOnly: !exists,
+		UpdateOnly: exists,
+	})
+	if err != nil {
+		return fmt.Errorf("Could not save layout of %s (is the %s table declared?): %w", table, LayoutsTable, err)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ulmenhaus/env/proto/jql/jqlpb"
+	"google.golang.org/protobuf/proto"
+)
+
+const testViewsSnapshot = `{
+	"_schemata": {
+		"_views.Name": {"type": "string", "primary": true},
+		"_views.Table": {"type": "string"},
+		"_views.Request": {"type": "string"},
+		"_views.Layout": {"type": "string"},
+		"tasks.Name": {"type": "string", "primary": true},
+		"tasks.Status": {"type": "string"}
+	},
+	"_views": {},
+	"tasks": {}
+}`
+
+func TestSaveView(t *testing.T) {
+	active := &jqlpb.ListRowsRequest{
+		Table: "tasks",
+		Conditions: []*jqlpb.Condition{{
+			Requires: []*jqlpb.Filter{{
+				Column: "Status",
+				Match:  &jqlpb.Filter_EqualMatch{EqualMatch: &jqlpb.EqualMatch{Value: "Active"}},
+			}},
+		}},
+		OrderBy: "Name",
+		Offset:  20,
+		Limit:   20,
+	}
+	cases := []struct {
+		name     string
+		views    []*View
+		expected *View
+	}{
+		{
+			name: "new view",
+			views: []*View{
+				{Name: "active", Table: "tasks", Request: active, Layout: "Name:30, Status"},
+			},
+			expected: &View{
+				Name:  "active",
+				Table: "tasks",
+				Request: &jqlpb.ListRowsRequest{
+					Table:      "tasks",
+					Conditions: active.Conditions,
+					OrderBy:    "Name",
+				},
+				Layout: "Name:30, Status",
+			},
+		},
+		{
+			name: "replaced view",
+			views: []*View{
+				{Name: "active", Table: "tasks", Request: active, Layout: "Name:30, Status"},
+				{Name: "active", Table: "tasks", Request: &jqlpb.ListRowsRequest{Table: "tasks", Dec: true}},
+			},
+			expected: &View{
+				Name:    "active",
+				Table:   "tasks",
+				Request: &jqlpb.ListRowsRequest{Table: "tasks", Dec: true},
+			},
+		},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			dbms := newTestDBMS(t, testViewsSnapshot)
+			ctx := context.Background()
+			for _, view := range tc.views {
+				require.NoError(t, SaveView(ctx, dbms, view))
+			}
+			view, err := GetView(ctx, dbms, tc.expected.Name)
+			require.NoError(t, err)
+			require.True(t, proto.Equal(tc.expected.Request, view.Request), "%v", view.Request)
+			view.Request = tc.expected.Request
+			require.Equal(t, tc.expected, view)
+
+			names, err := ListViews(ctx, dbms)
+			require.NoError(t, err)
+			require.Equal(t, []string{tc.expected.Name}, names)
+		})
+	}
+}
+
+func TestSaveViewWithoutViewsTable(t *testing.T) {
+	dbms := newTestDBMS(t, `{
+		"_schemata": {"tasks.Name": {"type": "string", "primary": true}},
+		"tasks": {}
+	}`)
+	ctx := context.Background()
+	err := SaveView(ctx, dbms, &View{Name: "all", Table: "tasks", Request: &jqlpb.ListRowsRequest{Table: "tasks"}})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "_views")
+	_, err = GetView(ctx, dbms, "all")
+	require.Error(t, err)
+}
+
+func TestSaveLayout(t *testing.T) {
+	cases := []struct {
+		name     string
+		layouts  []string
+		expected string
+	}{
+		{name: "no layout", expected: ""},
+		{name: "new layout", layouts: []string{"Name:30, -Status"}, expected: "Name:30, -Status"},
+		{name: "replaced layout", layouts: []string{"Name:30, -Status", "Status, Name"}, expected: "Status, Name"},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			dbms := newTestDBMS(t, `{
+				"_schemata": {
+					"_layouts.Table": {"type": "string", "primary": true},
+					"_layouts.Layout": {"type": "string"},
+					"tasks.Name": {"type": "string", "primary": true},
+					"tasks.Status": {"type": "string"}
+				},
+				"_layouts": {},
+				"tasks": {}
+			}`)
+			ctx := context.Background()
+			for _, layout := range tc.layouts {
+				require.NoError(t, SaveLayout(ctx, dbms, "tasks", layout))
+			}
+			layout, err := GetLayout(ctx, dbms, "tasks")
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, layout)
+		})
+	}
+}
+
+func TestGetLayoutWithoutLayoutsTable(t *testing.T) {
+	dbms := newTestDBMS(t, `{
+		"_schemata": {"tasks.Name": {"type": "string", "primary": true}},
+		"tasks": {}
+	}`)
+	_, err := GetLayout(context.Background(), dbms, "tasks")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "_layouts")
+}
@@ -3,26 +3,28 @@ package cli
 import (
 	"crypto/tls"
 	"crypto/x509"
-	"encoding/base64"
 	"fmt"
+	"net"
 	"os"
 	"os/exec"
 	"strings"
 	"syscall"
+	"time"
 
 	flag "github.com/spf13/pflag"
 	"github.com/ulmenhaus/env/img/jql/api"
 	"github.com/ulmenhaus/env/img/jql/osm"
+	"github.com/ulmenhaus/env/img/jql/types"
 	"github.com/ulmenhaus/env/proto/jql/jqlpb"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
-	"google.golang.org/protobuf/proto"
 )
 
 const (
 	ModeDaemon     = "daemon"
 	ModeClient     = "client"
 	ModeStandalone = "standalone"
+	ModeReplica    = "replica"
 
 	MaxPayloadSize = 100000000 // 100 Mb
 )
@@ -38,17 +40,31 @@ type JQLConfig struct {
 	Path           string
 	Table          string
 	Addr           string
+	Primary        string
 	VirtualGateway string
 	ListenUnix     string
+	ListenHTTP     string
 
 	PK       string
 	SelectPK string
 	Query    string
+	View     string
 
 	TLSCert string
 	TLSKey  string
 	TLSCA   string
 
+	Timezone    string
+	AuthzPolicy string
+
+	PersistDebounce     time.Duration
+	PersistInterval     time.Duration
+	PersistMaxDirtyRows int
+
+	Mounts []string
+
+	CommandTriggers bool
+
 	filters []string
 }
 
@@ -67,6 +83,9 @@ func (c *JQLConfig) Validate() error {
 	if c.Query != "" && (c.Table != "" || len(c.filters) > 0) {
 		return fmt.Errorf("--query cannot be used with --table or --filter")
 	}
+	if c.View != "" && (c.Query != "" || c.Table != "" || len(c.filters) > 0) {
+		return fmt.Errorf("--view cannot be used with --query, --table, or --filter")
+	}
 	switch c.Mode {
 	case ModeDaemon:
 		if c.Path == "" {
@@ -78,11 +97,14 @@ func (c *JQLConfig) Validate() error {
 		if c.Addr == "" {
 			return fmt.Errorf("Address must be provided for daemon mode")
 		}
+		if _, err := c.mountPaths(); err != nil {
+			return err
+		}
 	case ModeClient:
 		if c.Path != "" {
 			return fmt.Errorf("Path cannot be provided for client mode")
 		}
-		if c.Table == "" && c.queryTable() == "" {
+		if c.Table == "" && c.queryTable() == "" && c.View == "" {
 			return fmt.Errorf("Table must be provided for client mode")
 		}
 		if c.Addr == "" {
@@ -92,29 +114,68 @@ func (c *JQLConfig) Validate() error {
 		if c.Path == "" {
 			return fmt.Errorf("Path must be provided for standalone mode")
 		}
-		if c.Table == "" && c.queryTable() == "" {
+		if c.Table == "" && c.queryTable() == "" && c.View == "" {
 			return fmt.Errorf("Table must be provided for standalone mode")
 		}
+	case ModeReplica:
+		if c.Path != "" {
+			return fmt.Errorf("Path cannot be provided for replica mode")
+		}
+		if c.Primary == "" {
+			return fmt.Errorf("Primary must be provided for replica mode")
+		}
+		if c.Addr == "" {
+			return fmt.Errorf("Address must be provided for replica mode")
+		}
 	default:
 		return fmt.Errorf("Unknown mode")
 	}
+	if len(c.Mounts) > 0 && c.Mode != ModeDaemon {
+		return fmt.Errorf("Mounts can only be provided for daemon mode")
+	}
+	if c.ListenHTTP != "" && c.TLSCert == "" && !isLoopback(c.ListenHTTP) {
+		return fmt.Errorf("HTTP gateway must listen on a loopback address unless TLS is configured")
+	}
 	return nil
 }
 
+// isLoopback returns true iff the address can only be reached from this host
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
 func (c *JQLConfig) Register(f *flag.FlagSet) {
 	f.StringVarP(&c.Mode, "mode", "m", "standalone", "Mode of operation")
 	f.StringVarP(&c.Addr, "addr", "a", "localhost:9999", "Address (for remote connections)")
+	f.StringVarP(&c.Primary, "primary", "", "", "Address of the daemon to replicate (replica mode)")
 	f.StringVarP(&c.Path, "path", "p", "", "Path to the jql storage")
 	f.StringVarP(&c.Table, "table", "t", "", "The table to start on")
 	f.StringVarP(&c.PK, "pk", "", "", "The primary key to initially select")
 	f.StringVarP(&c.SelectPK, "select", "", "", "Place the cursor on the row with this primary key after initialization")
 	f.StringVarP(&c.VirtualGateway, "virtual-gateway", "", "", "The address where the virtual gateway runs")
 	f.StringVarP(&c.ListenUnix, "listen-unix", "", "", "Additional Unix socket path for the daemon to listen on")
+	f.StringVarP(&c.ListenHTTP, "listen-http", "", "", "Additional address for the daemon to serve the HTTP/JSON gateway on. Must be a loopback address unless TLS is configured, in which case clients must present a certificate")
+	f.BoolVarP(&c.CommandTriggers, "command-triggers", "", false, "Allow triggers to run external commands (daemon mode). They're always allowed in standalone mode")
+	f.StringArrayVarP(&c.Mounts, "mount", "", []string{}, "Serve the tables of another jql database under a prefix, e.g. projects.=/path/to/projects.json (daemon mode). Snapshots, stats and mutation watches only cover the default database")
 	f.StringArrayVarP(&c.filters, "filter", "", []string{}, "Add initial filters to the table")
 	f.StringVarP(&c.Query, "query", "", "", "Base64-encoded ListRowsRequest as the initial query (mutually exclusive with --table and --filter)")
+	f.StringVarP(&c.View, "view", "", "", "Name of a view saved in the _views table to start on (mutually exclusive with --query, --table, and --filter)")
 	f.StringVarP(&c.TLSCert, "tls-cert", "", "", "Path to TLS certificate file")
 	f.StringVarP(&c.TLSKey, "tls-key", "", "", "Path to TLS key file")
 	f.StringVarP(&c.TLSCA, "tls-ca", "", "", "Path to TLS CA certificate file")
+	f.StringVarP(&c.AuthzPolicy, "authz-policy", "", "", "Path to a JSON policy mapping client certificate subjects and unix peer UIDs to roles (daemon mode)")
+	f.DurationVarP(&c.PersistDebounce, "persist-debounce", "", 0, "Persist once no rows have been modified for this long, e.g. 5s (daemon mode)")
+	f.DurationVarP(&c.PersistInterval, "persist-interval", "", 0, "Persist modified rows at least this often, e.g. 10m (daemon mode)")
+	f.IntVarP(&c.PersistMaxDirtyRows, "persist-max-dirty", "", 0, "Persist as soon as this many rows have been modified (daemon mode)")
+	f.StringVarP(&c.Timezone, "timezone", "", os.Getenv("TZ"), "IANA time zone in which to display and parse dates and times (defaults to $TZ)")
 }
 
 func (c *JQLConfig) SwitchTool(tool, pk string, filters ...Filter) error {
@@ -142,6 +203,12 @@ func (c *JQLConfig) SwitchTool(tool, pk string, filters ...Filter) error {
 	if c.Query != "" {
 		args = append(args, "--query", c.Query)
 	}
+	if c.View != "" {
+		args = append(args, "--view", c.View)
+	}
+	if c.Timezone != "" {
+		args = append(args, "--timezone", c.Timezone)
+	}
 	for _, filter := range filters {
 		args = append(args, "--filter", fmt.Sprintf("%s=%s", filter.Key, filter.Value))
 	}
@@ -170,39 +237,22 @@ func (c *JQLConfig) InitDBMS() (api.JQL_DBMS, error) {
 	if err != nil {
 		return nil, err
 	}
+	err = c.applyTimezone()
+	if err != nil {
+		return nil, err
+	}
 	// As a convenience we reset the terminal when initializing the dbms
 	// so that any previous attributes like highlights are gone
 	clearTerminal()
 	switch c.Mode {
 	case ModeDaemon, ModeStandalone:
-		mapper, err := osm.NewObjectStoreMapper(c.Path)
+		dbms, err := c.loadLocalDBMS(c.Path)
 		if err != nil {
 			return nil, err
 		}
-		err = mapper.Load()
-		if err != nil {
-			return nil, err
-		}
-		dbms, err := api.NewLocalDBMS(mapper, c.Path)
-		if err != nil {
-			return nil, fmt.Errorf("failed to initialize database server: %v", err)
-		}
-		return dbms, err
+		return dbms, nil
 	case ModeClient:
-		dialOpts := []grpc.DialOption{
-			grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(MaxPayloadSize)),
-			grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(MaxPayloadSize)),
-		}
-		if c.TLSCert != "" {
-			creds, err := c.clientCredentials()
-			if err != nil {
-				return nil, err
-			}
-			dialOpts = append(dialOpts, grpc.WithTransportCredentials(creds))
-		} else {
-			dialOpts = append(dialOpts, grpc.WithInsecure())
-		}
-		conn, err := grpc.Dial(c.Addr, dialOpts...)
+		conn, err := c.dial(c.Addr)
 		if err != nil {
 			return nil, err
 		}
@@ -216,10 +266,132 @@ func (c *JQLConfig) InitDBMS() (api.JQL_DBMS, error) {
 	return nil, fmt.Errorf("Unknown mode")
 }
 
+func (c *JQLConfig) loadLocalDBMS(path string) (*api.LocalDBMS, error) {
+	mapper, err := osm.NewObjectStoreMapper(path)
+	if err != nil {
+		return nil, err
+	}
+	err = mapper.Load()
+	if err != nil {
+		return nil, err
+	}
+	dbms, err := api.NewLocalDBMS(mapper, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize database server: %v", err)
+	}
+	// a daemon runs triggers in the process serving its clients so
+	// commands must be explicitly allowed
+	dbms.CommandTriggers = c.Mode == ModeStandalone || c.CommandTriggers
+	// authz policies name tables exactly
+	dbms.ExactTables = c.AuthzPolicy != ""
+	return dbms, nil
+}
+
+// mountPaths parses the mounts as a map from table prefix to database path
+func (c *JQLConfig) mountPaths() (map[string]string, error) {
+	paths := map[string]string{}
+	for _, mount := range c.Mounts {
+		prefix, path, ok := strings.Cut(mount, "=")
+		if !ok || prefix == "" || path == "" {
+			return nil, fmt.Errorf("Mount must be of the form prefix=path: %s", mount)
+		}
+		if _, ok := paths[prefix]; ok {
+			return nil, fmt.Errorf("Multiple mounts with prefix %s", prefix)
+		}
+		paths[prefix] = path
+	}
+	return paths, nil
+}
+
+// InitMountedDBMSs loads the databases mounted in daemon mode keyed by the
+// prefix of their tables
+func (c *JQLConfig) InitMountedDBMSs() (map[string]*api.LocalDBMS, error) {
+	paths, err := c.mountPaths()
+	if err != nil {
+		return nil, err
+	}
+	mounted := map[string]*api.LocalDBMS{}
+	for prefix, path := range paths {
+		dbms, err := c.loadLocalDBMS(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to mount %s: %w", path, err)
+		}
+		mounted[prefix] = dbms
+	}
+	return mounted, nil
+}
+
+// applyTimezone sets the default location for date and time columns. Tools
+// that compute "today" themselves should do so in Location.
+func (c *JQLConfig) applyTimezone() error {
+	if c.Timezone == "" {
+		return nil
+	}
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return fmt.Errorf("invalid timezone %q: %w", c.Timezone, err)
+	}
+	types.SetDefaultLocation(loc)
+	return nil
+}
+
+// Location returns the time zone given by --timezone or, like date and time
+// columns, UTC if there is none
+func (c *JQLConfig) Location() *time.Location {
+	return types.DefaultLocation()
+}
+
+// InitPrimaryDBMS returns a client of the daemon being replicated in
+// replica mode
+func (c *JQLConfig) InitPrimaryDBMS() (api.JQL_DBMS, error) {
+	err := c.Validate()
+	if err != nil {
+		return nil, err
+	}
+	err = c.applyTimezone()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := c.dial(c.Primary)
+	if err != nil {
+		return nil, err
+	}
+	return api.NewRemoteDBMS(c.Primary, jqlpb.NewJQLClient(conn)), nil
+}
+
+// dial connects to the daemon at the given address using the configured
+// client credentials
+func (c *JQLConfig) dial(addr string) (*grpc.ClientConn, error) {
+	dialOpts := []grpc.DialOption{
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(MaxPayloadSize)),
+		grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(MaxPayloadSize)),
+	}
+	if c.TLSCert != "" {
+		creds, err := c.clientCredentials()
+		if err != nil {
+			return nil, err
+		}
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(creds))
+	} else {
+		dialOpts = append(dialOpts, grpc.WithInsecure())
+	}
+	return grpc.Dial(addr, dialOpts...)
+}
+
 // ServerCredentials returns a gRPC server option enforcing mTLS when TLS fields
 // are set: clients must present a certificate signed by the configured CA.
 // Returns nil, nil when TLS is not configured.
 func (c *JQLConfig) ServerCredentials() (grpc.ServerOption, error) {
+	tlsCfg, err := c.ServerTLSConfig()
+	if err != nil || tlsCfg == nil {
+		return nil, err
+	}
+	return grpc.Creds(credentials.NewTLS(tlsCfg)), nil
+}
+
+// ServerTLSConfig returns the TLS config of a server enforcing mTLS when TLS
+// fields are set. Returns nil, nil when TLS is not configured.
+func (c *JQLConfig) ServerTLSConfig() (*tls.Config, error) {
 	if c.TLSCert == "" {
 		return nil, nil
 	}
@@ -235,12 +407,30 @@ func (c *JQLConfig) ServerCredentials() (grpc.ServerOption, error) {
 	if !caPool.AppendCertsFromPEM(caCert) {
 		return nil, fmt.Errorf("failed to parse CA certificate")
 	}
-	tlsCfg := &tls.Config{
+	return &tls.Config{
 		Certificates: []tls.Certificate{cert},
 		ClientAuth:   tls.RequireAndVerifyClientCert,
 		ClientCAs:    caPool,
+	}, nil
+}
+
+// PersistPolicy returns the policy for automatically persisting the database
+// in daemon mode
+func (c *JQLConfig) PersistPolicy() api.PersistPolicy {
+	return api.PersistPolicy{
+		Debounce:     c.PersistDebounce,
+		Interval:     c.PersistInterval,
+		MaxDirtyRows: c.PersistMaxDirtyRows,
 	}
-	return grpc.Creds(credentials.NewTLS(tlsCfg)), nil
+}
+
+// Authz returns the configured authz policy. Returns nil, nil when no policy
+// is configured.
+func (c *JQLConfig) Authz() (*api.AuthzPolicy, error) {
+	if c.AuthzPolicy == "" {
+		return nil, nil
+	}
+	return api.LoadAuthzPolicy(c.AuthzPolicy)
 }
 
 func (c *JQLConfig) clientCredentials() (credentials.TransportCredentials, error) {
@@ -264,13 +454,9 @@ func (c *JQLConfig) clientCredentials() (credentials.TransportCredentials, error
 }
 
 func (c *JQLConfig) GetQuery() (*jqlpb.ListRowsRequest, error) {
-	data, err := base64.StdEncoding.DecodeString(c.Query)
+	req, err := api.DecodeRequest(c.Query)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode --query: %w", err)
-	}
-	req := &jqlpb.ListRowsRequest{}
-	if err := proto.Unmarshal(data, req); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal --query: %w", err)
+		return nil, fmt.Errorf("invalid --query: %w", err)
 	}
 	return req, nil
 }
@@ -2,10 +2,17 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"log"
+	"log/slog"
 	"net"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/jroimartin/gocui"
 	"github.com/spf13/cobra"
@@ -14,8 +21,14 @@ import (
 	"github.com/ulmenhaus/env/img/jql/ui"
 	"github.com/ulmenhaus/env/proto/jql/jqlpb"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
+// drainTimeout is how long the daemon waits for in-flight RPCs to finish
+// when shutting down
+const drainTimeout = 5 * time.Second
+
 func main() {
 	err := runCLI()
 	if err != nil {
@@ -52,12 +65,100 @@ func runJQL(cfg *cli.JQLConfig) error {
 			return err
 		}
 		return runDaemon(cfg, dbms)
+	case cli.ModeReplica:
+		err := cfg.Validate()
+		if err != nil {
+			return err
+		}
+		return runReplica(cfg)
 	default:
 		return fmt.Errorf("Unknown mode: %v", cfg.Mode)
 	}
 }
 
 func runDaemon(cfg *cli.JQLConfig, dbms api.JQL_DBMS) error {
+	mounted, err := cfg.InitMountedDBMSs()
+	if err != nil {
+		return err
+	}
+	var backend jqlpb.JQLServer
+	backend = api.NewDBMSShim(dbms)
+	mounts := []api.Mount{}
+	for prefix, mountedDBMS := range mounted {
+		mounts = append(mounts, api.Mount{Prefix: prefix, Backend: api.NewDBMSShim(mountedDBMS), StripPrefix: true})
+	}
+	if cfg.VirtualGateway != "" {
+		gateway, err := cfg.InitVirtualDBMS()
+		if err != nil {
+			return err
+		}
+		mounts = append(mounts, api.Mount{Prefix: api.VirtualPrefix, Backend: api.NewDBMSShim(gateway)})
+	}
+	if len(mounts) > 0 {
+		backend, err = api.NewMountRouter(backend, mounts...)
+		if err != nil {
+			return err
+		}
+	}
+	// mounted databases are persisted alongside the main one
+	persisted := []api.JQL_DBMS{dbms}
+	for _, mountedDBMS := range mounted {
+		persisted = append(persisted, mountedDBMS)
+	}
+	persistCtx, stopPersisting := context.WithCancel(context.Background())
+	defer stopPersisting()
+	if cfg.PersistPolicy().Enabled() {
+		for _, candidate := range persisted {
+			if local, ok := candidate.(*api.LocalDBMS); ok {
+				go local.AutoPersist(persistCtx, cfg.PersistPolicy())
+			}
+		}
+	}
+	// any outstanding mutations are persisted once in-flight RPCs have finished
+	return serveDaemon(cfg, backend, func() error {
+		stopPersisting()
+		for _, candidate := range persisted {
+			_, err := candidate.Persist(context.Background(), &jqlpb.PersistRequest{})
+			if err != nil {
+				return fmt.Errorf("failed to persist on shutdown: %v", err)
+			}
+		}
+		log.Printf("persisted database on shutdown")
+		return nil
+	})
+}
+
+func runReplica(cfg *cli.JQLConfig) error {
+	primary, err := cfg.InitPrimaryDBMS()
+	if err != nil {
+		return err
+	}
+	replica, err := api.NewReplica(primary)
+	if err != nil {
+		return err
+	}
+	if cfg.AuthzPolicy != "" {
+		// authz policies name tables exactly
+		replica.RequireExactTables()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := replica.Bootstrap(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to bootstrap from primary: %v", err)
+	}
+	log.Printf("replicating from %v", cfg.Primary)
+	go replica.Run(ctx, stream)
+	return serveDaemon(cfg, replica, func() error {
+		cancel()
+		return nil
+	})
+}
+
+// serveDaemon serves the backend on every configured listener until the
+// daemon is signaled to stop. It then stops accepting new RPCs, waits for
+// in-flight RPCs to finish, and calls onShutdown.
+func serveDaemon(cfg *cli.JQLConfig, backend jqlpb.JQLServer, onShutdown func() error) error {
 	lis, err := net.Listen("tcp", cfg.Addr)
 	if err != nil {
 		return fmt.Errorf("failed to listen: %v", err)
@@ -66,6 +167,28 @@ func runDaemon(cfg *cli.JQLConfig, dbms api.JQL_DBMS) error {
 		grpc.MaxSendMsgSize(cli.MaxPayloadSize),
 		grpc.MaxRecvMsgSize(cli.MaxPayloadSize),
 	}
+	// requests are logged and recorded before authorization so that
+	// denied requests show up in the logs and stats
+	stats := api.NewRequestStats(slog.Default())
+	interceptors := []grpc.UnaryServerInterceptor{stats.UnaryInterceptor()}
+	streamInterceptors := []grpc.StreamServerInterceptor{stats.StreamInterceptor()}
+	authz, err := cfg.Authz()
+	if err != nil {
+		return err
+	}
+	if authz != nil {
+		interceptors = append(interceptors, authz.UnaryInterceptor())
+		streamInterceptors = append(streamInterceptors, authz.StreamInterceptor())
+	}
+	sizeOpts = append(sizeOpts,
+		grpc.ChainUnaryInterceptor(interceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	)
+	unixOpts := append([]grpc.ServerOption{}, sizeOpts...)
+	if authz != nil {
+		// unix clients are identified by their UID when authz is enabled
+		unixOpts = append(unixOpts, grpc.Creds(api.UnixPeerCredentials()))
+	}
 	tcpOpts := append([]grpc.ServerOption{}, sizeOpts...)
 	tlsOpt, err := cfg.ServerCredentials()
 	if err != nil {
@@ -74,33 +197,97 @@ func runDaemon(cfg *cli.JQLConfig, dbms api.JQL_DBMS) error {
 	if tlsOpt != nil {
 		tcpOpts = append(tcpOpts, tlsOpt)
 	}
-	var backend jqlpb.JQLServer
-	backend = api.NewDBMSShim(dbms)
-	if cfg.VirtualGateway != "" {
-		gateway, err := cfg.InitVirtualDBMS()
-		if err != nil {
-			return err
-		}
-		backend = api.NewRouter(backend, api.NewDBMSShim(gateway))
-	}
+	healthServer := health.NewServer()
+	servers := []*grpc.Server{}
+	serveErrs := make(chan error, 3)
 	if cfg.ListenUnix != "" {
 		os.Remove(cfg.ListenUnix)
+		defer os.Remove(cfg.ListenUnix)
 		unixLis, err := net.Listen("unix", cfg.ListenUnix)
 		if err != nil {
 			return fmt.Errorf("failed to listen on unix socket: %v", err)
 		}
-		unixServer := grpc.NewServer(sizeOpts...)
+		unixServer := grpc.NewServer(unixOpts...)
 		jqlpb.RegisterJQLServer(unixServer, backend)
+		healthpb.RegisterHealthServer(unixServer, healthServer)
+		servers = append(servers, unixServer)
 		log.Printf("server additionally listening at unix://%v", cfg.ListenUnix)
-		go unixServer.Serve(unixLis)
+		go func() { serveErrs <- unixServer.Serve(unixLis) }()
 	}
 	s := grpc.NewServer(tcpOpts...)
 	jqlpb.RegisterJQLServer(s, backend)
+	healthpb.RegisterHealthServer(s, healthServer)
+	servers = append(servers, s)
 	log.Printf("server listening at %v", lis.Addr())
-	if err := s.Serve(lis); err != nil {
-		return fmt.Errorf("failed to serve: %v", err)
+	go func() { serveErrs <- s.Serve(lis) }()
+	var httpServer *http.Server
+	if cfg.ListenHTTP != "" {
+		tlsCfg, err := cfg.ServerTLSConfig()
+		if err != nil {
+			return err
+		}
+		httpLis, err := net.Listen("tcp", cfg.ListenHTTP)
+		if err != nil {
+			return fmt.Errorf("failed to listen for http: %v", err)
+		}
+		httpServer = &http.Server{Handler: api.NewHTTPGateway(backend, interceptors...)}
+		if tlsCfg != nil {
+			// clients of the gateway are held to the same mTLS as gRPC
+			// clients and identified by their certificates
+			httpLis = tls.NewListener(httpLis, tlsCfg)
+			log.Printf("server additionally listening at https://%v", httpLis.Addr())
+		} else {
+			log.Printf("server additionally listening at http://%v", httpLis.Addr())
+		}
+		go func() {
+			if err := httpServer.Serve(httpLis); err != http.ErrServerClosed {
+				serveErrs <- err
+			}
+		}()
+	}
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus(jqlpb.JQL_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	var serveErr error
+	select {
+	case sig := <-signals:
+		log.Printf("received %v, shutting down", sig)
+	case err := <-serveErrs:
+		serveErr = fmt.Errorf("failed to serve: %v", err)
+	}
+
+	healthServer.Shutdown()
+	if httpServer != nil {
+		// like gRPC servers, HTTP servers are closed if requests don't
+		// finish in time
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			httpServer.Close()
+		}
+		cancel()
+	}
+	for _, server := range servers {
+		drain(server)
+	}
+	return errors.Join(serveErr, onShutdown())
+}
+
+// drain gracefully stops the server, forcing it to stop if in-flight RPCs
+// don't finish in time. Mutation watchers in particular never finish on their
+// own.
+func drain(server *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(drainTimeout):
+		server.Stop()
 	}
-	return nil
 }
 
 func runUI(cfg *cli.JQLConfig, dbms api.JQL_DBMS) error {
@@ -116,6 +303,18 @@ func runUI(cfg *cli.JQLConfig, dbms api.JQL_DBMS) error {
 		// subsequent UI changes
 		cfg.Query = ""
 	}
+	var initialView *api.View
+	if cfg.View != "" {
+		view, err := api.GetView(context.Background(), dbms, cfg.View)
+		if err != nil {
+			return err
+		}
+		initialView = view
+		cfg.Table = view.Table
+		// Reset the view so that it won't be carried over in
+		// subsequent UI changes
+		cfg.View = ""
+	}
 	mv, err := ui.NewMainView(dbms, cfg.Table)
 	if err != nil {
 		return err
@@ -126,7 +325,12 @@ func runUI(cfg *cli.JQLConfig, dbms api.JQL_DBMS) error {
 			return err
 		}
 	}
-	if initialQuery != nil {
+	if initialView != nil {
+		err = mv.LoadView(initialView)
+		if err != nil {
+			return err
+		}
+	} else if initialQuery != nil {
 		err = mv.LoadQuery(initialQuery)
 		if err != nil {
 			return err
@@ -152,12 +356,21 @@ func runUI(cfg *cli.JQLConfig, dbms api.JQL_DBMS) error {
 
 	g.SetManagerFunc(mv.Layout)
 
-	if err := g.SetKeybinding("", gocui.KeyCtrlC, gocui.ModNone, quit); err != nil {
+	// Ctrl-C cancels a running macro before it quits
+	if err := g.SetKeybinding("", gocui.KeyCtrlC, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if mv.CancelMacro() {
+			return nil
+		}
+		return quit(g, v)
+	}); err != nil {
 		return err
 	}
 
 	cycler := func(envvar, defaultVal string) func(g *gocui.Gui, v *gocui.View) error {
 		return func(g *gocui.Gui, v *gocui.View) error {
+			if mv.MacroRunning() {
+				return nil
+			}
 			_, err := dbms.Persist(context.Background(), &jqlpb.PersistRequest{})
 			if err != nil {
 				return err
@@ -0,0 +1,118 @@
+package osm
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/ulmenhaus/env/img/jql/storage"
+	"github.com/ulmenhaus/env/img/jql/types"
+	"github.com/ulmenhaus/env/proto/jql/jqlpb"
+)
+
+// MigrateEnum changes the set of values of an enum column, rewriting the schema
+// and every existing row. Values may be renamed, reordered, or removed. Rows whose
+// value is a key of renames take on the mapped value. It's an error for a row to
+// end up with a value that isn't in values, in which case nothing is changed.
+// It returns the number of rows whose value changed.
+func (osm *ObjectStoreMapper) MigrateEnum(tname, column string, values []string, renames map[string]string) (int, error) {
+	table, ok := osm.db.Tables[tname]
+	if !ok {
+		return 0, fmt.Errorf("no such table: %s", tname)
+	}
+	colix := table.IndexOfField(column)
+	if colix == -1 {
+		return 0, fmt.Errorf("no such column '%s' in table '%s'", column, tname)
+	}
+	meta := table.ColumnMeta[column]
+	if meta.Type != jqlpb.EntryType_ENUM {
+		return 0, fmt.Errorf("%s.%s is not an enum", tname, column)
+	}
+	if colix == table.Primary() {
+		return 0, fmt.Errorf("cannot migrate the primary column of %s", tname)
+	}
+	if len(values) == 0 {
+		return 0, fmt.Errorf("enums must have at least one value")
+	}
+	allowed := map[string]bool{}
+	for _, value := range values {
+		if value == "" || strings.Contains(value, ", ") {
+			return 0, fmt.Errorf("invalid enum value: %q", value)
+		}
+		if allowed[value] {
+			return 0, fmt.Errorf("duplicate enum value: %s", value)
+		}
+		allowed[value] = true
+	}
+
+	// Validate every row before changing anything so a failed migration leaves
+	// the table as it was
+	migrated := map[string]string{}
+	for pk, row := range table.Entries {
+		old := row[colix].Format("")
+		value := old
+		if renamed, ok := renames[old]; ok {
+			value = renamed
+		}
+		if !allowed[value] {
+			return 0, fmt.Errorf("row '%s' has value '%s' which is not in the new values", pk, old)
+		}
+		migrated[pk] = value
+	}
+
+	features := map[string]interface{}{}
+	for k, v := range table.Features(column) {
+		features[k] = v
+	}
+	features["values"] = strings.Join(values, ", ")
+	constructor := table.Constructors[column]
+	entries := map[string]types.Entry{}
+	for pk, value := range migrated {
+		entry, err := constructor(value, features)
+		if err != nil {
+			return 0, fmt.Errorf("failed to migrate %s.%s for %s: %s", tname, column, pk, err)
+		}
+		entries[pk] = entry
+	}
+
+	schemaKey := fmt.Sprintf("%s.%s", tname, column)
+	schema := storage.EncodedEntry{}
+	for k, v := range osm.db.Schemata[schemaKey] {
+		schema[k] = v
+	}
+	schema["features"] = features
+	osm.db.Schemata[schemaKey] = schema
+	table.SetFeatures(column, features)
+	meta.Values = values
+
+	changed := 0
+	for pk, entry := range entries {
+		row := table.Entries[pk]
+		if row[colix].Format("") != entry.Format("") {
+			changed++
+		}
+		row[colix] = entry
+		osm.RowUpdating(tname, pk)
+	}
+	osm.mu.Lock()
+	osm.schemaUpdated = true
+	osm.mu.Unlock()
+	return changed, nil
+}
+
+// storeSchemata writes the schemata to the directory if they have been modified
+// since they were loaded
+func (osm *ObjectStoreMapper) storeSchemata() error {
+	if !osm.schemaUpdated {
+		return nil
+	}
+	err := osm.writeShard(
+		filepath.Join(osm.path, fmt.Sprintf("%s.json", schemataTableName)),
+		osm.db.Schemata,
+	)
+	if err != nil {
+		return err
+	}
+	osm.schemaUpdated = false
+	return nil
+}
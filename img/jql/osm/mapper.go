@@ -8,9 +8,12 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/ulmenhaus/env/img/jql/storage"
 	"github.com/ulmenhaus/env/img/jql/types"
@@ -30,6 +33,12 @@ var (
 		"id":       types.NewID,
 		"time":     types.NewTime,
 		"moneyamt": types.NewMoneyAmount,
+		"bool":     types.NewBool,
+		"float":    types.NewFloat,
+		"decimal":  types.NewFloat,
+		"duration": types.NewDuration,
+		"url":      types.NewURL,
+		"tags":     types.NewTags,
 	} // maps field types to their corresponding constructor functions
 	fieldTypes = map[string]jqlpb.EntryType{
 		"string":   jqlpb.EntryType_STRING,
@@ -39,6 +48,12 @@ var (
 		"id":       jqlpb.EntryType_ID,
 		"time":     jqlpb.EntryType_TIME,
 		"moneyamt": jqlpb.EntryType_MONEYAMT,
+		"bool":     jqlpb.EntryType_BOOL,
+		"float":    jqlpb.EntryType_FLOAT,
+		"decimal":  jqlpb.EntryType_FLOAT,
+		"duration": jqlpb.EntryType_DURATION,
+		"url":      jqlpb.EntryType_URL,
+		"tags":     jqlpb.EntryType_TAGS,
 	}
 )
 
@@ -61,6 +76,16 @@ type ObjectStoreMapper struct {
 	// Set of keys which have been updated
 	// if nil a snapshot was loaded and we update everything
 	updates map[update]bool
+	// schemaUpdated is true iff the schemata were modified since they
+	// were last stored
+	schemaUpdated bool
+
+	// lastUpdated is when a row was last modified
+	lastUpdated time.Time
+	// lastStored is when the entries were last successfully stored and
+	// lastStoreDuration is how long it took
+	lastStored        time.Time
+	lastStoreDuration time.Duration
 }
 
 // NewObjectStoreMapper returns a new ObjectStoreMapper given a storage driver
@@ -87,11 +112,47 @@ func (osm *ObjectStoreMapper) getAndPurgeUpdates() map[update]bool {
 func (osm *ObjectStoreMapper) RowUpdating(tname, pk string) {
 	osm.mu.Lock()
 	defer osm.mu.Unlock()
+	osm.lastUpdated = time.Now()
 	if osm.updates != nil {
 		osm.updates[newUpdate(osm.db.Tables[tname], tname, pk)] = true
 	}
 }
 
+// IDsGenerated records the features of the table's ID columns in the
+// schemata if they've changed, i.e. when a sequence has advanced, so that
+// they're stored along with the entries
+func (osm *ObjectStoreMapper) IDsGenerated(tname string) {
+	table := osm.db.Tables[tname]
+	for _, column := range table.Columns {
+		if meta, ok := table.ColumnMeta[column]; !ok || meta.Type != jqlpb.EntryType_ID {
+			continue
+		}
+		schemaKey := fmt.Sprintf("%s.%s", tname, column)
+		features := table.Features(column)
+		stored, _ := osm.db.Schemata[schemaKey]["features"].(map[string]interface{})
+		if len(features) == 0 || reflect.DeepEqual(stored, features) {
+			continue
+		}
+		schema := storage.EncodedEntry{}
+		for k, v := range osm.db.Schemata[schemaKey] {
+			schema[k] = v
+		}
+		schema["features"] = features
+		osm.db.Schemata[schemaKey] = schema
+		osm.mu.Lock()
+		osm.schemaUpdated = true
+		osm.mu.Unlock()
+	}
+}
+
+// LastUpdate returns when a row was last modified. The time is zero if no
+// rows have been modified since the mapper was created.
+func (osm *ObjectStoreMapper) LastUpdate() time.Time {
+	osm.mu.Lock()
+	defer osm.mu.Unlock()
+	return osm.lastUpdated
+}
+
 func (osm *ObjectStoreMapper) GetDB() *types.Database {
 	return osm.db
 }
@@ -181,6 +242,14 @@ func (osm *ObjectStoreMapper) loadEncodedDB(raw storage.EncodedDatabase) error {
 	constructorsByTable := map[string](map[string]types.FieldValueConstructor){}
 	featuresByColumnByTable := map[string](map[string](map[string]interface{})){}
 	columnMetaByTable := map[string](map[string]*types.ColumnMeta){}
+	allTables := []string{}
+	for name := range schemata {
+		table := strings.Split(name, ".")[0]
+		if !slices.Contains(allTables, table) {
+			allTables = append(allTables, table)
+		}
+	}
+	sort.Strings(allTables)
 	for name, schema := range schemata {
 		parts := strings.Split(name, ".")
 		if len(parts) != 2 {
@@ -202,6 +271,14 @@ func (osm *ObjectStoreMapper) loadEncodedDB(raw storage.EncodedDatabase) error {
 			// ignoring for now
 			continue
 		}
+		features := map[string]interface{}{}
+		featuresUncast, ok := schema["features"]
+		if ok {
+			features, ok = featuresUncast.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("invalid type for `features`")
+			}
+		}
 		if primary, ok := schema["primary"]; ok {
 			if primaryB, ok := primary.(bool); ok && primaryB {
 				if currentPrimary, ok := primariesByTable[table]; ok {
@@ -213,6 +290,7 @@ func (osm *ObjectStoreMapper) loadEncodedDB(raw storage.EncodedDatabase) error {
 		var constructor types.FieldValueConstructor
 		var entryType jqlpb.EntryType
 		var foreignTable string
+		var foreignTables []string
 		var values []string
 		if strings.HasPrefix(fieldType, "foreign.") {
 			// TODO(rabrams) double check scoping of this variable
@@ -241,6 +319,19 @@ func (osm *ObjectStoreMapper) loadEncodedDB(raw storage.EncodedDatabase) error {
 				features["table"] = table
 				return types.NewForeignList(i, features)
 			}
+		} else if fieldType == "polyforeign" {
+			entryType = jqlpb.EntryType_POLYFOREIGN
+			tables, err := polyforeignTables(features, allTables)
+			if err != nil {
+				return fmt.Errorf("invalid polyforeign %s.%s: %s", table, column, err)
+			}
+			foreignTables = tables
+			// virtual tables aren't known to the database so unrestricted
+			// keys may refer to any of them
+			_, restricted := features["tables"]
+			constructor = func(i interface{}, features map[string]interface{}) (types.Entry, error) {
+				return types.NewPolyForeignKey(i, map[string]interface{}{"tables": tables, "virtual": !restricted})
+			}
 		} else {
 			constructor, ok = constructors[fieldType]
 			if !ok {
@@ -251,6 +342,11 @@ func (osm *ObjectStoreMapper) loadEncodedDB(raw storage.EncodedDatabase) error {
 				return fmt.Errorf("invalid type '%s'", fieldType)
 			}
 		}
+		if entryType == jqlpb.EntryType_ENUM {
+			if valuesS, ok := features["values"].(string); ok {
+				values = strings.Split(valuesS, ", ")
+			}
+		}
 		byTable, ok := fieldsByTable[table]
 		var primaryShards int
 		if shard, ok := schema["primary_shards"]; ok {
@@ -271,6 +367,7 @@ func (osm *ObjectStoreMapper) loadEncodedDB(raw storage.EncodedDatabase) error {
 		meta := &types.ColumnMeta{
 			Type:            entryType,
 			ForeignTable:    foreignTable,
+			ForeignTables:   foreignTables,
 			Values:          values,
 			PrimaryShards:   primaryShards,
 			SecondaryShards: secondaryShards,
@@ -287,14 +384,6 @@ func (osm *ObjectStoreMapper) loadEncodedDB(raw storage.EncodedDatabase) error {
 			constructorsByTable[table][column] = constructor
 			columnMetaByTable[table][column] = meta
 		}
-		features := map[string]interface{}{}
-		featuresUncast, ok := schema["features"]
-		if ok {
-			features, ok = featuresUncast.(map[string]interface{})
-			if !ok {
-				return fmt.Errorf("invalid type for `features`")
-			}
-		}
 		featuresByColumnByTable[table][column] = features
 	}
 
@@ -356,6 +445,36 @@ func (osm *ObjectStoreMapper) loadEncodedDB(raw storage.EncodedDatabase) error {
 	return nil
 }
 
+// polyforeignTables returns the tables to which a polyforeign column may refer
+// given its features. If the features don't restrict the tables then any table
+// in the database may be referenced. Virtual tables may be listed even though
+// they aren't in the database.
+func polyforeignTables(features map[string]interface{}, allTables []string) ([]string, error) {
+	var tables []string
+	switch typed := features["tables"].(type) {
+	case nil:
+		return allTables, nil
+	case string:
+		tables = strings.Split(typed, ", ")
+	case []interface{}:
+		for _, tableI := range typed {
+			table, ok := tableI.(string)
+			if !ok {
+				return nil, fmt.Errorf("tables must be strings")
+			}
+			tables = append(tables, table)
+		}
+	default:
+		return nil, fmt.Errorf("invalid type for tables: %T", typed)
+	}
+	for _, table := range tables {
+		if !slices.Contains(allTables, table) && !types.IsVirtualTable(table) {
+			return nil, fmt.Errorf("unknown table: %s", table)
+		}
+	}
+	return tables, nil
+}
+
 func (osm *ObjectStoreMapper) dumpSnapshot(db *types.Database, dst io.Writer) error {
 	encoded := storage.EncodedDatabase{
 		schemataTableName: db.Schemata,
@@ -387,25 +506,85 @@ func (osm *ObjectStoreMapper) encodedRow(table *types.Table, pk string) storage.
 	return encodedEntry
 }
 
+// DirtyRows returns the number of rows in each table that have been modified
+// since the entries were last stored
+func (osm *ObjectStoreMapper) DirtyRows() map[string]int {
+	osm.mu.Lock()
+	defer osm.mu.Unlock()
+	dirty := map[string]int{}
+	if osm.updates == nil {
+		for name, table := range osm.db.Tables {
+			dirty[name] = len(table.Entries)
+		}
+		return dirty
+	}
+	// a row may be recorded under multiple shard keys
+	seen := map[update]bool{}
+	for u := range osm.updates {
+		u.key = ""
+		if !seen[u] {
+			seen[u] = true
+			dirty[u.table]++
+		}
+	}
+	return dirty
+}
+
+// LastStore returns when the entries were last successfully stored and how
+// long it took. The time is zero if they have not been stored.
+func (osm *ObjectStoreMapper) LastStore() (time.Time, time.Duration) {
+	osm.mu.Lock()
+	defer osm.mu.Unlock()
+	return osm.lastStored, osm.lastStoreDuration
+}
+
 func (osm *ObjectStoreMapper) StoreEntries() error {
 	osm.mu.Lock()
 	defer osm.mu.Unlock()
+	start := time.Now()
+	err := osm.storeEntries()
+	if err != nil {
+		return err
+	}
+	osm.lastStored = time.Now()
+	osm.lastStoreDuration = osm.lastStored.Sub(start)
+	return nil
+}
+
+// storeEntries stores the updated entries. Updates are only cleared once
+// they've been stored so that a failed store is retried.
+func (osm *ObjectStoreMapper) storeEntries() error {
 	if strings.HasSuffix(osm.path, ".json") {
 		dst, err := os.OpenFile(osm.path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
 		if err != nil {
 			return err
 		}
-		defer dst.Close()
-		return osm.dumpSnapshot(osm.db, dst)
+		err = osm.dumpSnapshot(osm.db, dst)
+		if closeErr := dst.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+		// the whole database is written so everything is clean afterwards
+		osm.getAndPurgeUpdates()
+		return nil
 	} else if strings.HasSuffix(osm.path, ".jql") {
-		updates := osm.getAndPurgeUpdates()
-		return osm.storeAsDirectory(updates)
+		err := osm.storeAsDirectory(osm.updates)
+		if err != nil {
+			return err
+		}
+		osm.getAndPurgeUpdates()
+		return nil
 	} else {
 		return fmt.Errorf("invalid path: %s", osm.path)
 	}
 }
 
 func (osm *ObjectStoreMapper) storeAsDirectory(updates map[update]bool) error {
+	if err := osm.storeSchemata(); err != nil {
+		return err
+	}
 	for name, table := range osm.db.Tables {
 		err := osm.storeTableInDirectory(updates, name, table)
 		if err != nil {
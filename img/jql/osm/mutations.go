@@ -0,0 +1,49 @@
+package osm
+
+import (
+	"fmt"
+
+	"github.com/ulmenhaus/env/img/jql/storage"
+	"github.com/ulmenhaus/env/img/jql/types"
+)
+
+// EncodedRow returns the row of the given table as it is encoded in storage.
+// Returns false if the row does not exist.
+func (osm *ObjectStoreMapper) EncodedRow(tname, pk string) (storage.EncodedEntry, bool, error) {
+	table, ok := osm.db.Tables[tname]
+	if !ok {
+		return nil, false, fmt.Errorf("Unknown table: %s", tname)
+	}
+	if _, ok := table.Entries[pk]; !ok {
+		return nil, false, nil
+	}
+	return osm.encodedRow(table, pk), true, nil
+}
+
+// ApplyEncodedRow replaces the row of the given table with one decoded from
+// its storage encoding, or deletes the row if encoded is nil. It's used to
+// mirror mutations made to another database with the same schemata.
+func (osm *ObjectStoreMapper) ApplyEncodedRow(tname, pk string, encoded storage.EncodedEntry) error {
+	table, ok := osm.db.Tables[tname]
+	if !ok {
+		return fmt.Errorf("Unknown table: %s", tname)
+	}
+	if encoded == nil {
+		delete(table.Entries, pk)
+		return nil
+	}
+	row := make([]types.Entry, len(table.Columns))
+	for i, column := range table.Columns {
+		var value interface{} = encoded[column]
+		if i == table.Primary() {
+			value = pk
+		}
+		entry, err := table.Constructors[column](value, table.Features(column))
+		if err != nil {
+			return fmt.Errorf("failed to init %s.%s for %s: %s", tname, column, pk, err)
+		}
+		row[i] = entry
+	}
+	table.Entries[pk] = row
+	return nil
+}
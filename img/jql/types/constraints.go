@@ -0,0 +1,148 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"unicode/utf8"
+)
+
+const (
+	// RequiredFeature is the feature of a column set to true when the column
+	// may not be empty
+	RequiredFeature = "required"
+	// UniqueFeature is the feature of a column set to true when no two rows
+	// may have the same value for the column. Rows may share an empty value
+	// unless the column is also required.
+	UniqueFeature = "unique"
+	// PatternFeature is the feature of a column holding a regular expression
+	// the whole formatted value must match
+	PatternFeature = "pattern"
+	// MinFeature is the feature of a numeric, date, or time column holding the
+	// smallest value, in user input form, the column may take
+	MinFeature = "min"
+	// MaxFeature is the feature of a numeric, date, or time column holding the
+	// largest value, in user input form, the column may take
+	MaxFeature = "max"
+	// MaxLengthFeature is the feature of a column holding the maximum number
+	// of characters of the formatted value
+	MaxLengthFeature = "max_length"
+)
+
+// CheckConstraints returns an error if the entry may not be written to the
+// column of the row with the given pk because it violates one of the
+// column's constraints
+func (t *Table) CheckConstraints(pk, column string, entry Entry) error {
+	err := t.checkConstraints(pk, column, entry)
+	if err != nil {
+		return fmt.Errorf("invalid value for %s: %s", column, err)
+	}
+	return nil
+}
+
+func (t *Table) checkConstraints(pk, column string, entry Entry) error {
+	features := t.featuresByColumn[column]
+	formatted := entry.Format("")
+	required, err := boolFeature(features, RequiredFeature)
+	if err != nil {
+		return err
+	}
+	if required && formatted == "" {
+		return fmt.Errorf("a value is required")
+	}
+	unique, err := boolFeature(features, UniqueFeature)
+	if err != nil {
+		return err
+	}
+	if unique && formatted != "" {
+		col := t.columnsByName[column]
+		for other, row := range t.Entries {
+			if other != pk && row[col].Format("") == formatted {
+				return fmt.Errorf("%q is already used by %s", formatted, other)
+			}
+		}
+	}
+	if patternI, ok := features[PatternFeature]; ok {
+		pattern, ok := patternI.(string)
+		if !ok {
+			return fmt.Errorf("%s must be a string", PatternFeature)
+		}
+		re, err := regexp.Compile("^(?:" + pattern + ")$")
+		if err != nil {
+			return fmt.Errorf("invalid %s: %s", PatternFeature, err)
+		}
+		if !re.MatchString(formatted) {
+			return fmt.Errorf("%q does not match %s", formatted, pattern)
+		}
+	}
+	if bound, ok, err := boundFeature(features, MinFeature, entry); err != nil {
+		return err
+	} else if ok && entry.Compare(bound) {
+		return fmt.Errorf("%q is less than the min of %s", formatted, bound.Format(""))
+	}
+	if bound, ok, err := boundFeature(features, MaxFeature, entry); err != nil {
+		return err
+	} else if ok && bound.Compare(entry) {
+		return fmt.Errorf("%q is greater than the max of %s", formatted, bound.Format(""))
+	}
+	if maxLengthI, ok := features[MaxLengthFeature]; ok {
+		maxLength, ok := maxLengthI.(float64)
+		if !ok {
+			return fmt.Errorf("%s must be a number", MaxLengthFeature)
+		}
+		if length := utf8.RuneCountInString(formatted); length > int(maxLength) {
+			return fmt.Errorf("%q is %d characters long which is more than the max length of %d", formatted, length, int(maxLength))
+		}
+	}
+	return nil
+}
+
+// checkRow checks the constraints of every column of a row
+func (t *Table) checkRow(pk string) error {
+	row, ok := t.Entries[pk]
+	if !ok {
+		return fmt.Errorf("Row does not exist with pk %s", pk)
+	}
+	for i, column := range t.Columns {
+		if err := t.CheckConstraints(pk, column, row[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// boolFeature returns the value of a feature that must be a bool if set
+func boolFeature(features map[string]interface{}, feature string) (bool, error) {
+	valueI, ok := features[feature]
+	if !ok {
+		return false, nil
+	}
+	value, ok := valueI.(bool)
+	if !ok {
+		return false, fmt.Errorf("%s must be a bool", feature)
+	}
+	return value, nil
+}
+
+// boundFeature returns the min or max of a column as an entry of the same type
+// as the provided one. Bounds may be numbers or user input such as "today".
+func boundFeature(features map[string]interface{}, feature string, entry Entry) (Entry, bool, error) {
+	boundI, ok := features[feature]
+	if !ok {
+		return nil, false, nil
+	}
+	var input string
+	switch typed := boundI.(type) {
+	case string:
+		input = typed
+	case float64:
+		input = strconv.FormatFloat(typed, 'f', -1, 64)
+	default:
+		return nil, false, fmt.Errorf("%s must be a string or number", feature)
+	}
+	bound, err := entry.Reverse("", input)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid %s: %s", feature, err)
+	}
+	return bound, true, nil
+}
@@ -0,0 +1,149 @@
+package types
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstraints(t *testing.T) {
+	cases := []struct {
+		name     string
+		features map[string]map[string]interface{}
+		fields   map[string]string
+		err      string
+		// updateOK is set when updating the existing row with the fields
+		// doesn't violate the constraint
+		updateOK bool
+	}{
+		{
+			name:     "required",
+			features: map[string]map[string]interface{}{"Notes": {"required": true}},
+			fields:   map[string]string{"Points": "3"},
+			err:      "invalid value for Notes: a value is required",
+			updateOK: true,
+		},
+		{
+			name:     "required and set",
+			features: map[string]map[string]interface{}{"Notes": {"required": true}},
+			fields:   map[string]string{"Notes": "see logs"},
+		},
+		{
+			name:     "unique",
+			features: map[string]map[string]interface{}{"Notes": {"unique": true}},
+			fields:   map[string]string{"Notes": "flaky"},
+			err:      `invalid value for Notes: "flaky" is already used by fix tests`,
+			updateOK: true,
+		},
+		{
+			name:     "pattern",
+			features: map[string]map[string]interface{}{"Notes": {"pattern": `[A-Z]+-[0-9]+`}},
+			fields:   map[string]string{"Notes": "see JQL-12 for details"},
+			err:      `invalid value for Notes: "see JQL-12 for details" does not match [A-Z]+-[0-9]+`,
+		},
+		{
+			name:     "pattern matches",
+			features: map[string]map[string]interface{}{"Notes": {"pattern": `[A-Z]+-[0-9]+`}},
+			fields:   map[string]string{"Notes": "JQL-12"},
+		},
+		{
+			name:     "min",
+			features: map[string]map[string]interface{}{"Points": {"min": float64(1)}},
+			fields:   map[string]string{"Points": "0"},
+			err:      `invalid value for Points: "0" is less than the min of 1`,
+		},
+		{
+			name:     "max",
+			features: map[string]map[string]interface{}{"Points": {"max": "13"}},
+			fields:   map[string]string{"Points": "21"},
+			err:      `invalid value for Points: "21" is greater than the max of 13`,
+		},
+		{
+			name:     "within bounds",
+			features: map[string]map[string]interface{}{"Points": {"min": float64(1), "max": float64(13)}},
+			fields:   map[string]string{"Points": "13"},
+		},
+		{
+			name:     "date min",
+			features: map[string]map[string]interface{}{"Due": {"min": "2026-01-01"}},
+			fields:   map[string]string{"Due": "31 Dec 2025"},
+			err:      `invalid value for Due: "31 Dec 2025" is less than the min of 01 Jan 2026`,
+		},
+		{
+			name:     "max length",
+			features: map[string]map[string]interface{}{"Notes": {"max_length": float64(5)}},
+			fields:   map[string]string{"Notes": "résumé"},
+			err:      `invalid value for Notes: "résumé" is 6 characters long which is more than the max length of 5`,
+		},
+		{
+			name:     "invalid pattern",
+			features: map[string]map[string]interface{}{"Notes": {"pattern": `(`}},
+			fields:   map[string]string{"Notes": "JQL-12"},
+			err:      "invalid value for Notes: invalid pattern: error parsing regexp: missing closing ): `^(?:()$`",
+		},
+		{
+			name:     "non-bool required",
+			features: map[string]map[string]interface{}{"Notes": {"required": "yes"}},
+			err:      "invalid value for Notes: required must be a bool",
+		},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			table := newTaskTable(map[string]map[string]interface{}{})
+			require.NoError(t, table.InsertWithFields("fix tests", map[string]string{"Notes": "flaky", "Points": "5"}))
+			for column, features := range tc.features {
+				table.SetFeatures(column, features)
+			}
+			err := table.InsertWithFields("fix build", tc.fields)
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+				// rows that violate constraints aren't inserted
+				_, ok := table.Entries["fix build"]
+				require.False(t, ok)
+				// nor are existing rows updated to violate them
+				for field, value := range tc.fields {
+					err := table.Update("fix tests", field, value)
+					if tc.updateOK {
+						require.NoError(t, err)
+					} else {
+						require.Error(t, err)
+					}
+				}
+				return
+			}
+			require.NoError(t, err)
+			for field, value := range tc.fields {
+				require.Equal(t, value, table.Entries["fix build"][table.IndexOfField(field)].Format(""))
+			}
+		})
+	}
+}
+
+func TestUniqueEmptyValues(t *testing.T) {
+	table := newTaskTable(map[string]map[string]interface{}{"Notes": {"unique": true}})
+	require.NoError(t, table.InsertWithFields("fix tests", map[string]string{"Points": "5"}))
+	require.NoError(t, table.InsertWithFields("fix build", map[string]string{"Points": "3"}))
+	require.NoError(t, table.Update("fix build", "Notes", "flaky"))
+	require.Error(t, table.Update("fix tests", "Notes", "flaky"))
+}
+
+func TestUpdateFieldsIsAtomic(t *testing.T) {
+	table := newTaskTable(map[string]map[string]interface{}{"Points": {"max": float64(13)}})
+	require.NoError(t, table.InsertWithFields("fix tests", map[string]string{"Notes": "flaky", "Points": "5"}))
+	for i := 0; i < 10; i++ {
+		// fields are applied in a random order so try a few times
+		pk, err := table.UpdateFields("fix tests", map[string]string{
+			"Name":   "fix more tests",
+			"Notes":  "very flaky",
+			"Status": "Active",
+			"Points": "21",
+		})
+		require.Error(t, err)
+		require.Equal(t, "fix tests", pk)
+		row := table.Entries["fix tests"]
+		require.Equal(t, "flaky", row[table.IndexOfField("Notes")].Format(""))
+		require.Equal(t, "Pending", row[table.IndexOfField("Status")].Format(""))
+		require.Equal(t, "5", row[table.IndexOfField("Points")].Format(""))
+	}
+}
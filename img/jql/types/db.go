@@ -41,6 +41,7 @@ type ColumnMeta struct {
 	Type            jqlpb.EntryType
 	MaxLength       int
 	ForeignTable    string
+	ForeignTables   []string
 	Values          []string
 	PrimaryShards   int
 	SecondaryShards int
@@ -170,7 +171,8 @@ func (t *Table) Query(params QueryParams) (*Response, error) {
 	return resp, nil
 }
 
-// Insert adds a new row to the table
+// Insert adds a new row to the table. Columns other than the primary
+// column and generated IDs are set to their initial values.
 func (t *Table) Insert(pk string) error {
 	// TODO Insert needs to be gorouting safe
 	_, ok := t.Entries[pk]
@@ -181,54 +183,144 @@ func (t *Table) Insert(pk string) error {
 	for i, col := range t.Columns {
 		constructor := t.Constructors[col]
 		var input interface{}
+		generated := false
 		if i == t.primary {
 			input = pk
+		} else if meta, ok := t.ColumnMeta[col]; ok && meta.Type == jqlpb.EntryType_ID {
+			id, err := t.GenerateID(col)
+			if err != nil {
+				return err
+			}
+			input = id
+		} else {
+			generated = true
 		}
 		entry, err := constructor(input, t.featuresByColumn[t.Columns[i]])
 		if err != nil {
 			return err
 		}
+		if generated {
+			entry, err = t.initialValue(col, entry)
+			if err != nil {
+				return err
+			}
+		}
 		row = append(row, entry)
 	}
 	t.Entries[pk] = row
 	return nil
 }
 
+// InsertWithFields adds a new row to the table with the provided fields. The
+// row is not added if it violates any column constraints.
 func (t *Table) InsertWithFields(pk string, fields map[string]string) error {
 	err := t.Insert(pk)
 	if err != nil {
 		return err
 	}
 	for field, value := range fields {
-		err = t.Update(pk, field, value)
+		pk, err = t.update(pk, field, value)
 		if err != nil {
+			delete(t.Entries, pk)
 			return err
 		}
 	}
+	err = t.checkRow(pk)
+	if err != nil {
+		delete(t.Entries, pk)
+		return err
+	}
 	return nil
 }
 
-// Update modifies a row
+// Update modifies a row and sets any columns with an on_update feature
+// other than the modified one to now. The row is not modified if the value
+// violates the column's constraints.
 func (t *Table) Update(pk, field, value string) error {
+	newPK, err := t.update(pk, field, value)
+	if err != nil {
+		return err
+	}
+	return t.Touch(newPK, field)
+}
+
+// UpdateFields modifies multiple fields of a row, including possibly its
+// primary key, and returns the pk of the row after the update. Columns with an
+// on_update feature that are not among the fields are set to now. The row is
+// not modified if any of the values is invalid.
+func (t *Table) UpdateFields(pk string, fields map[string]string) (string, error) {
+	// Parse every field before setting any so that an invalid value
+	// doesn't leave the row partially updated
+	entries := map[int]Entry{}
+	written := []string{}
+	for field, value := range fields {
+		col, entry, err := t.parse(pk, field, value)
+		if err != nil {
+			return pk, err
+		}
+		entries[col] = entry
+		if col != t.primary {
+			written = append(written, field)
+		}
+	}
+	// The pk is set last since the other fields are set by pk
+	for col, entry := range entries {
+		if col != t.primary {
+			t.set(pk, col, entry)
+		}
+	}
+	if err := t.Touch(pk, written...); err != nil {
+		return pk, err
+	}
+	if entry, ok := entries[t.primary]; ok {
+		pk = t.set(pk, t.primary, entry)
+	}
+	return pk, nil
+}
+
+// update modifies a single field of a row and returns the pk of the row
+// after the update
+func (t *Table) update(pk, field, value string) (string, error) {
+	col, entry, err := t.parse(pk, field, value)
+	if err != nil {
+		return pk, err
+	}
+	return t.set(pk, col, entry), nil
+}
+
+// parse returns the index of the field and the entry for the value if it
+// may be written to the row
+func (t *Table) parse(pk, field, value string) (int, Entry, error) {
 	col, ok := t.columnsByName[field]
 	if !ok {
-		return fmt.Errorf("Unknown column: %s", field)
+		return 0, nil, fmt.Errorf("Unknown column: %s", field)
 	}
 	current, ok := t.Entries[pk]
 	if !ok {
-		return fmt.Errorf("Row does not exist with pk %s", pk)
+		return 0, nil, fmt.Errorf("Row does not exist with pk %s", pk)
 	}
 	// TODO this needs to be passed the format string
 	new, err := current[col].Reverse("", value)
 	if err != nil {
-		return err
+		return 0, nil, err
 	}
-	current[col] = new
+	err = t.CheckConstraints(pk, field, new)
+	if err != nil {
+		return 0, nil, err
+	}
+	return col, new, nil
+}
+
+// set sets an entry of a row and returns the pk of the row afterwards
+func (t *Table) set(pk string, col int, entry Entry) string {
+	current := t.Entries[pk]
+	current[col] = entry
 	if col == t.primary {
 		delete(t.Entries, pk)
-		t.Entries[new.Format("")] = current
+		pk = entry.Format("")
+		t.Entries[pk] = current
 	}
-	return nil
+	return pk
 }
 
 // Primary returns the index of the primary key column of the table
@@ -256,6 +348,18 @@ func (t *Table) IndexOfField(field string) int {
 	return index
 }
 
+// Features returns the features configured for the given column
+func (t *Table) Features(column string) map[string]interface{} {
+	return t.featuresByColumn[column]
+}
+
+// SetFeatures replaces the features configured for the given column. New entries
+// for the column will be constructed with these features but it's up to the
+// caller to migrate existing entries.
+func (t *Table) SetFeatures(column string, features map[string]interface{}) {
+	t.featuresByColumn[column] = features
+}
+
 func (t *Table) calculateMaxLengths() {
 	for _, row := range t.Entries {
 		for i, entry := range row {
@@ -0,0 +1,83 @@
+package types
+
+import (
+	"fmt"
+	"slices"
+)
+
+const (
+	// DefaultFeature is the feature of a column holding the value, in user
+	// input form, new rows take for that column, e.g. "Pending" or "+1w"
+	DefaultFeature = "default"
+	// OnCreateFeature is the feature of a column set to "now" when the
+	// column should hold the time at which its row was inserted
+	OnCreateFeature = "on_create"
+	// OnUpdateFeature is the feature of a column set to "now" when the
+	// column should hold the time at which its row was last updated
+	OnUpdateFeature = "on_update"
+
+	// Now is the only supported value of the on_create and on_update
+	// features
+	Now = "now"
+)
+
+// initialValue returns the entry a column of a new row should start with
+// given the entry constructed without a value. An on_create feature takes
+// precedence over a default.
+func (t *Table) initialValue(column string, entry Entry) (Entry, error) {
+	features := t.featuresByColumn[column]
+	if ok, err := nowFeature(features, OnCreateFeature); err != nil {
+		return nil, fmt.Errorf("invalid %s for %s: %s", OnCreateFeature, column, err)
+	} else if ok {
+		return entry.Reverse("", Now)
+	}
+	defaultI, ok := features[DefaultFeature]
+	if !ok {
+		return entry, nil
+	}
+	value, ok := defaultI.(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid %s for %s: must be a string", DefaultFeature, column)
+	}
+	initial, err := entry.Reverse("", value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s for %s: %s", DefaultFeature, column, err)
+	}
+	return initial, nil
+}
+
+// Touch sets the columns of a row that have an on_update feature to now
+// except for the provided columns which were explicitly written
+func (t *Table) Touch(pk string, written ...string) error {
+	row, ok := t.Entries[pk]
+	if !ok {
+		return fmt.Errorf("Row does not exist with pk %s", pk)
+	}
+	for i, column := range t.Columns {
+		ok, err := nowFeature(t.featuresByColumn[column], OnUpdateFeature)
+		if err != nil {
+			return fmt.Errorf("invalid %s for %s: %s", OnUpdateFeature, column, err)
+		}
+		if !ok || i == t.primary || slices.Contains(written, column) {
+			continue
+		}
+		touched, err := row[i].Reverse("", Now)
+		if err != nil {
+			return fmt.Errorf("invalid %s for %s: %s", OnUpdateFeature, column, err)
+		}
+		row[i] = touched
+	}
+	return nil
+}
+
+// nowFeature returns true iff the feature is set to "now"
+func nowFeature(features map[string]interface{}, feature string) (bool, error) {
+	valueI, ok := features[feature]
+	if !ok {
+		return false, nil
+	}
+	if value, ok := valueI.(string); !ok || value != Now {
+		return false, fmt.Errorf("the only supported value is %q", Now)
+	}
+	return true, nil
+}
@@ -0,0 +1,169 @@
+package types
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ulmenhaus/env/proto/jql/jqlpb"
+)
+
+func newTaskTable(features map[string]map[string]interface{}) *Table {
+	columns := []string{"Created", "Due", "Modified", "Name", "Notes", "Points", "Status"}
+	constructors := map[string]FieldValueConstructor{
+		"Created":  NewTime,
+		"Due":      NewDate,
+		"Modified": NewTime,
+		"Name":     NewString,
+		"Notes":    NewString,
+		"Points":   NewInteger,
+		"Status":   NewEnum,
+	}
+	meta := map[string]*ColumnMeta{}
+	for _, column := range columns {
+		meta[column] = &ColumnMeta{Type: jqlpb.EntryType_STRING}
+		if features[column] == nil {
+			features[column] = map[string]interface{}{}
+		}
+	}
+	features["Status"]["values"] = "Pending, Active, Satisfied"
+	return NewTable(columns, map[string][]Entry{}, "Name", constructors, features, meta)
+}
+
+func TestInsertDefaults(t *testing.T) {
+	cases := []struct {
+		name     string
+		features map[string]map[string]interface{}
+		expected map[string]string
+		now      []string
+		err      bool
+	}{
+		{
+			name: "constructor defaults",
+			expected: map[string]string{
+				"Created": "01 Jan 1970 00:00:00",
+				"Points":  "0",
+				"Status":  "Pending",
+			},
+		},
+		{
+			name: "configured defaults",
+			features: map[string]map[string]interface{}{
+				"Points": {"default": "3"},
+				"Status": {"default": "Active"},
+			},
+			expected: map[string]string{
+				"Created": "01 Jan 1970 00:00:00",
+				"Points":  "3",
+				"Status":  "Active",
+			},
+		},
+		{
+			name: "date default",
+			features: map[string]map[string]interface{}{
+				"Due": {"default": "2026-10-17"},
+			},
+			expected: map[string]string{"Due": "17 Oct 2026"},
+		},
+		{
+			name: "created now",
+			features: map[string]map[string]interface{}{
+				"Created":  {"on_create": "now", "default": "2020-01-01"},
+				"Modified": {"on_update": "now"},
+			},
+			expected: map[string]string{"Modified": "01 Jan 1970 00:00:00"},
+			now:      []string{"Created"},
+		},
+		{
+			name: "invalid default",
+			features: map[string]map[string]interface{}{
+				"Status": {"default": "Blocked"},
+			},
+			err: true,
+		},
+		{
+			name: "non-string default",
+			features: map[string]map[string]interface{}{
+				"Points": {"default": float64(3)},
+			},
+			err: true,
+		},
+		{
+			name: "unsupported on_create",
+			features: map[string]map[string]interface{}{
+				"Created": {"on_create": "yesterday"},
+			},
+			err: true,
+		},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			if tc.features == nil {
+				tc.features = map[string]map[string]interface{}{}
+			}
+			table := newTaskTable(tc.features)
+			before := time.Now().Add(-time.Second)
+			err := table.Insert("fix build")
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			row := table.Entries["fix build"]
+			for column, expected := range tc.expected {
+				require.Equal(t, expected, row[table.IndexOfField(column)].Format(""), column)
+			}
+			for _, column := range tc.now {
+				secs := row[table.IndexOfField(column)].(Time).secs
+				require.True(t, int64(secs) >= before.Unix(), column)
+			}
+		})
+	}
+}
+
+func TestUpdateTouches(t *testing.T) {
+	cases := []struct {
+		name    string
+		fields  map[string]string
+		touched bool
+		pk      string
+	}{
+		{
+			name:    "other column",
+			fields:  map[string]string{"Status": "Active"},
+			touched: true,
+			pk:      "fix build",
+		},
+		{
+			name:    "explicit value",
+			fields:  map[string]string{"Status": "Active", "Modified": "01 Jan 2020 00:00:00"},
+			touched: false,
+			pk:      "fix build",
+		},
+		{
+			name:    "rename",
+			fields:  map[string]string{"Name": "fix the build"},
+			touched: true,
+			pk:      "fix the build",
+		},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			table := newTaskTable(map[string]map[string]interface{}{
+				"Modified": {"on_update": "now"},
+			})
+			require.NoError(t, table.InsertWithFields("fix build", map[string]string{"Modified": "01 Jan 2019 00:00:00"}))
+			before := time.Now().Add(-time.Second)
+			pk, err := table.UpdateFields("fix build", tc.fields)
+			require.NoError(t, err)
+			require.Equal(t, tc.pk, pk)
+			modified := table.Entries[pk][table.IndexOfField("Modified")]
+			if tc.touched {
+				require.True(t, int64(modified.(Time).secs) >= before.Unix())
+			} else {
+				require.Equal(t, "01 Jan 2020 00:00:00", modified.Format(""))
+			}
+		})
+	}
+}
@@ -2,6 +2,8 @@ package types
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/ulmenhaus/env/img/jql/storage"
 )
@@ -150,3 +152,106 @@ func (fl ForeignList) Add(i interface{}) (Entry, error) {
 func (fl ForeignList) Encoded() storage.Primitive {
 	return fl.Keys
 }
+
+// A PolyForeignKey stores the primary key for an entry in any one of a set of
+// tables. It's formatted as the name of the table followed by a space and the key.
+type PolyForeignKey struct {
+	Table string
+	Key   string
+
+	tables  []string // the tables to which the key may refer
+	virtual bool     // whether the key may also refer to any virtual table
+}
+
+// VirtualPrefix is the prefix of tables served by a virtual gateway
+const VirtualPrefix = "vt."
+
+// IsVirtualTable returns true iff the table is served by a virtual gateway
+// rather than stored in a database
+func IsVirtualTable(name string) bool {
+	return strings.HasPrefix(name, VirtualPrefix)
+}
+
+// NewPolyForeignKey returns a new PolyForeignKey from the encoded data. The
+// tables feature is the list of tables to which the key may refer and the
+// virtual feature allows it to refer to any virtual table as well.
+func NewPolyForeignKey(i interface{}, features map[string]interface{}) (Entry, error) {
+	var tables []string
+	switch typed := features["tables"].(type) {
+	case []string:
+		tables = typed
+	case string:
+		tables = strings.Split(typed, ", ")
+	case []interface{}:
+		for _, tableI := range typed {
+			table, ok := tableI.(string)
+			if !ok {
+				return nil, fmt.Errorf("tables must be strings")
+			}
+			tables = append(tables, table)
+		}
+	default:
+		return nil, fmt.Errorf("tables not provided for polyforeign key")
+	}
+	if i == nil {
+		i = ""
+	}
+	s, ok := i.(string)
+	if !ok {
+		return nil, fmt.Errorf("failed to unpack string from: %#v", i)
+	}
+	virtual, _ := features["virtual"].(bool)
+	return PolyForeignKey{tables: tables, virtual: virtual}.Reverse("", s)
+}
+
+// Format formats the key
+func (pfk PolyForeignKey) Format(ft string) string {
+	formatted := ""
+	if pfk.Table != "" {
+		formatted = pfk.Table + " " + pfk.Key
+	}
+	if ft == ListFormat {
+		return "\n" + formatted + "\n"
+	}
+	return formatted
+}
+
+// Reverse creates a new key from input of the form "table key"
+func (pfk PolyForeignKey) Reverse(ft, input string) (Entry, error) {
+	if input == "" {
+		return PolyForeignKey{tables: pfk.tables, virtual: pfk.virtual}, nil
+	}
+	parts := strings.SplitN(input, " ", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return nil, fmt.Errorf("polyforeign keys must be of the form 'table key': %s", input)
+	}
+	if !slices.Contains(pfk.tables, parts[0]) && !(pfk.virtual && IsVirtualTable(parts[0])) {
+		return nil, fmt.Errorf("polyforeign key may not refer to table '%s'", parts[0])
+	}
+	return PolyForeignKey{
+		Table:   parts[0],
+		Key:     parts[1],
+		tables:  pfk.tables,
+		virtual: pfk.virtual,
+	}, nil
+}
+
+// Compare returns true iff the given object is a polyforeign key
+// that comes lexicographically after this one
+func (pfk PolyForeignKey) Compare(i interface{}) bool {
+	entry, ok := i.(PolyForeignKey)
+	if !ok {
+		return false
+	}
+	return entry.Format("") > pfk.Format("")
+}
+
+// Add adds to the polyforeign key
+func (pfk PolyForeignKey) Add(i interface{}) (Entry, error) {
+	return nil, fmt.Errorf("Cannot add to a polyforeign key")
+}
+
+// Encoded returns the PolyForeignKey encoded as a string
+func (pfk PolyForeignKey) Encoded() storage.Primitive {
+	return pfk.Format("")
+}
@@ -0,0 +1,65 @@
+package types
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolyForeignKey(t *testing.T) {
+	cases := []struct {
+		name     string
+		features map[string]interface{}
+		encoded  interface{}
+		expected string
+		err      bool
+	}{
+		{
+			name:     "empty",
+			features: map[string]interface{}{"tables": []string{"nouns", "tasks"}},
+			expected: "",
+		},
+		{
+			name:     "key with spaces",
+			features: map[string]interface{}{"tables": []string{"nouns", "tasks"}},
+			encoded:  "tasks Plan for Monday",
+			expected: "tasks Plan for Monday",
+		},
+		{
+			name:     "tables from schema",
+			features: map[string]interface{}{"tables": "nouns, tasks"},
+			encoded:  "nouns jql",
+			expected: "nouns jql",
+		},
+		{
+			name:     "table not allowed",
+			features: map[string]interface{}{"tables": []string{"tasks"}},
+			encoded:  "nouns jql",
+			err:      true,
+		},
+		{
+			name:     "missing key",
+			features: map[string]interface{}{"tables": []string{"tasks"}},
+			encoded:  "tasks",
+			err:      true,
+		},
+		{
+			name:    "missing tables",
+			encoded: "tasks foo",
+			err:     true,
+		},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			entry, err := NewPolyForeignKey(tc.encoded, tc.features)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, entry.Format(""))
+			require.Equal(t, tc.expected, entry.Encoded())
+		})
+	}
+}
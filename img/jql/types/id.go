@@ -1,39 +1,133 @@
 package types
 
 import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
 	"fmt"
-	"math/rand"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ulmenhaus/env/img/jql/storage"
 )
 
-// An ID is a random identifier for an entry
+const (
+	// crockford is the base32 alphabet used for ULIDs
+	crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	// maxIDAttempts is the number of times to regenerate a random ID
+	// that collides with an existing one before giving up
+	maxIDAttempts = 16
+)
+
+// An ID is a generated identifier for an entry. The strategy feature
+// determines how new IDs are generated:
+//
+//   - hex: a random hex string of the given length
+//   - uuid4: a random RFC 4122 version 4 UUID
+//   - ulid: a time-sortable ULID
+//   - sequence: one more than the last ID generated for the column, or the
+//     largest ID in the column if that's larger, zero-padded to the given
+//     length if there is one. The last ID is kept in the "last" feature so
+//     that IDs of deleted rows aren't reused.
 type ID string
 
-// NewID returns a new ID from the encoded data
-func NewID(i interface{}, features map[string]interface{}) (Entry, error) {
-	strategy, ok := features["strategy"]
+// idStrategy describes how new IDs are generated for a column
+type idStrategy struct {
+	name   string
+	length int
+	last   int
+}
+
+func parseIDStrategy(features map[string]interface{}) (idStrategy, error) {
+	strategyI, ok := features["strategy"]
 	if !ok {
-		return nil, fmt.Errorf("ID schema must have a strategy")
+		return idStrategy{}, fmt.Errorf("ID schema must have a strategy")
 	}
-	len := 0
-	switch strategy {
-	case "hex":
-		lenI, ok := features["length"]
+	strategy := idStrategy{}
+	strategy.name, ok = strategyI.(string)
+	if !ok {
+		return idStrategy{}, fmt.Errorf("strategy must be a string")
+	}
+	lenI, hasLength := features["length"]
+	if hasLength {
+		lenF, ok := lenI.(float64)
 		if !ok {
-			return nil, fmt.Errorf("hex strategy must have a length")
+			return idStrategy{}, fmt.Errorf("length must be an int")
 		}
-		lenF, ok := lenI.(float64)
+		strategy.length = int(lenF)
+	}
+	if lastI, ok := features["last"]; ok {
+		lastF, ok := lastI.(float64)
 		if !ok {
-			return nil, fmt.Errorf ("length must be an int")
+			return idStrategy{}, fmt.Errorf("last must be an int")
+		}
+		strategy.last = int(lastF)
+	}
+	switch strategy.name {
+	case "hex":
+		if !hasLength {
+			return idStrategy{}, fmt.Errorf("hex strategy must have a length")
 		}
-		len = int(lenF)
+	case "uuid4", "ulid", "sequence":
 	default:
-		return nil, fmt.Errorf("unknown strategy: %s", strategy)
+		return idStrategy{}, fmt.Errorf("unknown strategy: %s", strategy.name)
+	}
+	return strategy, nil
+}
+
+// generate returns a new ID. The existing IDs in the column are used to avoid
+// collisions and to determine the next value of a sequence.
+func (s idStrategy) generate(existing map[string]bool) (string, error) {
+	if s.name == "sequence" {
+		next := s.last + 1
+		for id := range existing {
+			n, err := strconv.Atoi(id)
+			if err == nil && n >= next {
+				next = n + 1
+			}
+		}
+		formatted := strconv.Itoa(next)
+		if len(formatted) < s.length {
+			formatted = strings.Repeat("0", s.length-len(formatted)) + formatted
+		}
+		return formatted, nil
+	}
+	for i := 0; i < maxIDAttempts; i++ {
+		var id string
+		var err error
+		switch s.name {
+		case "hex":
+			id, err = hexID(s.length)
+		case "uuid4":
+			id, err = uuid4()
+		case "ulid":
+			id, err = ulid(time.Now())
+		}
+		if err != nil {
+			return "", err
+		}
+		if !existing[id] {
+			return id, nil
+		}
+	}
+	return "", fmt.Errorf("failed to generate a unique %s id after %d attempts", s.name, maxIDAttempts)
+}
+
+// NewID returns a new ID from the encoded data. When given nil a new ID is
+// generated without checking for collisions. Tables use GenerateID instead
+// so that IDs are unique and sequences are monotonic.
+func NewID(i interface{}, features map[string]interface{}) (Entry, error) {
+	strategy, err := parseIDStrategy(features)
+	if err != nil {
+		return nil, err
 	}
 	if i == nil {
-		return ID(hexID(len)), nil
+		id, err := strategy.generate(map[string]bool{})
+		if err != nil {
+			return nil, err
+		}
+		return ID(id), nil
 	}
 	s, ok := i.(string)
 	if !ok {
@@ -42,19 +136,91 @@ func NewID(i interface{}, features map[string]interface{}) (Entry, error) {
 	return ID(s), nil
 }
 
-func hexID(l int) string {
-	rand.Seed(time.Now().UTC().UnixNano())
-	s := ""
-	for i := 0; i < l; i++ {
-		// TODO(rabrams) inefficient -- could get 8 values out of this
-		encoded := rand.Int() & 15
-		if encoded >= 10 {
-			s += string('a' + (encoded - 10))
+// GenerateID returns a new ID for the given column using the column's strategy
+// that does not collide with any existing value in the column. Sequences record
+// the ID in the column's features which should be stored with the schema.
+func (t *Table) GenerateID(column string) (string, error) {
+	col, ok := t.columnsByName[column]
+	if !ok {
+		return "", fmt.Errorf("Unknown column: %s", column)
+	}
+	strategy, err := parseIDStrategy(t.featuresByColumn[column])
+	if err != nil {
+		return "", fmt.Errorf("%s is not an ID column: %s", column, err)
+	}
+	existing := map[string]bool{}
+	for pk, row := range t.Entries {
+		if col == t.primary {
+			existing[pk] = true
 		} else {
-			s += string('0' + encoded)
+			existing[row[col].Format("")] = true
 		}
 	}
-	return s
+	id, err := strategy.generate(existing)
+	if err != nil || strategy.name != "sequence" {
+		return id, err
+	}
+	last, err := strconv.Atoi(id)
+	if err != nil {
+		return "", err
+	}
+	features := map[string]interface{}{}
+	for k, v := range t.featuresByColumn[column] {
+		features[k] = v
+	}
+	// features are stored as JSON so numbers are floats
+	features["last"] = float64(last)
+	t.featuresByColumn[column] = features
+	return id, nil
+}
+
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func hexID(l int) (string, error) {
+	b, err := randomBytes((l + 1) / 2)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b)[:l], nil
+}
+
+func uuid4() (string, error) {
+	b, err := randomBytes(16)
+	if err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 variant
+	h := hex.EncodeToString(b)
+	return fmt.Sprintf("%s-%s-%s-%s-%s", h[0:8], h[8:12], h[12:16], h[16:20], h[20:32]), nil
+}
+
+// ulid returns a ULID for the given time: 48 bits of milliseconds since the
+// epoch followed by 80 random bits, encoded as 26 characters of base32
+func ulid(t time.Time) (string, error) {
+	random, err := randomBytes(10)
+	if err != nil {
+		return "", err
+	}
+	b := make([]byte, 16)
+	binary.BigEndian.PutUint64(b[0:8], uint64(t.UnixMilli())<<16)
+	copy(b[6:], random)
+	hi := binary.BigEndian.Uint64(b[0:8])
+	lo := binary.BigEndian.Uint64(b[8:16])
+	encoded := make([]byte, 26)
+	// 128 bits are encoded as 130 bits so the first character holds 3 bits
+	for i := 25; i >= 0; i-- {
+		encoded[i] = crockford[lo&31]
+		lo = (lo >> 5) | (hi << 59)
+		hi >>= 5
+	}
+	return string(encoded), nil
 }
 
 // Format formats the ID
@@ -0,0 +1,113 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ulmenhaus/env/proto/jql/jqlpb"
+)
+
+func newIDTable(features map[string]interface{}, pks ...string) *Table {
+	entries := map[string][]Entry{}
+	for _, pk := range pks {
+		entries[pk] = []Entry{ID(pk)}
+	}
+	return NewTable(
+		[]string{"ID"},
+		entries,
+		"ID",
+		map[string]FieldValueConstructor{"ID": NewID},
+		map[string]map[string]interface{}{"ID": features},
+		map[string]*ColumnMeta{"ID": {Type: jqlpb.EntryType_ID}},
+	)
+}
+
+func TestGenerateID(t *testing.T) {
+	cases := []struct {
+		name     string
+		features map[string]interface{}
+		existing []string
+		pattern  string
+		expected string
+		err      bool
+	}{
+		{
+			name:     "hex",
+			features: map[string]interface{}{"strategy": "hex", "length": float64(7)},
+			pattern:  `^[0-9a-f]{7}$`,
+		},
+		{
+			name:     "uuid4",
+			features: map[string]interface{}{"strategy": "uuid4"},
+			pattern:  `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`,
+		},
+		{
+			name:     "ulid",
+			features: map[string]interface{}{"strategy": "ulid"},
+			pattern:  `^[0-7][0-9A-HJKMNP-TV-Z]{25}$`,
+		},
+		{
+			name:     "first in sequence",
+			features: map[string]interface{}{"strategy": "sequence", "length": float64(4)},
+			expected: "0001",
+		},
+		{
+			name:     "next in sequence",
+			features: map[string]interface{}{"strategy": "sequence"},
+			existing: []string{"7", "12", "legacy"},
+			expected: "13",
+		},
+		{
+			name:     "hex space exhausted",
+			features: map[string]interface{}{"strategy": "hex", "length": float64(1)},
+			existing: []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9", "a", "b", "c", "d", "e", "f"},
+			err:      true,
+		},
+		{
+			name:     "unknown strategy",
+			features: map[string]interface{}{"strategy": "snowflake"},
+			err:      true,
+		},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			table := newIDTable(tc.features, tc.existing...)
+			id, err := table.GenerateID("ID")
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if tc.pattern != "" {
+				require.Regexp(t, regexp.MustCompile(tc.pattern), id)
+			} else {
+				require.Equal(t, tc.expected, id)
+			}
+		})
+	}
+}
+
+func TestSequenceDoesNotReuseDeletedIDs(t *testing.T) {
+	table := newIDTable(map[string]interface{}{"strategy": "sequence"}, "1", "2")
+	id, err := table.GenerateID("ID")
+	require.NoError(t, err)
+	require.Equal(t, "3", id)
+	require.NoError(t, table.Insert(id))
+	require.NoError(t, table.Delete(id))
+	id, err = table.GenerateID("ID")
+	require.NoError(t, err)
+	require.Equal(t, "4", id)
+	require.Equal(t, float64(4), table.Features("ID")["last"])
+}
+
+func TestULIDSortsByTime(t *testing.T) {
+	now := time.Now()
+	earlier, err := ulid(now)
+	require.NoError(t, err)
+	later, err := ulid(now.Add(time.Millisecond))
+	require.NoError(t, err)
+	require.True(t, earlier < later)
+}
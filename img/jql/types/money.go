@@ -2,64 +2,201 @@ package types
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/ulmenhaus/env/img/jql/storage"
 )
 
-// A MoneyAmount denotes an amount of money
-type MoneyAmount int
+// A Currency determines how a MoneyAmount is presented and parsed
+type Currency struct {
+	// Symbol is the prefix shown before the amount, e.g. $
+	Symbol string
+	// Digits is the number of minor-unit digits, e.g. 2 for cents
+	Digits int
+}
+
+var (
+	// DefaultCurrency is used for money columns with no currency feature
+	DefaultCurrency = Currency{Symbol: "$", Digits: 2}
+
+	currenciesByCode = map[string]Currency{
+		"USD": {Symbol: "$", Digits: 2},
+		"CAD": {Symbol: "$", Digits: 2},
+		"AUD": {Symbol: "$", Digits: 2},
+		"EUR": {Symbol: "€", Digits: 2},
+		"GBP": {Symbol: "£", Digits: 2},
+		"CHF": {Symbol: "CHF ", Digits: 2},
+		"INR": {Symbol: "₹", Digits: 2},
+		"JPY": {Symbol: "¥", Digits: 0},
+		"KRW": {Symbol: "₩", Digits: 0},
+		"BTC": {Symbol: "₿", Digits: 8},
+	}
+)
+
+// A MoneyAmount denotes an amount of money as an integral number of the
+// currency's minor units (e.g. cents)
+type MoneyAmount struct {
+	amount   int
+	currency Currency
+}
 
-// NewMoneyAmount returns a new MoneyAmount from the encodema data
+// NewMoneyAmount returns a new MoneyAmount from the encoded data. The currency
+// feature may either be an ISO code like "EUR" or an object with a symbol and
+// the number of minor-unit digits.
 func NewMoneyAmount(i interface{}, features map[string]interface{}) (Entry, error) {
+	currency, err := currencyFromFeatures(features)
+	if err != nil {
+		return nil, err
+	}
 	if i == nil {
-		return MoneyAmount(0), nil
+		return MoneyAmount{currency: currency}, nil
 	}
-	// TODO would be good to support currency as a feature
 	n, ok := i.(float64)
 	if !ok {
 		return nil, fmt.Errorf("failed to unpack int from: %#v", i)
 	}
-	return MoneyAmount(n), nil
+	return MoneyAmount{amount: int(n), currency: currency}, nil
+}
+
+func currencyFromFeatures(features map[string]interface{}) (Currency, error) {
+	currencyI, ok := features["currency"]
+	if !ok {
+		return DefaultCurrency, nil
+	}
+	switch typed := currencyI.(type) {
+	case string:
+		currency, ok := currenciesByCode[strings.ToUpper(typed)]
+		if !ok {
+			return Currency{}, fmt.Errorf("unknown currency: %s", typed)
+		}
+		return currency, nil
+	case map[string]interface{}:
+		currency := DefaultCurrency
+		if symbolI, ok := typed["symbol"]; ok {
+			symbol, ok := symbolI.(string)
+			if !ok {
+				return Currency{}, fmt.Errorf("currency symbol must be a string")
+			}
+			currency.Symbol = symbol
+		}
+		if digitsI, ok := typed["digits"]; ok {
+			digits, ok := digitsI.(float64)
+			if !ok || digits < 0 {
+				return Currency{}, fmt.Errorf("currency digits must be a non-negative int")
+			}
+			currency.Digits = int(digits)
+		}
+		return currency, nil
+	}
+	return Currency{}, fmt.Errorf("invalid type for currency: %T", currencyI)
 }
 
 // Format formats the MoneyAmount
 func (ma MoneyAmount) Format(ft string) string {
 	prefix := ""
-	amt := ma
-	if ma < 0 {
-		amt = -ma
+	amt := ma.amount
+	if amt < 0 {
+		amt = -amt
 		prefix = "-"
 	}
-	dollars := amt / 100
-	cents := amt - (dollars * 100)
-	return fmt.Sprintf("%s$%d.%02d", prefix, dollars, cents)
+	if ma.currency.Digits == 0 {
+		return fmt.Sprintf("%s%s%d", prefix, ma.currency.Symbol, amt)
+	}
+	scale := pow10(ma.currency.Digits)
+	major := amt / scale
+	minor := amt - (major * scale)
+	return fmt.Sprintf("%s%s%d.%0*d", prefix, ma.currency.Symbol, major, ma.currency.Digits, minor)
 }
 
-// Reverse creates a new MoneyAmount from the input
+// Reverse creates a new MoneyAmount from the input. Inputs may have a sign,
+// the currency symbol, thousands separators, and up to the currency's number
+// of minor-unit digits, e.g. -$1,234.50
 func (ma MoneyAmount) Reverse(ft, input string) (Entry, error) {
-	return nil, fmt.Errorf("not implemented")
+	s := strings.TrimSpace(input)
+	negative := false
+	if strings.HasPrefix(s, "-") {
+		negative = true
+		s = s[1:]
+	}
+	symbol := strings.TrimSpace(ma.currency.Symbol)
+	if symbol != "" {
+		s = strings.TrimSpace(strings.TrimPrefix(s, symbol))
+	}
+	if !negative && strings.HasPrefix(s, "-") {
+		// also accept the sign after the symbol, e.g. $-12.34
+		negative = true
+		s = s[1:]
+	}
+	s = strings.ReplaceAll(s, ",", "")
+	parts := strings.SplitN(s, ".", 2)
+	if parts[0] == "" && (len(parts) == 1 || parts[1] == "") {
+		return nil, fmt.Errorf("invalid amount: %q", input)
+	}
+	major := 0
+	if parts[0] != "" {
+		var err error
+		major, err = parseDigits(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid amount: %q", input)
+		}
+	}
+	minor := 0
+	if len(parts) == 2 && parts[1] != "" {
+		if len(parts[1]) > ma.currency.Digits {
+			return nil, fmt.Errorf("%q has more than %d decimal places", input, ma.currency.Digits)
+		}
+		var err error
+		minor, err = parseDigits(parts[1] + strings.Repeat("0", ma.currency.Digits-len(parts[1])))
+		if err != nil {
+			return nil, fmt.Errorf("invalid amount: %q", input)
+		}
+	}
+	amount := major*pow10(ma.currency.Digits) + minor
+	if negative {
+		amount = -amount
+	}
+	return MoneyAmount{amount: amount, currency: ma.currency}, nil
+}
+
+// parseDigits parses a string made up solely of decimal digits
+func parseDigits(s string) (int, error) {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return 0, fmt.Errorf("not a digit: %c", c)
+		}
+	}
+	return strconv.Atoi(s)
+}
+
+func pow10(n int) int {
+	p := 1
+	for i := 0; i < n; i++ {
+		p *= 10
+	}
+	return p
 }
 
-// Compare returns true iff the given object is a MoneyAmount anma comes
+// Compare returns true iff the given object is a MoneyAmount and comes
 // after this MoneyAmount
 func (ma MoneyAmount) Compare(i interface{}) bool {
 	entry, ok := i.(MoneyAmount)
 	if !ok {
 		return false
 	}
-	return entry > ma
+	return entry.amount > ma.amount
 }
 
-// Add increments the MoneyAmount by the providema number of days
+// Add increments the MoneyAmount by the provided number of minor units
 func (ma MoneyAmount) Add(i interface{}) (Entry, error) {
-	cents, ok := i.(int)
+	units, ok := i.(int)
 	if !ok {
-		return nil, fmt.Errorf("MoneyAmounts can only be incrementema by integers")
+		return nil, fmt.Errorf("MoneyAmounts can only be incremented by integers")
 	}
-	return MoneyAmount(int(ma) + cents), nil
+	return MoneyAmount{amount: ma.amount + units, currency: ma.currency}, nil
 }
 
-// Encoded returns the MoneyAmount encodema as a string
+// Encoded returns the MoneyAmount encoded as an int of minor units
 func (ma MoneyAmount) Encoded() storage.Primitive {
-	return int(ma)
+	return ma.amount
 }
@@ -0,0 +1,102 @@
+package types
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoneyAmountReverse(t *testing.T) {
+	cases := []struct {
+		name     string
+		features map[string]interface{}
+		input    string
+		encoded  int
+		expected string
+		err      bool
+	}{
+		{
+			name:     "default currency",
+			input:    "$12.34",
+			encoded:  1234,
+			expected: "$12.34",
+		},
+		{
+			name:     "no symbol or fraction",
+			input:    "12",
+			encoded:  1200,
+			expected: "$12.00",
+		},
+		{
+			name:     "thousands separators and padded fraction",
+			input:    "1,234.5",
+			encoded:  123450,
+			expected: "$1234.50",
+		},
+		{
+			name:     "negative",
+			input:    "-$3.07",
+			encoded:  -307,
+			expected: "-$3.07",
+		},
+		{
+			name:     "currency code",
+			features: map[string]interface{}{"currency": "EUR"},
+			input:    "€1,000",
+			encoded:  100000,
+			expected: "€1000.00",
+		},
+		{
+			name:     "currency without minor units",
+			features: map[string]interface{}{"currency": "JPY"},
+			input:    "¥500",
+			encoded:  500,
+			expected: "¥500",
+		},
+		{
+			name: "custom currency",
+			features: map[string]interface{}{"currency": map[string]interface{}{
+				"symbol": "BTC ",
+				"digits": float64(3),
+			}},
+			input:    "BTC 0.25",
+			encoded:  250,
+			expected: "BTC 0.250",
+		},
+		{
+			name:  "too many decimal places",
+			input: "$1.234",
+			err:   true,
+		},
+		{
+			name:  "mismatched symbol",
+			input: "€3",
+			err:   true,
+		},
+		{
+			name:  "empty",
+			input: "$",
+			err:   true,
+		},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			zero, err := NewMoneyAmount(nil, tc.features)
+			require.NoError(t, err)
+			actual, err := zero.Reverse("", tc.input)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.encoded, actual.Encoded())
+			require.Equal(t, tc.expected, actual.Format(""))
+		})
+	}
+}
+
+func TestUnknownCurrency(t *testing.T) {
+	_, err := NewMoneyAmount(nil, map[string]interface{}{"currency": "XYZ"})
+	require.Error(t, err)
+}
@@ -0,0 +1,136 @@
+package types
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	offsetPattern = regexp.MustCompile(`^([+-]?)([0-9]+)([dwmy])$`)
+	isoLayouts    = []string{"2006-01-02", "2006-01-02 15:04", "2006-01-02T15:04:05"}
+	weekdays      = map[string]time.Weekday{
+		"sun": time.Sunday,
+		"mon": time.Monday,
+		"tue": time.Tuesday,
+		"wed": time.Wednesday,
+		"thu": time.Thursday,
+		"fri": time.Friday,
+		"sat": time.Saturday,
+	}
+)
+
+// parseTimeInput parses user input for a date or time column. An empty input
+// means now, an expression understood by parseRelative is evaluated relative to
+// now, and anything else must match the provided layout.
+func parseTimeInput(layout, input string, now time.Time) (time.Time, error) {
+	if input == "" {
+		return now, nil
+	}
+	if t, ok := parseRelative(input, now); ok {
+		return t, nil
+	}
+	noLoc, err := time.Parse(layout, input)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(noLoc.Year(), noLoc.Month(), noLoc.Day(), noLoc.Hour(), noLoc.Minute(),
+		noLoc.Second(), noLoc.Nanosecond(), now.Location()), nil
+}
+
+// parseRelative interprets a date expression relative to now. It returns false if
+// the input is not a recognized expression. Supported expressions are
+//
+//   - now, today, tomorrow, yesterday
+//   - offsets such as +3d, -1w, +2m, or 1y
+//   - weekdays such as mon, next fri, or last tuesday
+//   - som, eom, sow, eow, soy, eoy for the start and end of the month, week, and year
+//   - ISO dates such as 2026-10-17 or 2026-10-17 09:30
+//
+// Offsets and the named days keep the time of day of now while every other
+// expression resolves to the start of the day.
+func parseRelative(input string, now time.Time) (time.Time, bool) {
+	expr := strings.ToLower(strings.TrimSpace(input))
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch expr {
+	case "now", "today":
+		return now, true
+	case "tomorrow":
+		return now.AddDate(0, 0, 1), true
+	case "yesterday":
+		return now.AddDate(0, 0, -1), true
+	case "som", "bom":
+		return midnight.AddDate(0, 0, 1-now.Day()), true
+	case "eom":
+		return midnight.AddDate(0, 1, -now.Day()), true
+	case "sow", "bow":
+		// weeks start on Monday
+		return midnight.AddDate(0, 0, -((int(now.Weekday()) + 6) % 7)), true
+	case "eow":
+		return midnight.AddDate(0, 0, 6-((int(now.Weekday())+6)%7)), true
+	case "soy", "boy":
+		return time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, now.Location()), true
+	case "eoy":
+		return time.Date(now.Year(), time.December, 31, 0, 0, 0, 0, now.Location()), true
+	}
+	if match := offsetPattern.FindStringSubmatch(expr); match != nil {
+		n, err := strconv.Atoi(match[2])
+		if err != nil {
+			return time.Time{}, false
+		}
+		if match[1] == "-" {
+			n = -n
+		}
+		switch match[3] {
+		case "d":
+			return now.AddDate(0, 0, n), true
+		case "w":
+			return now.AddDate(0, 0, 7*n), true
+		case "m":
+			return now.AddDate(0, n, 0), true
+		case "y":
+			return now.AddDate(n, 0, 0), true
+		}
+	}
+	if t, ok := parseWeekday(expr, midnight); ok {
+		return t, true
+	}
+	for _, layout := range isoLayouts {
+		t, err := time.ParseInLocation(layout, expr, now.Location())
+		if err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// parseWeekday resolves expressions like "fri", "next fri", and "last fri". A bare
+// weekday is the next occurrence including today.
+func parseWeekday(expr string, midnight time.Time) (time.Time, bool) {
+	direction := 0
+	if strings.HasPrefix(expr, "next ") {
+		direction = 1
+		expr = strings.TrimPrefix(expr, "next ")
+	} else if strings.HasPrefix(expr, "last ") {
+		direction = -1
+		expr = strings.TrimPrefix(expr, "last ")
+	}
+	if len(expr) < 3 {
+		return time.Time{}, false
+	}
+	day, ok := weekdays[expr[:3]]
+	if !ok || !strings.HasPrefix(strings.ToLower(day.String()), expr) {
+		return time.Time{}, false
+	}
+	delta := (int(day) - int(midnight.Weekday()) + 7) % 7
+	switch direction {
+	case 1:
+		if delta == 0 {
+			delta = 7
+		}
+	case -1:
+		delta -= 7
+	}
+	return midnight.AddDate(0, 0, delta), true
+}
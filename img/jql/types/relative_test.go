@@ -0,0 +1,128 @@
+package types
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRelative(t *testing.T) {
+	// a Friday
+	now := time.Date(2026, 10, 16, 13, 30, 0, 0, time.UTC)
+	cases := []struct {
+		name     string
+		input    string
+		expected time.Time
+		ok       bool
+	}{
+		{
+			name:     "today",
+			input:    "today",
+			expected: now,
+			ok:       true,
+		},
+		{
+			name:     "tomorrow",
+			input:    "Tomorrow",
+			expected: time.Date(2026, 10, 17, 13, 30, 0, 0, time.UTC),
+			ok:       true,
+		},
+		{
+			name:     "day offset",
+			input:    "+3d",
+			expected: time.Date(2026, 10, 19, 13, 30, 0, 0, time.UTC),
+			ok:       true,
+		},
+		{
+			name:     "negative week offset",
+			input:    "-1w",
+			expected: time.Date(2026, 10, 9, 13, 30, 0, 0, time.UTC),
+			ok:       true,
+		},
+		{
+			name:     "next weekday",
+			input:    "next mon",
+			expected: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+			ok:       true,
+		},
+		{
+			name:     "next same weekday",
+			input:    "next friday",
+			expected: time.Date(2026, 10, 23, 0, 0, 0, 0, time.UTC),
+			ok:       true,
+		},
+		{
+			name:     "bare same weekday",
+			input:    "fri",
+			expected: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC),
+			ok:       true,
+		},
+		{
+			name:     "last weekday",
+			input:    "last wed",
+			expected: time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC),
+			ok:       true,
+		},
+		{
+			name:     "end of month",
+			input:    "eom",
+			expected: time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC),
+			ok:       true,
+		},
+		{
+			name:     "end of week",
+			input:    "eow",
+			expected: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+			ok:       true,
+		},
+		{
+			name:     "iso date",
+			input:    "2026-10-17",
+			expected: time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC),
+			ok:       true,
+		},
+		{
+			name:  "fixed layout is not relative",
+			input: "17 Oct 2026",
+		},
+		{
+			name:  "misspelled weekday",
+			input: "next frday",
+		},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			actual, ok := parseRelative(tc.input, now)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestDateReverse(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "fixed layout",
+			input:    "17 Oct 2026",
+			expected: "17 Oct 2026",
+		},
+		{
+			name:     "iso date",
+			input:    "2026-10-17",
+			expected: "17 Oct 2026",
+		},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			actual, err := Date{}.Reverse("", tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, actual.Format(""))
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package types
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ulmenhaus/env/img/jql/storage"
+)
+
+// A Bool is a value that is either true or false
+type Bool bool
+
+// NewBool returns a new Bool from the encoded data
+func NewBool(i interface{}, features map[string]interface{}) (Entry, error) {
+	if i == nil {
+		return Bool(false), nil
+	}
+	b, ok := i.(bool)
+	if !ok {
+		return nil, fmt.Errorf("failed to unpack bool from: %#v", i)
+	}
+	return Bool(b), nil
+}
+
+// Format formats the Bool as yes or no
+func (b Bool) Format(ft string) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+// Reverse creates a new Bool from the input
+func (b Bool) Reverse(ft, input string) (Entry, error) {
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "yes", "y", "true", "t", "1":
+		return Bool(true), nil
+	case "no", "n", "false", "f", "0", "":
+		return Bool(false), nil
+	}
+	return nil, fmt.Errorf("invalid bool: %s", input)
+}
+
+// Compare returns true iff the given object is a Bool and this Bool
+// is false while the other is true
+func (b Bool) Compare(i interface{}) bool {
+	entry, ok := i.(Bool)
+	if !ok {
+		return false
+	}
+	return bool(entry) && !bool(b)
+}
+
+// Add toggles the Bool once for each increment
+func (b Bool) Add(i interface{}) (Entry, error) {
+	n, ok := i.(int)
+	if !ok {
+		return nil, fmt.Errorf("Bools can only be incremented by integers")
+	}
+	if n%2 != 0 {
+		return !b, nil
+	}
+	return b, nil
+}
+
+// Encoded returns the Bool encoded as a bool
+func (b Bool) Encoded() storage.Primitive {
+	return bool(b)
+}
+
+// A Float is a numerical value with a fractional part
+type Float float64
+
+// NewFloat returns a new Float from the encoded data
+func NewFloat(i interface{}, features map[string]interface{}) (Entry, error) {
+	if i == nil {
+		return Float(0), nil
+	}
+	f, ok := i.(float64)
+	if !ok {
+		return nil, fmt.Errorf("failed to unpack float from: %#v", i)
+	}
+	return Float(f), nil
+}
+
+// Format formats the Float with as many digits as are necessary
+func (f Float) Format(ft string) string {
+	return strconv.FormatFloat(float64(f), 'f', -1, 64)
+}
+
+// Reverse creates a new Float from the input
+func (f Float) Reverse(ft, input string) (Entry, error) {
+	value, err := strconv.ParseFloat(strings.TrimSpace(input), 64)
+	if err != nil {
+		return nil, err
+	}
+	return Float(value), nil
+}
+
+// Compare returns true iff the given object is a Float and is
+// greater than this Float
+func (f Float) Compare(i interface{}) bool {
+	entry, ok := i.(Float)
+	if !ok {
+		return false
+	}
+	return entry > f
+}
+
+// Add adds to the Float
+func (f Float) Add(i interface{}) (Entry, error) {
+	switch typed := i.(type) {
+	case int:
+		return Float(float64(f) + float64(typed)), nil
+	case float64:
+		return Float(float64(f) + typed), nil
+	}
+	return nil, fmt.Errorf("Unsupported addition - float + %T", i)
+}
+
+// Encoded returns the Float encoded as a float
+func (f Float) Encoded() storage.Primitive {
+	return float64(f)
+}
+
+// A Duration is a length of time modeled as a number of seconds
+type Duration int
+
+// NewDuration returns a new Duration from the encoded data
+func NewDuration(i interface{}, features map[string]interface{}) (Entry, error) {
+	if i == nil {
+		return Duration(0), nil
+	}
+	n, ok := i.(float64)
+	if !ok {
+		return nil, fmt.Errorf("failed to unpack int from: %#v", i)
+	}
+	return Duration(n), nil
+}
+
+// Format formats the Duration omitting units that are zero, e.g. 1h30m
+func (d Duration) Format(ft string) string {
+	if d == 0 {
+		return "0s"
+	}
+	prefix := ""
+	secs := int(d)
+	if secs < 0 {
+		prefix = "-"
+		secs = -secs
+	}
+	formatted := ""
+	if hours := secs / 3600; hours > 0 {
+		formatted += fmt.Sprintf("%dh", hours)
+	}
+	if mins := (secs % 3600) / 60; mins > 0 {
+		formatted += fmt.Sprintf("%dm", mins)
+	}
+	if rem := secs % 60; rem > 0 {
+		formatted += fmt.Sprintf("%ds", rem)
+	}
+	return prefix + formatted
+}
+
+// Reverse creates a new Duration from the input, e.g. 1h30m or 45s
+func (d Duration) Reverse(ft, input string) (Entry, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return Duration(0), nil
+	}
+	parsed, err := time.ParseDuration(input)
+	if err != nil {
+		return nil, err
+	}
+	return Duration(parsed / time.Second), nil
+}
+
+// Compare returns true iff the given object is a Duration and is
+// longer than this Duration
+func (d Duration) Compare(i interface{}) bool {
+	entry, ok := i.(Duration)
+	if !ok {
+		return false
+	}
+	return entry > d
+}
+
+// Add increments the Duration by the provided number of minutes
+func (d Duration) Add(i interface{}) (Entry, error) {
+	mins, ok := i.(int)
+	if !ok {
+		return nil, fmt.Errorf("Durations can only be incremented by integers")
+	}
+	return Duration(int(d) + mins*60), nil
+}
+
+// Encoded returns the Duration encoded as an int of seconds
+func (d Duration) Encoded() storage.Primitive {
+	return int(d)
+}
+
+// A URL is a string that references a web resource
+type URL string
+
+// NewURL returns a new URL from the encoded data
+func NewURL(i interface{}, features map[string]interface{}) (Entry, error) {
+	if i == nil {
+		return URL(""), nil
+	}
+	s, ok := i.(string)
+	if !ok {
+		return nil, fmt.Errorf("failed to unpack string from: %#v", i)
+	}
+	return URL(s), nil
+}
+
+// Format formats the URL
+func (u URL) Format(ft string) string {
+	return string(u)
+}
+
+// Reverse creates a new URL from the input which must be absolute
+func (u URL) Reverse(ft, input string) (Entry, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return URL(""), nil
+	}
+	parsed, err := url.Parse(input)
+	if err != nil {
+		return nil, err
+	}
+	if parsed.Scheme == "" || (parsed.Host == "" && parsed.Opaque == "") {
+		return nil, fmt.Errorf("not an absolute url: %s", input)
+	}
+	return URL(input), nil
+}
+
+// Compare returns true iff the given object is a URL and comes
+// lexicographically after this URL
+func (u URL) Compare(i interface{}) bool {
+	entry, ok := i.(URL)
+	if !ok {
+		return false
+	}
+	return entry > u
+}
+
+// Add adds to the URL
+func (u URL) Add(i interface{}) (Entry, error) {
+	return nil, fmt.Errorf("Cannot add to a url")
+}
+
+// Encoded returns the URL encoded as a string
+func (u URL) Encoded() storage.Primitive {
+	return string(u)
+}
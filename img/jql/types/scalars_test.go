@@ -0,0 +1,152 @@
+package types
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScalarReverse(t *testing.T) {
+	cases := []struct {
+		name     string
+		zero     Entry
+		input    string
+		encoded  interface{}
+		expected string
+		err      bool
+	}{
+		{
+			name:     "bool yes",
+			zero:     Bool(false),
+			input:    "yes",
+			encoded:  true,
+			expected: "yes",
+		},
+		{
+			name:     "bool false",
+			zero:     Bool(true),
+			input:    "False",
+			encoded:  false,
+			expected: "no",
+		},
+		{
+			name:  "invalid bool",
+			zero:  Bool(false),
+			input: "maybe",
+			err:   true,
+		},
+		{
+			name:     "float",
+			zero:     Float(0),
+			input:    "3.25",
+			encoded:  3.25,
+			expected: "3.25",
+		},
+		{
+			name:     "duration",
+			zero:     Duration(0),
+			input:    "90m",
+			encoded:  5400,
+			expected: "1h30m",
+		},
+		{
+			name:     "duration with seconds",
+			zero:     Duration(0),
+			input:    "2h0m5s",
+			encoded:  7205,
+			expected: "2h5s",
+		},
+		{
+			name:  "invalid duration",
+			zero:  Duration(0),
+			input: "an hour",
+			err:   true,
+		},
+		{
+			name:     "url",
+			zero:     URL(""),
+			input:    "https://example.com/a?b=c",
+			encoded:  "https://example.com/a?b=c",
+			expected: "https://example.com/a?b=c",
+		},
+		{
+			name:  "relative url",
+			zero:  URL(""),
+			input: "example.com",
+			err:   true,
+		},
+		{
+			name:     "tags",
+			zero:     Tags{},
+			input:    "work, home,work,, errands",
+			encoded:  []string{"errands", "home", "work"},
+			expected: "errands, home, work",
+		},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			actual, err := tc.zero.Reverse("", tc.input)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.encoded, actual.Encoded())
+			require.Equal(t, tc.expected, actual.Format(""))
+		})
+	}
+}
+
+func TestScalarAdd(t *testing.T) {
+	cases := []struct {
+		name     string
+		entry    Entry
+		addend   interface{}
+		expected string
+		err      bool
+	}{
+		{
+			name:     "toggle bool",
+			entry:    Bool(false),
+			addend:   1,
+			expected: "yes",
+		},
+		{
+			name:     "float",
+			entry:    Float(1.5),
+			addend:   -2,
+			expected: "-0.5",
+		},
+		{
+			name:     "duration by minutes",
+			entry:    Duration(3600),
+			addend:   15,
+			expected: "1h15m",
+		},
+		{
+			name:     "tag",
+			entry:    Tags{"work"},
+			addend:   "home",
+			expected: "home, work",
+		},
+		{
+			name:   "increment tags",
+			entry:  Tags{"work"},
+			addend: 1,
+			err:    true,
+		},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			actual, err := tc.entry.Add(tc.addend)
+			if tc.err {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "cannot be incremented")
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, actual.Format(""))
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ulmenhaus/env/img/jql/storage"
+)
+
+// Tags are an unordered set of strings. Membership can be filtered on by
+// formatting the Tags with the ListFormat.
+type Tags []string
+
+// NewTags returns a new set of Tags from the encoded data
+func NewTags(i interface{}, features map[string]interface{}) (Entry, error) {
+	if i == nil {
+		return Tags{}, nil
+	}
+	tagsI, ok := i.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("failed to unpack slice from: %#v", i)
+	}
+	tags := []string{}
+	for _, tagI := range tagsI {
+		tag, ok := tagI.(string)
+		if !ok {
+			return nil, fmt.Errorf("failed to unpack string from: %#v", tagI)
+		}
+		tags = append(tags, tag)
+	}
+	return newTags(tags), nil
+}
+
+// newTags returns the Tags with empty and duplicate values removed in a
+// canonical order
+func newTags(tags []string) Tags {
+	seen := map[string]bool{}
+	canonical := Tags{}
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		canonical = append(canonical, tag)
+	}
+	sort.Strings(canonical)
+	return canonical
+}
+
+// Format formats the Tags as a comma separated list
+func (t Tags) Format(ft string) string {
+	if ft == ListFormat {
+		fullList := "\n"
+		for _, tag := range t {
+			fullList = fullList + tag + "\n"
+		}
+		return fullList
+	}
+	return strings.Join(t, ", ")
+}
+
+// Reverse creates new Tags from a comma separated list
+func (t Tags) Reverse(ft, input string) (Entry, error) {
+	return newTags(strings.Split(input, ",")), nil
+}
+
+// Compare returns true iff the given object is a set of Tags that comes
+// lexicographically after these Tags
+func (t Tags) Compare(i interface{}) bool {
+	entry, ok := i.(Tags)
+	if !ok {
+		return false
+	}
+	return entry.Format("") > t.Format("")
+}
+
+// Add adds the provided tag to the Tags. Tags have no order so they can't
+// be incremented.
+func (t Tags) Add(i interface{}) (Entry, error) {
+	switch typed := i.(type) {
+	case string:
+		return newTags(append(append([]string{}, t...), typed)), nil
+	case int:
+		return nil, fmt.Errorf("Tags cannot be incremented")
+	}
+	return nil, fmt.Errorf("Tags can only be added with strings")
+}
+
+// Encoded returns the Tags encoded as a list of strings
+func (t Tags) Encoded() storage.Primitive {
+	return []string(t)
+}
@@ -2,67 +2,124 @@ package types
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/ulmenhaus/env/img/jql/storage"
 )
 
 // A Date denotes a specifc day in history by modeling as the
-// number of days (positive or negative) since 1 January 1970 UTC
-type Date int
+// number of days (positive or negative) since 1 January 1970.
+// The location of a Date only determines which day is today.
+type Date struct {
+	days int
+	loc  *time.Location
+}
 
 var (
 	epoch = time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	defaultLocationMu sync.RWMutex
+	defaultLocation   = time.UTC
 )
 
+// SetDefaultLocation sets the time zone in which dates and times are
+// displayed and parsed for columns with no timezone feature
+func SetDefaultLocation(loc *time.Location) {
+	defaultLocationMu.Lock()
+	defer defaultLocationMu.Unlock()
+	defaultLocation = loc
+}
+
+// DefaultLocation returns the time zone in which dates and times are
+// displayed and parsed for columns with no timezone feature
+func DefaultLocation() *time.Location {
+	defaultLocationMu.RLock()
+	defer defaultLocationMu.RUnlock()
+	return defaultLocation
+}
+
+// locationFromFeatures returns the location given by the timezone feature or nil
+// if the column should use the default location
+func locationFromFeatures(features map[string]interface{}) (*time.Location, error) {
+	tzI, ok := features["timezone"]
+	if !ok {
+		return nil, nil
+	}
+	tz, ok := tzI.(string)
+	if !ok {
+		return nil, fmt.Errorf("timezone must be a string")
+	}
+	return time.LoadLocation(tz)
+}
+
+// ColumnLocation returns the time zone in which a date or time column with
+// the given features is displayed and parsed
+func ColumnLocation(features map[string]interface{}) (*time.Location, error) {
+	loc, err := locationFromFeatures(features)
+	if err != nil {
+		return nil, err
+	}
+	return location(loc), nil
+}
+
+// location returns the provided location or the default location if it is nil
+func location(loc *time.Location) *time.Location {
+	if loc == nil {
+		return DefaultLocation()
+	}
+	return loc
+}
+
 // NewDate returns a new date from the encoded data
 func NewDate(i interface{}, features map[string]interface{}) (Entry, error) {
+	loc, err := locationFromFeatures(features)
+	if err != nil {
+		return nil, err
+	}
 	if i == nil {
-		return Date(0).Reverse("", "")
+		return Date{loc: loc}.Reverse("", "")
 	}
 	n, ok := i.(float64)
 	if !ok {
 		return nil, fmt.Errorf("failed to unpack int from: %#v", i)
 	}
-	return Date(n), nil
+	return Date{days: int(n), loc: loc}, nil
 }
 
 // Format formats the date
 func (d Date) Format(ft string) string {
-	t := epoch.Add(time.Hour * 24 * time.Duration(d))
+	t := epoch.Add(time.Hour * 24 * time.Duration(d.days))
 	if ft == "" {
 		ft = "02 Jan 2006"
 	}
 	if ft == "user-input" {
-		today := dateFromTime(time.Now().UTC())
-		if today == d {
+		today := dateFromTime(time.Now().In(location(d.loc)))
+		if today == d.days {
 			return ""
 		}
 	}
 	return t.In(time.UTC).Format(ft)
 }
 
-// Reverse creates a new date from the input
+// Reverse creates a new date from the input which may be in the given
+// format or a relative expression such as "tomorrow" or "+3d"
 func (d Date) Reverse(ft, input string) (Entry, error) {
 	if ft == "" {
 		ft = "02 Jan 2006"
 	}
-	var t time.Time
-	if input == "" {
-		t = time.Now().UTC()
-	} else {
-		noLoc, err := time.Parse(ft, input)
-		if err != nil {
-			return nil, err
-		}
-		t = time.Date(noLoc.Year(), noLoc.Month(), noLoc.Day(), noLoc.Hour(), noLoc.Minute(),
-			noLoc.Second(), noLoc.Nanosecond(), time.UTC)
+	t, err := parseTimeInput(ft, input, time.Now().In(location(d.loc)))
+	if err != nil {
+		return nil, err
 	}
-	return dateFromTime(t), nil
+	return Date{days: dateFromTime(t), loc: d.loc}, nil
 }
 
-func dateFromTime(t time.Time) Date {
-	return Date(t.Sub(epoch) / (time.Hour * 24))
+// dateFromTime returns the number of days since the epoch of the calendar
+// day of the time in its own location
+func dateFromTime(t time.Time) int {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return int(day.Sub(epoch) / (time.Hour * 24))
 }
 
 // Compare returns true iff the given object is a Date and comes
@@ -72,7 +129,7 @@ func (d Date) Compare(i interface{}) bool {
 	if !ok {
 		return false
 	}
-	return entry > d
+	return entry.days > d.days
 }
 
 // Add increments the Date by the provided number of days
@@ -81,55 +138,58 @@ func (d Date) Add(i interface{}) (Entry, error) {
 	if !ok {
 		return nil, fmt.Errorf("Dates can only be incremented by integers")
 	}
-	return Date(int(d) + days), nil
+	return Date{days: d.days + days, loc: d.loc}, nil
 }
 
 // Encoded returns the Date encoded as a string
 func (d Date) Encoded() storage.Primitive {
-	return int(d)
+	return d.days
 }
 
 // A Time denotes a specifc second in history by modeling as the
-// number of seconds (positive or negative) since 1 January 1970 UTC
-type Time int
+// number of seconds (positive or negative) since 1 January 1970 UTC.
+// Times are displayed and parsed in their location.
+type Time struct {
+	secs int
+	loc  *time.Location
+}
 
 // NewTime returns a new time from the encoded data
 func NewTime(i interface{}, features map[string]interface{}) (Entry, error) {
+	loc, err := locationFromFeatures(features)
+	if err != nil {
+		return nil, err
+	}
 	if i == nil {
-		return Time(0), nil
+		return Time{loc: loc}, nil
 	}
 	n, ok := i.(float64)
 	if !ok {
 		return nil, fmt.Errorf("failed to unpack int from: %#v", i)
 	}
-	return Time(n), nil
+	return Time{secs: int(n), loc: loc}, nil
 }
 
 // Format formats the time
 func (t Time) Format(ft string) string {
-	p := epoch.Add(time.Second * time.Duration(t))
+	p := epoch.Add(time.Second * time.Duration(t.secs))
 	if ft == "" {
 		ft = "02 Jan 2006 15:04:05"
 	}
-	return p.In(time.UTC).Format(ft)
+	return p.In(location(t.loc)).Format(ft)
 }
 
-// Reverse creates a new time from the input
+// Reverse creates a new time from the input which may be in the given
+// format or a relative expression such as "tomorrow" or "+3d"
 func (t Time) Reverse(ft, input string) (Entry, error) {
 	if ft == "" {
 		ft = "02 Jan 2006 15:04:05"
 	}
-	if input == "" {
-		return Time(time.Now().Unix()), nil
-	}
-	noLoc, err := time.Parse(ft, input)
+	p, err := parseTimeInput(ft, input, time.Now().In(location(t.loc)))
 	if err != nil {
 		return nil, err
 	}
-	p := time.Date(noLoc.Year(), noLoc.Month(), noLoc.Day(), noLoc.Hour(), noLoc.Minute(),
-		noLoc.Second(), noLoc.Nanosecond(), time.UTC)
-
-	return Time(p.Sub(epoch) / time.Second), nil
+	return Time{secs: int(p.Sub(epoch) / time.Second), loc: t.loc}, nil
 }
 
 // Compare returns true iff the given object is a Time and comes
@@ -139,7 +199,7 @@ func (t Time) Compare(i interface{}) bool {
 	if !ok {
 		return false
 	}
-	return entry > t
+	return entry.secs > t.secs
 }
 
 // Add increments the Time by the provided number of days
@@ -148,10 +208,10 @@ func (t Time) Add(i interface{}) (Entry, error) {
 	if !ok {
 		return nil, fmt.Errorf("Times can only be incremented by integers")
 	}
-	return Time(int(t) + days*24*60*60), nil
+	return Time{secs: t.secs + days*24*60*60, loc: t.loc}, nil
 }
 
 // Encoded returns the Time encoded as a string
 func (t Time) Encoded() storage.Primitive {
-	return int(t)
+	return t.secs
 }
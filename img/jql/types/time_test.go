@@ -16,7 +16,7 @@ func TestLoad(t *testing.T) {
 	}{
 		{
 			name:     "default format",
-			date:     Date(0),
+			date:     Date{},
 			format:   "",
 			expected: "01 Jan 1970",
 		},
@@ -27,3 +27,43 @@ func TestLoad(t *testing.T) {
 		})
 	}
 }
+
+func TestTimeZone(t *testing.T) {
+	cases := []struct {
+		name     string
+		features map[string]interface{}
+		input    string
+		expected string
+		encoded  int
+	}{
+		{
+			name:     "default location",
+			features: map[string]interface{}{},
+			input:    "01 Jan 1970 01:00:00",
+			expected: "01 Jan 1970 01:00:00",
+			encoded:  3600,
+		},
+		{
+			name:     "column timezone",
+			features: map[string]interface{}{"timezone": "America/New_York"},
+			input:    "01 Jan 1970 01:00:00",
+			expected: "01 Jan 1970 01:00:00",
+			encoded:  6 * 3600,
+		},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			empty, err := NewTime(nil, tc.features)
+			require.NoError(t, err)
+			entry, err := empty.Reverse("", tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, entry.Format(""))
+			require.Equal(t, tc.encoded, entry.Encoded())
+		})
+	}
+}
+
+func TestUnknownTimeZone(t *testing.T) {
+	_, err := NewDate(nil, map[string]interface{}{"timezone": "Mars/Olympus_Mons"})
+	require.Error(t, err)
+}
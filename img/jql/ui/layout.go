@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// defaultColumnWidth is the widest a column is displayed unless its
+	// layout says otherwise
+	defaultColumnWidth = 40
+	// minColumnWidth is the narrowest a column can be resized to
+	minColumnWidth = 3
+)
+
+// A layoutColumn is a column of the table view along with its width, or 0
+// if the width should be derived from the column's contents, and whether
+// it's hidden
+type layoutColumn struct {
+	Name   string
+	Width  int
+	Hidden bool
+}
+
+// parseLayout parses a column layout of the form "Name:30, Status, -Notes"
+// listing columns in the order to show them with optional widths. Columns
+// prefixed with "-" are hidden. Columns not in the layout are shown after
+// those that are so an empty layout shows every column.
+func parseLayout(s string) ([]layoutColumn, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	layout := []layoutColumn{}
+	for _, part := range strings.Split(s, ",") {
+		name, widthS, hasWidth := strings.Cut(strings.TrimSpace(part), ":")
+		column := layoutColumn{Name: strings.TrimSpace(name)}
+		if strings.HasPrefix(column.Name, "-") {
+			column.Name = strings.TrimPrefix(column.Name, "-")
+			column.Hidden = true
+		}
+		if column.Name == "" {
+			return nil, fmt.Errorf("invalid layout %q: empty column name", s)
+		}
+		if hasWidth {
+			width, err := strconv.Atoi(strings.TrimSpace(widthS))
+			if err != nil || width <= 0 {
+				return nil, fmt.Errorf("invalid layout %q: bad width for %s", s, column.Name)
+			}
+			column.Width = width
+		}
+		layout = append(layout, column)
+	}
+	return layout, nil
+}
+
+// formatLayout formats a column layout so that it can be parsed by parseLayout
+func formatLayout(layout []layoutColumn) string {
+	parts := []string{}
+	for _, column := range layout {
+		part := column.Name
+		if column.Hidden {
+			part = "-" + part
+		}
+		if column.Width > 0 {
+			part = fmt.Sprintf("%s:%d", part, column.Width)
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// indexOfLayoutColumn returns the index of the named column in the layout or
+// -1 if it's not present
+func indexOfLayoutColumn(layout []layoutColumn, name string) int {
+	for i, column := range layout {
+		if column.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// hideColumn hides the named column unless it's the only one shown
+func hideColumn(layout []layoutColumn, name string) error {
+	shown := 0
+	for _, column := range layout {
+		if !column.Hidden {
+			shown++
+		}
+	}
+	if shown <= 1 {
+		return fmt.Errorf("Cannot hide the only column shown")
+	}
+	index := indexOfLayoutColumn(layout, name)
+	if index == -1 {
+		return fmt.Errorf("no such column: %s", name)
+	}
+	layout[index].Hidden = true
+	return nil
+}
+
+// unhideColumns shows every hidden column and returns whether there were any
+func unhideColumns(layout []layoutColumn) bool {
+	unhidden := false
+	for i := range layout {
+		unhidden = unhidden || layout[i].Hidden
+		layout[i].Hidden = false
+	}
+	return unhidden
+}
+
+// moveColumn swaps the named column with the next shown column in the
+// direction of delta, staying put at either end
+func moveColumn(layout []layoutColumn, name string, delta int) error {
+	index := indexOfLayoutColumn(layout, name)
+	if index == -1 {
+		return fmt.Errorf("no such column: %s", name)
+	}
+	for neighbor := index + delta; neighbor >= 0 && neighbor < len(layout); neighbor += delta {
+		if !layout[neighbor].Hidden {
+			layout[index], layout[neighbor] = layout[neighbor], layout[index]
+			return nil
+		}
+	}
+	return nil
+}
+
+// resizeColumn sets the width of the named column to its current width plus
+// delta but no narrower than minColumnWidth
+func resizeColumn(layout []layoutColumn, name string, current, delta int) error {
+	index := indexOfLayoutColumn(layout, name)
+	if index == -1 {
+		return fmt.Errorf("no such column: %s", name)
+	}
+	layout[index].Width = max(minColumnWidth, current+delta)
+	return nil
+}
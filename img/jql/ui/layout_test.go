@@ -0,0 +1,185 @@
+package ui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLayout(t *testing.T) {
+	cases := []struct {
+		name     string
+		layout   string
+		expected []layoutColumn
+		err      bool
+	}{
+		{
+			name:   "empty layout",
+			layout: " ",
+		},
+		{
+			name:   "widths and hidden columns",
+			layout: "Name:30, Status, -Notes:12",
+			expected: []layoutColumn{
+				{Name: "Name", Width: 30},
+				{Name: "Status"},
+				{Name: "Notes", Width: 12, Hidden: true},
+			},
+		},
+		{
+			name:   "empty column name",
+			layout: "Name, , Status",
+			err:    true,
+		},
+		{
+			name:   "non-numeric width",
+			layout: "Name:wide",
+			err:    true,
+		},
+		{
+			name:   "zero width",
+			layout: "Name:0",
+			err:    true,
+		},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			layout, err := parseLayout(tc.layout)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, layout)
+		})
+	}
+}
+
+func TestFormatLayout(t *testing.T) {
+	formatted := "Name:30, Status, -Notes:12"
+	layout, err := parseLayout(formatted)
+	require.NoError(t, err)
+	require.Equal(t, formatted, formatLayout(layout))
+}
+
+func newTestLayout() []layoutColumn {
+	return []layoutColumn{{Name: "Name"}, {Name: "Status", Hidden: true}, {Name: "Notes"}, {Name: "Tags"}}
+}
+
+func TestMoveColumn(t *testing.T) {
+	cases := []struct {
+		name     string
+		column   string
+		delta    int
+		expected string
+		err      bool
+	}{
+		{
+			name:     "right past a hidden column",
+			column:   "Name",
+			delta:    1,
+			expected: "Notes, -Status, Name, Tags",
+		},
+		{
+			name:     "left",
+			column:   "Tags",
+			delta:    -1,
+			expected: "Name, -Status, Tags, Notes",
+		},
+		{
+			name:     "left at the start",
+			column:   "Name",
+			delta:    -1,
+			expected: "Name, -Status, Notes, Tags",
+		},
+		{
+			name:     "right at the end",
+			column:   "Tags",
+			delta:    1,
+			expected: "Name, -Status, Notes, Tags",
+		},
+		{
+			name:   "missing column",
+			column: "Owner",
+			delta:  1,
+			err:    true,
+		},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			layout := newTestLayout()
+			err := moveColumn(layout, tc.column, tc.delta)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, formatLayout(layout))
+		})
+	}
+}
+
+func TestHideColumn(t *testing.T) {
+	layout := newTestLayout()
+	require.NoError(t, hideColumn(layout, "Name"))
+	require.NoError(t, hideColumn(layout, "Notes"))
+	require.Error(t, hideColumn(layout, "Tags"))
+	require.Error(t, hideColumn(layout, "Owner"))
+	require.Equal(t, "-Name, -Status, -Notes, Tags", formatLayout(layout))
+
+	require.True(t, unhideColumns(layout))
+	require.Equal(t, "Name, Status, Notes, Tags", formatLayout(layout))
+	require.False(t, unhideColumns(layout))
+}
+
+func TestResizeColumn(t *testing.T) {
+	cases := []struct {
+		name     string
+		column   string
+		current  int
+		delta    int
+		expected int
+		err      bool
+	}{
+		{
+			name:     "widen",
+			column:   "Notes",
+			current:  20,
+			delta:    5,
+			expected: 25,
+		},
+		{
+			name:     "narrow",
+			column:   "Notes",
+			current:  20,
+			delta:    -5,
+			expected: 15,
+		},
+		{
+			name:     "narrow past the minimum",
+			column:   "Notes",
+			current:  4,
+			delta:    -5,
+			expected: minColumnWidth,
+		},
+		{
+			name:   "missing column",
+			column: "Owner",
+			delta:  5,
+			err:    true,
+		},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			layout := newTestLayout()
+			err := resizeColumn(layout, tc.column, tc.current, tc.delta)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, layout[indexOfLayoutColumn(layout, tc.column)].Width)
+		})
+	}
+}
@@ -3,12 +3,16 @@ package ui
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"os"
 	"os/exec"
 	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jroimartin/gocui"
 	"github.com/ulmenhaus/env/img/jql/api"
@@ -48,6 +52,12 @@ const (
 	// MainViewModePromptForMacro is for when the user is
 	// requested to select a macro to run
 	MainViewModePromptForMacro
+	// MainViewModePromptForView is for when the user is
+	// requested to select a saved view to open
+	MainViewModePromptForView
+	// MainViewModePromptForForeignTable is for when the user is
+	// requested to select the table a polyforeign value refers to
+	MainViewModePromptForForeignTable
 
 	// MacroTable is the name of the standard table containing
 	// macros
@@ -64,6 +74,20 @@ const (
 	// MacroV2Col is teh name of the column of the macros table
 	// indicating if the macro supports the v2 interface
 	MacroV2Col = "V2"
+	// MacroProtocolCol is the name of the column of the macros table
+	// containing the protocol version of the macro, e.g. "v3". It takes
+	// precedence over the V2 column.
+	MacroProtocolCol = "Protocol"
+	// MacroTimeoutCol is the name of the column of the macros table
+	// containing how long the macro may run, e.g. "30s"
+	MacroTimeoutCol = "Timeout"
+	// MacroActionsCol is the name of the column of the macros table
+	// containing a pipeline of built-in actions, e.g.
+	// "set Status=Satisfied | next". It takes precedence over the location.
+	MacroActionsCol = "Actions"
+	// tableKeys are the keys the table view handles itself so macros bound
+	// to them can only be run from the macro prompt
+	tableKeys = `"rlkhjguGU><fFqQdD':?/oOpPiIJKsnNwyYbLHmvVxX{}+-`
 
 	blackTextEscape = "\033[30m"
 	whiteBackEscape = "\033[47m"
@@ -71,25 +95,62 @@ const (
 	resetEscape     = "\033[0m"
 )
 
+// searchKind is the type of match used for filtering when the user
+// is typing a search query
+type searchKind int
+
+const (
+	searchKindContains searchKind = iota
+	searchKindPrefix
+	searchKindRegex
+)
+
+// label returns the indicator shown in the prompt while searching
+func (sk searchKind) label() string {
+	switch sk {
+	case searchKindPrefix:
+		return "(prefix) "
+	case searchKindRegex:
+		return "(regex) "
+	}
+	return ""
+}
+
 // A MainView is the overall view of the table including headers,
 // prompts, &c. It will also be responsible for managing differnt
 // interaction modes if jql supports those.
 type MainView struct {
 	dbms api.JQL_DBMS
+	gui  *gocui.Gui
 
 	TableView *TableView
 	Mode      MainViewMode
 
-	request  jqlpb.ListRowsRequest
+	request  *jqlpb.ListRowsRequest
 	response *jqlpb.ListRowsResponse
 
 	switching     bool // on when transitioning modes has not yet been acknowleged by Layout
 	alert         string
+	alertLog      string // shown below the alert, e.g. the log of a failed macro
 	promptText    string
 	searchText    string
 	searchAll     bool // indicates if we search all fields or just this one
+	searchKind    searchKind
 	selectOptions []string
 	selectedPK    string
+
+	// marked are the pks of the rows selected for bulk operations. Marks are
+	// kept by pk so that they survive paging and ordering but they're cleared
+	// when the filters change so that rows that aren't shown can't be deleted.
+	marked         map[string]bool
+	markAnchor     string             // pk of the row last marked where a marked range starts
+	markConditions []*jqlpb.Condition // the conditions under which the rows were marked
+
+	layout      []layoutColumn // the layout of the columns of the table
+	layoutTable string         // the table the layout is of
+	layoutView  string         // the view the layout is of if it isn't the table's
+
+	macroCancel context.CancelFunc // cancels the running macro if there is one
 }
 
 // NewMainView returns a MainView initialized with a given Table
@@ -97,12 +158,45 @@ func NewMainView(dbms api.JQL_DBMS, start string) (*MainView, error) {
 	mv := &MainView{
 		dbms: dbms,
 	}
-	return mv, mv.loadTable(start)
+	if err := mv.loadTable(start); err != nil {
+		return nil, err
+	}
+	if shadowed := mv.shadowedMacros(); len(shadowed) > 0 {
+		mv.showError(fmt.Errorf("Macros bound to keys used by jql can only be run from the macro prompt (m): %s", strings.Join(shadowed, ", ")))
+	}
+	return mv, nil
+}
+
+// shadowedMacros returns the macros bound to keys the table view handles
+// itself. Macros are optional so any error listing them is ignored.
+func (mv *MainView) shadowedMacros() []string {
+	resp, err := mv.dbms.ListRows(ctx, &jqlpb.ListRowsRequest{Table: MacroTable})
+	if err != nil {
+		return nil
+	}
+	keyIndex := api.IndexOfField(resp.GetColumns(), MacroKeyCol)
+	if keyIndex == -1 {
+		return nil
+	}
+	primary := api.GetPrimary(resp.GetColumns())
+	shadowed := []string{}
+	for _, row := range resp.GetRows() {
+		key := row.GetEntries()[keyIndex].GetFormatted()
+		if len(key) == 1 && strings.Contains(tableKeys, key) {
+			shadowed = append(shadowed, fmt.Sprintf("%s (%s)", row.GetEntries()[primary].GetFormatted(), key))
+		}
+	}
+	return shadowed
 }
 
 // loadTable displays the named table in the main table view
 func (mv *MainView) loadTable(t string) error {
-	mv.request = jqlpb.ListRowsRequest{
+	mv.clearMarks()
+	// the saved layout of the table is loaded even if it's already shown
+	// in case it was shown with the layout of a view
+	mv.layoutTable = ""
+	mv.layoutView = ""
+	mv.request = &jqlpb.ListRowsRequest{
 		Table:      t,
 		Conditions: []*jqlpb.Condition{{}},
 		GroupBy:    &jqlpb.GroupBy{},
@@ -127,6 +221,9 @@ func (mv *MainView) filteredSelectOptions(g *gocui.Gui) []string {
 
 // Layout returns the gocui object
 func (mv *MainView) Layout(g *gocui.Gui) error {
+	// keep the gui around so that background work like macros can update
+	// the view once it's done
+	mv.gui = g
 	switching := mv.switching
 	mv.switching = false
 
@@ -203,7 +300,24 @@ func (mv *MainView) Layout(g *gocui.Gui) error {
 	// Virtual Tables store auxiliary pks in an entry's main pk to prevent unnecessary look-ups
 	// when modifying entries. These auxiliary pks are tab delimited so we hide them here
 	location.Write([]byte(fmt.Sprintf("    L%d C%d           %s", row, col, strings.Split(primarySelection.Formatted, "\t")[0])))
-	if mv.Mode == MainViewModeSelectBox || mv.Mode == MainViewModePromptForMacro {
+	if mv.Mode == MainViewModeAlert && mv.alertLog != "" {
+		alertLog, err := g.SetView("alertLog", 0, 3+groupingsOffset, maxX-2, maxY+groupingsOffset-5)
+		if err != nil {
+			if err != gocui.ErrUnknownView {
+				return err
+			}
+			alertLog.Title = "log"
+			alertLog.Wrap = true
+		}
+		alertLog.Clear()
+		alertLog.Write([]byte(tailLines(mv.alertLog, maxY+groupingsOffset-5-(3+groupingsOffset)-1)))
+	} else {
+		err := g.DeleteView("alertLog")
+		if err != nil && err != gocui.ErrUnknownView {
+			return err
+		}
+	}
+	if mv.Mode == MainViewModeSelectBox || mv.Mode == MainViewModePromptForMacro || mv.Mode == MainViewModePromptForView || mv.Mode == MainViewModePromptForForeignTable {
 		selectBox, err := g.SetView("selectBox", maxX/2-30, maxY/2-10, maxX/2+30, maxY/2+10)
 		if err != nil {
 			if err != gocui.ErrUnknownView {
@@ -246,7 +360,7 @@ func (mv *MainView) Layout(g *gocui.Gui) error {
 
 	}
 	switch mv.Mode {
-	case MainViewModeSelectBox, MainViewModePromptForMacro:
+	case MainViewModeSelectBox, MainViewModePromptForMacro, MainViewModePromptForView, MainViewModePromptForForeignTable:
 		selectBox, err := g.View("selectBox")
 		if err != nil {
 			return err
@@ -266,7 +380,7 @@ func (mv *MainView) Layout(g *gocui.Gui) error {
 			return err
 		}
 		g.Cursor = false
-		fmt.Fprintf(prompt, mv.alert)
+		fmt.Fprintf(prompt, "%s", mv.alert)
 	case MainViewModePrompt:
 		if _, err := g.SetCurrentView("prompt"); err != nil {
 			return err
@@ -290,6 +404,7 @@ func (mv *MainView) Layout(g *gocui.Gui) error {
 		} else {
 			prompt.Write([]byte{'/'})
 		}
+		prompt.Write([]byte(mv.searchKind.label()))
 		prompt.Write([]byte(mv.searchText))
 	}
 	return nil
@@ -356,6 +471,21 @@ func (mv *MainView) handleSelectInput(g *gocui.Gui, v *gocui.View) error {
 		var ch rune
 		return mv.runMacro(ch, selected)
 	}
+	if mv.Mode == MainViewModePromptForView {
+		mv.switchMode(MainViewModeTable)
+		return mv.openView(selected)
+	}
+	if mv.Mode == MainViewModePromptForForeignTable {
+		// the select box is reused for the rows of the table so the query
+		// for the table is cleared
+		if searchBox, err := g.View("searchBox"); err == nil {
+			searchBox.Clear()
+			searchBox.SetCursor(0, 0)
+		}
+		v.SetCursor(0, 0)
+		v.SetOrigin(0, 0)
+		return mv.selectPolyForeignRow(selected)
+	}
 	mv.switchMode(MainViewModeTable)
 	return mv.updateEntryValue(selected)
 }
@@ -367,7 +497,10 @@ func (mv *MainView) handleSearchInput(v *gocui.View, key gocui.Key, ch rune, mod
 		mv.switching = true
 		return nil
 	}
-	if key == gocui.KeyBackspace || key == gocui.KeyBackspace2 {
+	if key == gocui.KeyCtrlR {
+		// Ctrl-R cycles between contains, prefix, and regex matching
+		mv.searchKind = (mv.searchKind + 1) % (searchKindRegex + 1)
+	} else if key == gocui.KeyBackspace || key == gocui.KeyBackspace2 {
 		if len(mv.searchText) != 0 {
 			mv.searchText = mv.searchText[:len(mv.searchText)-1]
 		}
@@ -385,11 +518,35 @@ func (mv *MainView) handleSearchInput(v *gocui.View, key gocui.Key, ch rune, mod
 	if mv.searchAll {
 		field = "Any field"
 	}
-	mv.request.Conditions[0].Requires[len(mv.request.Conditions[0].Requires)-1] = &jqlpb.Filter{
+	if mv.searchKind == searchKindRegex {
+		if _, err := regexp.Compile(mv.searchText); err != nil {
+			// The user is likely mid-way through typing the expression so
+			// keep showing the last valid results
+			return nil
+		}
+	}
+	mv.request.Conditions[0].Requires[len(mv.request.Conditions[0].Requires)-1] = mv.searchFilter(field)
+	return mv.updateTableViewContents(true)
+}
+
+// searchFilter returns the filter for the current search text on the given field
+func (mv *MainView) searchFilter(field string) *jqlpb.Filter {
+	switch mv.searchKind {
+	case searchKindPrefix:
+		return &jqlpb.Filter{
+			Column: field,
+			Match:  &jqlpb.Filter_PrefixMatch{PrefixMatch: &jqlpb.PrefixMatch{Value: mv.searchText}},
+		}
+	case searchKindRegex:
+		return &jqlpb.Filter{
+			Column: field,
+			Match:  &jqlpb.Filter_RegexMatch{RegexMatch: &jqlpb.RegexMatch{Value: mv.searchText}},
+		}
+	}
+	return &jqlpb.Filter{
 		Column: field,
 		Match:  &jqlpb.Filter_ContainsMatch{ContainsMatch: &jqlpb.ContainsMatch{Value: mv.searchText}},
 	}
-	return mv.updateTableViewContents(true)
 }
 
 func (mv *MainView) triggerEdit() error {
@@ -399,6 +556,9 @@ func (mv *MainView) triggerEdit() error {
 	case jqlpb.EntryType_ENUM:
 		mv.selectOptions = meta.Values
 		mv.switchMode(MainViewModeSelectBox)
+	case jqlpb.EntryType_BOOL:
+		mv.selectOptions = []string{"no", "yes"}
+		mv.switchMode(MainViewModeSelectBox)
 	case jqlpb.EntryType_FOREIGN:
 		resp, err := mv.dbms.ListRows(ctx, &jqlpb.ListRowsRequest{
 			Table: meta.ForeignTable,
@@ -413,6 +573,13 @@ func (mv *MainView) triggerEdit() error {
 		}
 		mv.selectOptions = values
 		mv.switchMode(MainViewModeSelectBox)
+	case jqlpb.EntryType_POLYFOREIGN:
+		// the table is selected first so that only its rows are listed
+		if len(meta.ForeignTables) == 1 {
+			return mv.selectPolyForeignRow(meta.ForeignTables[0])
+		}
+		mv.selectOptions = meta.ForeignTables
+		mv.switchMode(MainViewModePromptForForeignTable)
 	default:
 		mv.promptText = mv.TableView.Values[row][col]
 		mv.switchMode(MainViewModeEdit)
@@ -420,17 +587,41 @@ func (mv *MainView) triggerEdit() error {
 	return nil
 }
 
+// selectPolyForeignRow prompts the user to select a row of the table as the
+// value of the selected polyforeign entry
+func (mv *MainView) selectPolyForeignRow(table string) error {
+	resp, err := mv.dbms.ListRows(ctx, &jqlpb.ListRowsRequest{
+		Table: table,
+	})
+	if err != nil {
+		mv.switchMode(MainViewModeTable)
+		return err
+	}
+	values := []string{}
+	primary := api.GetPrimary(resp.Columns)
+	for _, row := range resp.Rows {
+		values = append(values, api.ConstructPolyForeign(table, row.Entries[primary].Formatted))
+	}
+	mv.selectOptions = values
+	mv.switchMode(MainViewModeSelectBox)
+	return nil
+}
+
 // Edit handles keyboard inputs while in table mode
 func (mv *MainView) Edit(v *gocui.View, key gocui.Key, ch rune, mod gocui.Modifier) {
+	if mv.MacroRunning() {
+		// input is ignored until the running macro finishes or is cancelled
+		return
+	}
 	if mv.Mode == MainViewModeAlert {
+		mv.alertLog = ""
 		mv.switchMode(MainViewModeTable)
 	}
 
 	var err error
 	defer func() {
 		if err != nil {
-			mv.alert = err.Error()
-			mv.switchMode(MainViewModeAlert)
+			mv.showError(err)
 		}
 	}()
 
@@ -466,6 +657,10 @@ func (mv *MainView) Edit(v *gocui.View, key gocui.Key, ch rune, mod gocui.Modifi
 	case gocui.KeyPgup:
 		mv.request.Offset = uint32(mv.prevPageStart())
 		err = mv.updateTableViewContents(true)
+	case gocui.KeyCtrlA:
+		err = mv.markAll()
+	case gocui.KeyCtrlV:
+		err = mv.promptForView()
 	}
 
 	if int(ch) == 0 {
@@ -531,7 +726,10 @@ func (mv *MainView) Edit(v *gocui.View, key gocui.Key, ch rune, mod gocui.Modifi
 		})
 		err = mv.updateTableViewContents(true)
 	case 'q':
-		if len(mv.request.GroupBy.Groupings) > 0 {
+		// q first deselects marked rows before removing filters
+		if mv.clearMarks() {
+			err = mv.updateTableViewContents(false)
+		} else if len(mv.request.GroupBy.Groupings) > 0 {
 			mv.request.GroupBy.Groupings = mv.request.GroupBy.Groupings[:len(mv.request.GroupBy.Groupings)-1]
 		} else if len(mv.request.Conditions[0].Requires) > 0 {
 			mv.request.Conditions[0].Requires = mv.request.Conditions[0].Requires[:len(mv.request.Conditions[0].Requires)-1]
@@ -541,7 +739,7 @@ func (mv *MainView) Edit(v *gocui.View, key gocui.Key, ch rune, mod gocui.Modifi
 		mv.request.Conditions = []*jqlpb.Condition{{}}
 		err = mv.updateTableViewContents(true)
 	case 'd':
-		err = mv.deleteSelectedRow()
+		err = mv.deleteSelectedRows()
 		if err != nil {
 			return
 		}
@@ -559,17 +757,11 @@ func (mv *MainView) Edit(v *gocui.View, key gocui.Key, ch rune, mod gocui.Modifi
 		mv.switchMode(MainViewModePrompt)
 	case '?':
 		mv.searchAll = true
-		mv.request.Conditions[0].Requires = append(mv.request.Conditions[0].Requires, &jqlpb.Filter{
-			Column: "",
-			Match:  &jqlpb.Filter_ContainsMatch{ContainsMatch: &jqlpb.ContainsMatch{Value: ""}},
-		})
+		mv.request.Conditions[0].Requires = append(mv.request.Conditions[0].Requires, mv.searchFilter(""))
 		mv.switchMode(MainViewModeSearch)
 	case '/':
 		mv.searchAll = false
-		mv.request.Conditions[0].Requires = append(mv.request.Conditions[0].Requires, &jqlpb.Filter{
-			Column: mv.response.Columns[mv.TableView.Selections.Primary.Column].Name,
-			Match:  &jqlpb.Filter_ContainsMatch{ContainsMatch: &jqlpb.ContainsMatch{Value: ""}},
-		})
+		mv.request.Conditions[0].Requires = append(mv.request.Conditions[0].Requires, mv.searchFilter(mv.response.Columns[mv.TableView.Selections.Primary.Column].Name))
 		mv.switchMode(MainViewModeSearch)
 	case 'o':
 		_, col := mv.SelectedEntry()
@@ -617,6 +809,35 @@ func (mv *MainView) Edit(v *gocui.View, key gocui.Key, ch rune, mod gocui.Modifi
 		err = mv.shiftSelectedGrouping(-1)
 	case 'm':
 		err = mv.promptForMacro()
+	case 'v':
+		err = mv.toggleMark()
+	case 'V':
+		err = mv.markRange()
+	case 'x':
+		err = mv.hideSelectedColumn()
+	case 'X':
+		err = mv.updateLayout(func(layout []layoutColumn, name string) error {
+			unhideColumns(layout)
+			return nil
+		})
+	case '{', '}':
+		delta := 1
+		if ch == '{' {
+			delta = -1
+		}
+		err = mv.updateLayout(func(layout []layoutColumn, name string) error {
+			return moveColumn(layout, name, delta)
+		})
+	case '+', '-':
+		delta := 1
+		if ch == '-' {
+			delta = -1
+		}
+		_, col := mv.SelectedEntry()
+		current := mv.columnWidth(mv.response.Columns[col])
+		err = mv.updateLayout(func(layout []layoutColumn, name string) error {
+			return resizeColumn(layout, name, current, delta)
+		})
 	default:
 		err = mv.runMacro(ch, "")
 	}
@@ -637,6 +858,9 @@ func (mv *MainView) headerContents() []byte {
 	// Actual params are 0-indexed but displayed as 1-indexed
 	l1 := fmt.Sprintf("Table: %s\t\t\t Entries %d - %d of %d (%d total)",
 		mv.request.Table, mv.request.Offset+1, mv.nextPageStart(), mv.response.Total, mv.response.All)
+	if len(mv.marked) > 0 {
+		l1 += fmt.Sprintf(" (%d selected)", len(mv.marked))
+	}
 	subqs := make([]string, len(mv.request.Conditions[0].Requires))
 	for i, filter := range mv.request.Conditions[0].Requires {
 		subqs[i] = api.Description(filter)
@@ -646,15 +870,98 @@ func (mv *MainView) headerContents() []byte {
 }
 
 func (mv *MainView) getColumnIndices() []int {
+	return mv.columnIndices(mv.response)
+}
+
+// columnIndices returns the indices of the columns of the response to show in
+// the order to show them
+func (mv *MainView) columnIndices(response *jqlpb.ListRowsResponse) []int {
 	var indices []int
-	for i, col := range mv.response.Columns {
-		if !strings.HasPrefix(col.Name, "_") {
-			indices = append(indices, i)
+	for _, column := range mv.fullLayout(response) {
+		if !column.Hidden {
+			indices = append(indices, api.IndexOfField(response.Columns, column.Name))
 		}
 	}
 	return indices
 }
 
+// fullLayout returns the layout of every column of the response. Columns
+// prefixed with an underscore are left out unless they're in the layout.
+func (mv *MainView) fullLayout(response *jqlpb.ListRowsResponse) []layoutColumn {
+	layout := []layoutColumn{}
+	for _, column := range mv.layout {
+		if api.IndexOfField(response.Columns, column.Name) != -1 && indexOfLayoutColumn(layout, column.Name) == -1 {
+			layout = append(layout, column)
+		}
+	}
+	for _, col := range response.Columns {
+		if !strings.HasPrefix(col.Name, "_") && indexOfLayoutColumn(layout, col.Name) == -1 {
+			layout = append(layout, layoutColumn{Name: col.Name})
+		}
+	}
+	return layout
+}
+
+// updateLayout applies f to the layout of the table given the name of the
+// selected column and saves the new layout to the database. If the layout
+// is that of a loaded view it's saved to the view instead of the table.
+func (mv *MainView) updateLayout(f func(layout []layoutColumn, name string) error) error {
+	_, col := mv.SelectedEntry()
+	layout := mv.fullLayout(mv.response)
+	if err := f(layout, mv.response.Columns[col].Name); err != nil {
+		return err
+	}
+	mv.layout = layout
+	mv.layoutTable = mv.response.Table
+	if err := mv.updateTableViewContents(false); err != nil {
+		return err
+	}
+	if mv.layoutView != "" {
+		view, err := api.GetView(ctx, mv.dbms, mv.layoutView)
+		if err != nil {
+			return err
+		}
+		view.Layout = formatLayout(layout)
+		return api.SaveView(ctx, mv.dbms, view)
+	}
+	return api.SaveLayout(ctx, mv.dbms, mv.response.Table, formatLayout(layout))
+}
+
+// hideSelectedColumn hides the selected column and selects the one that
+// takes its place
+func (mv *MainView) hideSelectedColumn() error {
+	_, col := mv.TableView.PrimarySelection()
+	err := mv.updateLayout(func(layout []layoutColumn, name string) error {
+		return hideColumn(layout, name)
+	})
+	mv.TableView.Selections.Primary.Column = min(col, len(mv.TableView.Header)-1)
+	return err
+}
+
+// loadLayout returns the saved layout of the table. Layouts only affect
+// presentation so a layout that can't be loaded is ignored.
+func (mv *MainView) loadLayout(table string) []layoutColumn {
+	saved, err := api.GetLayout(ctx, mv.dbms, table)
+	if err != nil {
+		return nil
+	}
+	layout, err := parseLayout(saved)
+	if err != nil {
+		return nil
+	}
+	return layout
+}
+
+// columnWidth returns the width at which to show a column
+func (mv *MainView) columnWidth(col *jqlpb.Column) int {
+	for _, column := range mv.layout {
+		if column.Name == col.Name && column.Width > 0 {
+			return column.Width
+		}
+	}
+	return minInt(int(col.MaxLength), defaultColumnWidth)
+}
+
 func minInt(a, b int) int {
 	if a < b {
 		return a
@@ -666,9 +973,13 @@ func (mv *MainView) selectColumn(respA, respB *jqlpb.ListRowsResponse) int {
 	// If after changing the contents, the same column in the
 	// same table exists, then we select it
 	if respA != nil && respA.Table == respB.Table {
-		for i, col := range respB.GetColumns() {
-			if col.Name == respA.GetColumns()[mv.TableView.Selections.Primary.Column].Name {
-				return i
+		indicesA := mv.columnIndices(respA)
+		if mv.TableView.Selections.Primary.Column < len(indicesA) {
+			selected := respA.GetColumns()[indicesA[mv.TableView.Selections.Primary.Column]].Name
+			for i, index := range mv.columnIndices(respB) {
+				if respB.GetColumns()[index].Name == selected {
+					return i
+				}
 			}
 		}
 	}
@@ -677,12 +988,20 @@ func (mv *MainView) selectColumn(respA, respB *jqlpb.ListRowsResponse) int {
 }
 
 func (mv *MainView) updateTableViewContents(resetCursorRow bool) error {
-	response, err := mv.dbms.ListRows(ctx, &mv.request)
+	if len(mv.marked) > 0 && !conditionsEqual(mv.markConditions, mv.request.Conditions) {
+		mv.clearMarks()
+	}
+	response, err := mv.dbms.ListRows(ctx, mv.request)
 	if err != nil {
 		return err
 	}
 	mv.request.Table = response.Table
 	selectedCol := mv.selectColumn(mv.response, response)
+	if response.Table != mv.layoutTable {
+		mv.layout = mv.loadLayout(response.Table)
+		mv.layoutTable = response.Table
+		mv.layoutView = ""
+	}
 	selectedRow := 0
 	if !resetCursorRow && mv.TableView != nil {
 		selectedRow = mv.TableView.Selections.Primary.Row
@@ -716,7 +1035,7 @@ func (mv *MainView) updateTableViewContents(resetCursorRow bool) error {
 			}
 		}
 		header = append(header, name)
-		widths = append(widths, minInt(int(col.MaxLength), 40))
+		widths = append(widths, mv.columnWidth(col))
 	}
 	mv.TableView = &TableView{
 		Header: header,
@@ -733,7 +1052,13 @@ func (mv *MainView) updateTableViewContents(resetCursorRow bool) error {
 	}
 
 	// NOTE putting this here to support swapping columns later
-	for _, row := range mv.response.Rows {
+	pkIndex := api.GetPrimary(mv.response.Columns)
+	for r, row := range mv.response.Rows {
+		if mv.marked[row.Entries[pkIndex].Formatted] {
+			for c := range mv.getColumnIndices() {
+				mv.TableView.Selections.Tertiary[Coordinate{Row: r, Column: c}] = true
+			}
+		}
 		formatted := []string{}
 		for _, i := range mv.getColumnIndices() {
 			meta := mv.response.Columns[i]
@@ -785,6 +1110,20 @@ func (mv *MainView) promptExit(contents string, finish bool, err error) {
 			}
 			err = mv.loadTable(parts[1])
 			return
+		case "save-view":
+			if len(parts) < 2 {
+				err = fmt.Errorf("save-view takes 1 arg")
+				return
+			}
+			err = mv.saveView(strings.Join(parts[1:], " "))
+			return
+		case "view":
+			if len(parts) < 2 {
+				err = fmt.Errorf("view takes 1 arg")
+				return
+			}
+			err = mv.openView(strings.Join(parts[1:], " "))
+			return
 		case "create-new-entry":
 			if len(parts) == 0 {
 				err = fmt.Errorf("create-new-entry takes at least 1 arg")
@@ -811,6 +1150,55 @@ func (mv *MainView) promptExit(contents string, finish bool, err error) {
 			}
 			err = mv.updateTableViewContents(true)
 			return
+		case "import-csv":
+			if len(parts) < 2 {
+				err = fmt.Errorf("import-csv takes 1 arg")
+				return
+			}
+			var f *os.File
+			f, err = os.Open(strings.Join(parts[1:], " "))
+			if err != nil {
+				return
+			}
+			defer f.Close()
+			_, err = api.ImportCSV(ctx, mv.dbms, mv.request.Table, f)
+			if err != nil {
+				return
+			}
+			err = mv.updateTableViewContents(true)
+			return
+		case "migrate-enum":
+			// Migrates the selected column to the comma separated values provided. A value
+			// written as old->new renames old to new, and if new is already present old
+			// gets removed with its rows taking on the value new.
+			if len(parts) < 2 {
+				err = fmt.Errorf("migrate-enum takes at least 1 arg")
+				return
+			}
+			_, col := mv.SelectedEntry()
+			values := []string{}
+			renames := map[string]string{}
+			for _, value := range strings.Split(strings.Join(parts[1:], " "), ",") {
+				value = strings.TrimSpace(value)
+				if oldNew := strings.SplitN(value, "->", 2); len(oldNew) == 2 {
+					value = strings.TrimSpace(oldNew[1])
+					renames[strings.TrimSpace(oldNew[0])] = value
+				}
+				if !slices.Contains(values, value) {
+					values = append(values, value)
+				}
+			}
+			_, err = mv.dbms.MigrateEnum(ctx, &jqlpb.MigrateEnumRequest{
+				Table:   mv.request.Table,
+				Column:  mv.response.Columns[col].Name,
+				Values:  values,
+				Renames: renames,
+			})
+			if err != nil {
+				return
+			}
+			err = mv.updateTableViewContents(true)
+			return
 		default:
 			err = fmt.Errorf("unknown command: %s", contents)
 		}
@@ -868,12 +1256,12 @@ loop:
 	if len(keys) == 1 {
 		filter = &jqlpb.Filter{
 			Column: mv.response.Columns[primary].Name,
-			Match:  &jqlpb.Filter_EqualMatch{&jqlpb.EqualMatch{Value: keys[0]}},
+			Match:  &jqlpb.Filter_EqualMatch{EqualMatch: &jqlpb.EqualMatch{Value: keys[0]}},
 		}
 	} else {
 		filter = &jqlpb.Filter{
 			Column: mv.response.Columns[primary].Name,
-			Match:  &jqlpb.Filter_InMatch{&jqlpb.InMatch{Values: keys}},
+			Match:  &jqlpb.Filter_InMatch{InMatch: &jqlpb.InMatch{Values: keys}},
 		}
 	}
 	mv.request.Conditions[0].Requires = []*jqlpb.Filter{filter}
@@ -892,7 +1280,7 @@ func (mv *MainView) goFromSelectedValue(tables []*jqlpb.TableMeta, reverse bool)
 	row, colix := mv.SelectedEntry()
 	selected := mv.response.Rows[row].Entries[api.GetPrimary(mv.response.Columns)]
 	for _, table := range tables {
-		cols := api.GetForeign(table.Columns, mv.request.Table)
+		cols := append(api.GetForeign(table.Columns, mv.request.Table), api.GetPolyforeign(table.Columns, mv.request.Table)...)
 		if len(cols) == 0 {
 			continue
 		}
@@ -924,12 +1312,16 @@ func (mv *MainView) goFromSelectedValue(tables []*jqlpb.TableMeta, reverse bool)
 		var conditions []*jqlpb.Condition
 		// If there are multiple foreign key columns, ignore any that don't have any matching entries
 		for _, col := range cols {
+			value := selected.Formatted
+			if table.Columns[col].Type == jqlpb.EntryType_POLYFOREIGN {
+				value = api.ConstructPolyForeign(mv.request.Table, value)
+			}
 			conditions = []*jqlpb.Condition{
 				{
 					Requires: []*jqlpb.Filter{
 						{
 							Column: table.Columns[col].Name,
-							Match:  &jqlpb.Filter_EqualMatch{EqualMatch: &jqlpb.EqualMatch{Value: selected.Formatted}},
+							Match:  &jqlpb.Filter_EqualMatch{EqualMatch: &jqlpb.EqualMatch{Value: value}},
 						},
 					},
 				},
@@ -980,17 +1372,17 @@ func (mv *MainView) goFromSelectedValuePath(reverse bool) error {
 }
 
 func (mv *MainView) incrementSelected(amt int) error {
-	row, col := mv.SelectedEntry()
-	_, err := mv.dbms.IncrementEntry(ctx, &jqlpb.IncrementEntryRequest{
-		Table:  mv.request.Table,
-		Pk:     mv.response.Rows[row].Entries[api.GetPrimary(mv.response.Columns)].Formatted,
-		Amount: int32(amt),
-		Column: mv.response.Columns[col].Name,
-	})
-	if err != nil {
+	_, col := mv.SelectedEntry()
+	err := mv.forEachSelected(func(pk string) error {
+		_, err := mv.dbms.IncrementEntry(ctx, &jqlpb.IncrementEntryRequest{
+			Table:  mv.request.Table,
+			Pk:     pk,
+			Amount: int32(amt),
+			Column: mv.response.Columns[col].Name,
+		})
 		return err
-	}
-	return mv.updateTableViewContents(false)
+	})
+	return errors.Join(err, mv.updateTableViewContents(false))
 }
 
 func (mv *MainView) moveRow(delta int) error {
@@ -1055,12 +1447,15 @@ func (mv *MainView) openCellInWindow() error {
 	return cmd.Run()
 }
 
-func (mv *MainView) deleteSelectedRow() error {
-	row, _ := mv.SelectedEntry()
-	_, err := mv.dbms.DeleteRow(ctx, &jqlpb.DeleteRowRequest{
-		Table: mv.request.Table,
-		Pk:    mv.response.Rows[row].Entries[api.GetPrimary(mv.response.Columns)].Formatted,
+func (mv *MainView) deleteSelectedRows() error {
+	err := mv.forEachSelected(func(pk string) error {
+		_, err := mv.dbms.DeleteRow(ctx, &jqlpb.DeleteRowRequest{
+			Table: mv.request.Table,
+			Pk:    pk,
+		})
+		return err
 	})
+	mv.clearMarks()
 	return err
 }
 
@@ -1155,17 +1550,20 @@ func (mv *MainView) copyValue() error {
 }
 
 func (mv *MainView) updateEntryValue(contents string) error {
-	row, column := mv.SelectedEntry()
-	_, err := mv.dbms.WriteRow(ctx, &jqlpb.WriteRowRequest{
-		Table:      mv.request.Table,
-		Pk:         mv.response.Rows[row].Entries[api.GetPrimary(mv.response.Columns)].Formatted,
-		Fields:     map[string]string{mv.response.Columns[column].Name: contents},
-		UpdateOnly: true,
-	})
-	if err != nil {
+	_, column := mv.SelectedEntry()
+	if len(mv.marked) > 1 && column == api.GetPrimary(mv.response.Columns) {
+		return fmt.Errorf("Cannot set the primary key of %d rows to the same value", len(mv.marked))
+	}
+	err := mv.forEachSelected(func(pk string) error {
+		_, err := mv.dbms.WriteRow(ctx, &jqlpb.WriteRowRequest{
+			Table:      mv.request.Table,
+			Pk:         pk,
+			Fields:     map[string]string{mv.response.Columns[column].Name: contents},
+			UpdateOnly: true,
+		})
 		return err
-	}
-	return mv.updateTableViewContents(false)
+	})
+	return errors.Join(err, mv.updateTableViewContents(false))
 }
 
 func (mv *MainView) pasteValue() error {
@@ -1277,25 +1675,31 @@ func (mv *MainView) runMacro(ch rune, description string) error {
 	if isReload {
 		return fmt.Errorf("Reloaded macros no longer supported. Please change the macro.")
 	}
-	v2 := v2Index != -1 && entries[v2Index].GetFormatted() == "yes"
-	requestNoLimit := &jqlpb.ListRowsRequest{
-		Table:      mv.request.Table,
-		Conditions: mv.request.Conditions,
-		OrderBy:    mv.request.OrderBy,
-		Dec:        mv.request.Dec,
-		GroupBy:    mv.request.GroupBy,
+	protocolIndex := api.IndexOfField(resp.GetColumns(), MacroProtocolCol)
+	protocol := api.MacroV1
+	if protocolIndex != -1 && entries[protocolIndex].GetFormatted() != "" {
+		protocol, err = api.ParseMacroProtocol(entries[protocolIndex].GetFormatted())
+		if err != nil {
+			return err
+		}
+	} else if v2Index != -1 && entries[v2Index].GetFormatted() == "yes" {
+		protocol = api.MacroV2
 	}
-	response, err := mv.dbms.ListRows(ctx, requestNoLimit)
+	timeout := api.DefaultMacroTimeout
+	timeoutIndex := api.IndexOfField(resp.GetColumns(), MacroTimeoutCol)
+	if timeoutIndex != -1 && entries[timeoutIndex].GetFormatted() != "" {
+		timeout, err = time.ParseDuration(entries[timeoutIndex].GetFormatted())
+		if err != nil {
+			return fmt.Errorf("Invalid timeout for macro: %s", err)
+		}
+	}
+	pks, err := mv.filteredPKs()
 	if err != nil {
 		return err
 	}
-	pks := []string{}
-	for _, row := range response.Rows {
-		pks = append(pks, row.Entries[api.GetPrimary(response.Columns)].Formatted)
-	}
 	row, col := mv.SelectedEntry()
 	primarySelection := mv.response.Rows[row].Entries[api.GetPrimary(mv.response.Columns)]
-	requestBytes, err := proto.Marshal(&mv.request)
+	requestBytes, err := proto.Marshal(mv.request)
 	if err != nil {
 		return err
 	}
@@ -1306,9 +1710,64 @@ func (mv *MainView) runMacro(ch rune, description string) error {
 		PrimaryColumn:    mv.response.Columns[col].GetName(),
 		EncodedRequest:   hex.EncodeToString(requestBytes),
 	}
+	if len(mv.marked) > 0 {
+		currentView.SelectedPKs = mv.markedPKs()
+	}
+	actionsIndex := api.IndexOfField(resp.GetColumns(), MacroActionsCol)
+	if actionsIndex != -1 && entries[actionsIndex].GetFormatted() != "" {
+		// built-in macros are interpreted directly so there's nothing to
+		// run in the background
+		actions := entries[actionsIndex].GetFormatted()
+		if len(mv.marked) == 0 {
+			output, err := api.RunBuiltinMacro(ctx, mv.dbms, actions, currentView)
+			return mv.finishMacro(actions, currentView, output, err)
+		}
+		// the actions are run once for each marked row as though it were
+		// selected and the view is left as the last run leaves it. The
+		// actions may change any table so they can't be undone if one run
+		// fails and instead the rows they ran for are reported.
+		var output *api.MacroInterface
+		pks := mv.selectedPKs()
+		for i, pk := range pks {
+			rowView := currentView
+			rowView.PrimarySelection = pk
+			var err error
+			output, err = api.RunBuiltinMacro(ctx, mv.dbms, actions, rowView)
+			if err != nil {
+				err = fmt.Errorf("Ran %s for [%s] but not for [%s] since it failed for %s: %w", actions, strings.Join(pks[:i], ", "), strings.Join(pks[i:], ", "), pk, err)
+				return errors.Join(err, mv.updateTableViewContents(false))
+			}
+		}
+		output.CurrentView.PrimarySelection = currentView.PrimarySelection
+		return mv.finishMacro(actions, currentView, output, nil)
+	}
 
 	path := entries[locIndex].GetFormatted()
-	output, err := api.RunMacro(ctx, mv.dbms, path, currentView, v2)
+	macroCtx, cancel := context.WithTimeout(ctx, timeout)
+	mv.macroCancel = cancel
+	mv.alert = fmt.Sprintf("Running macro %s (Ctrl-C to cancel)", path)
+	mv.switchMode(MainViewModeAlert)
+	// the macro runs in the background so that the UI can still process
+	// Ctrl-C to cancel it
+	go func() {
+		output, err := api.RunMacro(macroCtx, mv.dbms, path, currentView, protocol)
+		mv.gui.Update(func(g *gocui.Gui) error {
+			cancel()
+			mv.macroCancel = nil
+			err := mv.finishMacro(path, currentView, output, err)
+			if err != nil {
+				mv.showError(err)
+			} else {
+				mv.switchMode(MainViewModeTable)
+			}
+			return nil
+		})
+	}()
+	return nil
+}
+
+// finishMacro updates the view to the one returned by a macro
+func (mv *MainView) finishMacro(path string, currentView api.MacroCurrentView, output *api.MacroInterface, err error) error {
 	if err != nil {
 		return err
 	}
@@ -1320,13 +1779,14 @@ func (mv *MainView) runMacro(ch rune, description string) error {
 	}
 	if !tableSwitch {
 		mv.request = request
+		mv.selectedPK = output.CurrentView.PrimarySelection
 	}
 	if currentView.EncodedRequest != output.CurrentView.EncodedRequest {
 		requestBytes, err := hex.DecodeString(output.CurrentView.EncodedRequest)
 		if err != nil {
 			return err
 		}
-		err = proto.Unmarshal(requestBytes, &mv.request)
+		err = proto.Unmarshal(requestBytes, mv.request)
 		if err != nil {
 			return err
 		}
@@ -1363,15 +1823,45 @@ func (mv *MainView) runMacro(ch rune, description string) error {
 	if err != nil {
 		return fmt.Errorf("Could not update table view after macro: %s", err)
 	}
-	if description != "" {
-		// TODO this is a bit hacky - if we are searching by description
-		// then the user is prompted for a macro and so we can't show this
-		// error text and just return nil
-		return nil
-	}
 	return fmt.Errorf("Ran macro %s", path)
 }
 
+// MacroRunning returns whether a macro is running
+func (mv *MainView) MacroRunning() bool {
+	return mv.macroCancel != nil
+}
+
+// CancelMacro cancels the running macro if there is one and returns whether
+// there was
+func (mv *MainView) CancelMacro() bool {
+	if !mv.MacroRunning() {
+		return false
+	}
+	mv.macroCancel()
+	return true
+}
+
+// tailLines returns the last n lines of s
+func tailLines(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if n > 0 && len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// showError shows the error in the alert view along with the log of a macro
+// if the error is from a macro
+func (mv *MainView) showError(err error) {
+	mv.alert = err.Error()
+	mv.alertLog = ""
+	var macroErr *api.MacroError
+	if errors.As(err, &macroErr) {
+		mv.alertLog = macroErr.Log
+	}
+	mv.switchMode(MainViewModeAlert)
+}
+
 func (mv *MainView) SelectedEntry() (int, int) {
 	row, col := mv.TableView.PrimarySelection()
 	return row, mv.getColumnIndices()[col]
@@ -1396,7 +1886,7 @@ func (mv *MainView) SetSelectedPK(pk string) {
 }
 
 func (mv *MainView) LoadQuery(req *jqlpb.ListRowsRequest) error {
-	mv.request = *req
+	mv.request = proto.Clone(req).(*jqlpb.ListRowsRequest)
 	if len(mv.request.Conditions) == 0 {
 		mv.request.Conditions = []*jqlpb.Condition{{}}
 	}
@@ -1406,6 +1896,60 @@ func (mv *MainView) LoadQuery(req *jqlpb.ListRowsRequest) error {
 	return mv.updateTableViewContents(true)
 }
 
+// LoadView shows the request of a saved view with its column layout
+func (mv *MainView) LoadView(view *api.View) error {
+	layout, err := parseLayout(view.Layout)
+	if err != nil {
+		return err
+	}
+	mv.clearMarks()
+	mv.layout = layout
+	mv.layoutTable = view.Table
+	mv.layoutView = view.Name
+	if layout == nil {
+		// views without a layout use that of their table
+		mv.layoutTable = ""
+		mv.layoutView = ""
+	}
+	return mv.LoadQuery(view.Request)
+}
+
+// openView loads the saved view with the given name
+func (mv *MainView) openView(name string) error {
+	view, err := api.GetView(ctx, mv.dbms, name)
+	if err != nil {
+		return err
+	}
+	return mv.LoadView(view)
+}
+
+// saveView saves the current request and column layout as a named view
+func (mv *MainView) saveView(name string) error {
+	err := api.SaveView(ctx, mv.dbms, &api.View{
+		Name:    name,
+		Table:   mv.request.Table,
+		Request: mv.request,
+		Layout:  formatLayout(mv.layout),
+	})
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("Saved view %s", name)
+}
+
+func (mv *MainView) promptForView() error {
+	names, err := api.ListViews(ctx, mv.dbms)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("No saved views. Save one with :save-view NAME")
+	}
+	mv.selectOptions = names
+	mv.switchMode(MainViewModePromptForView)
+	return nil
+}
+
 func (mv *MainView) AddFilters(filters []cli.Filter) error {
 	for _, filter := range filters {
 		mv.request.Conditions[0].Requires = append(mv.request.Conditions[0].Requires, &jqlpb.Filter{
@@ -1460,3 +2004,204 @@ func sortedKeys(m map[string]int64) []string {
 	})
 	return sorted
 }
+
+// filteredPKs returns the pks of every row matching the current request, in
+// order, across all pages
+func (mv *MainView) filteredPKs() ([]string, error) {
+	requestNoLimit := &jqlpb.ListRowsRequest{
+		Table:      mv.request.Table,
+		Conditions: mv.request.Conditions,
+		OrderBy:    mv.request.OrderBy,
+		Dec:        mv.request.Dec,
+		GroupBy:    mv.request.GroupBy,
+	}
+	response, err := mv.dbms.ListRows(ctx, requestNoLimit)
+	if err != nil {
+		return nil, err
+	}
+	pks := []string{}
+	for _, row := range response.Rows {
+		pks = append(pks, row.Entries[api.GetPrimary(response.Columns)].Formatted)
+	}
+	return pks, nil
+}
+
+// toggleMark marks or unmarks the selected row for bulk operations
+func (mv *MainView) toggleMark() error {
+	pk := mv.GetPrimarySelection()
+	if pk == "" {
+		return nil
+	}
+	mv.startMarking()
+	if mv.marked[pk] {
+		delete(mv.marked, pk)
+	} else {
+		mv.marked[pk] = true
+	}
+	mv.markAnchor = pk
+	return mv.updateTableViewContents(false)
+}
+
+// markRange marks every row between the row last marked and the selected row
+// in the current ordering, like a visual-line selection in vim
+func (mv *MainView) markRange() error {
+	if mv.markAnchor == "" {
+		return mv.toggleMark()
+	}
+	pk := mv.GetPrimarySelection()
+	pks, err := mv.filteredPKs()
+	if err != nil {
+		return err
+	}
+	start, end := slices.Index(pks, mv.markAnchor), slices.Index(pks, pk)
+	if start == -1 || end == -1 {
+		// the anchor was filtered out of the view so start over from here
+		return mv.toggleMark()
+	}
+	if start > end {
+		start, end = end, start
+	}
+	mv.startMarking()
+	for _, marked := range pks[start : end+1] {
+		mv.marked[marked] = true
+	}
+	mv.markAnchor = pk
+	return mv.updateTableViewContents(false)
+}
+
+// markAll marks every row matching the current filters, including those on
+// other pages
+func (mv *MainView) markAll() error {
+	pks, err := mv.filteredPKs()
+	if err != nil {
+		return err
+	}
+	mv.clearMarks()
+	mv.startMarking()
+	for _, pk := range pks {
+		mv.marked[pk] = true
+	}
+	return mv.updateTableViewContents(false)
+}
+
+// startMarking prepares to mark rows under the current conditions if no
+// rows are marked yet
+func (mv *MainView) startMarking() {
+	if len(mv.marked) > 0 {
+		return
+	}
+	mv.marked = map[string]bool{}
+	mv.markConditions = []*jqlpb.Condition{}
+	for _, condition := range mv.request.Conditions {
+		mv.markConditions = append(mv.markConditions, proto.Clone(condition).(*jqlpb.Condition))
+	}
+}
+
+// clearMarks unmarks all rows and returns whether any were marked
+func (mv *MainView) clearMarks() bool {
+	cleared := len(mv.marked) > 0
+	mv.marked = nil
+	mv.markAnchor = ""
+	mv.markConditions = nil
+	return cleared
+}
+
+// conditionsEqual returns true iff both lists have the same conditions
+func conditionsEqual(a, b []*jqlpb.Condition) bool {
+	return slices.EqualFunc(a, b, func(x, y *jqlpb.Condition) bool { return proto.Equal(x, y) })
+}
+
+// markedPKs returns the pks of the marked rows in sorted order
+func (mv *MainView) markedPKs() []string {
+	pks := []string{}
+	for pk := range mv.marked {
+		pks = append(pks, pk)
+	}
+	sort.Strings(pks)
+	return pks
+}
+
+// selectedPKs returns the pks of the marked rows in sorted order or, if no
+// rows are marked, the pk of the selected row
+func (mv *MainView) selectedPKs() []string {
+	pks := mv.markedPKs()
+	if len(pks) == 0 {
+		pk := mv.GetPrimarySelection()
+		if pk == "" {
+			return nil
+		}
+		pks = []string{pk}
+	}
+	return pks
+}
+
+// forEachSelected calls f with the pk of every selected row in order to
+// change it. If f fails for one row then the rows before it are restored to
+// how they were beforehand so that either every row is changed or none are.
+func (mv *MainView) forEachSelected(f func(pk string) error) error {
+	pks := mv.selectedPKs()
+	if len(pks) == 1 {
+		return f(pks[0])
+	}
+	saved := make([]*jqlpb.GetRowResponse, len(pks))
+	for i, pk := range pks {
+		resp, err := mv.dbms.GetRow(ctx, &jqlpb.GetRowRequest{
+			Table: mv.request.Table,
+			Pk:    pk,
+		})
+		if err != nil {
+			return err
+		}
+		saved[i] = resp
+	}
+	for i, pk := range pks {
+		err := f(pk)
+		if err == nil {
+			continue
+		}
+		restoreErr := mv.restoreRows(saved[:i])
+		if restoreErr != nil {
+			return fmt.Errorf("Failed to change %s: %w; restoring the %d rows changed before it also failed: %w", pk, err, i, restoreErr)
+		}
+		return fmt.Errorf("Changed none of the %d rows since %s failed: %w", len(pks), pk, err)
+	}
+	return nil
+}
+
+// restoreRows writes back each of the given rows in reverse order as it was
+// when it was read, re-inserting it if it's since been deleted. The pks of
+// rows that couldn't be restored are included in the returned error.
+func (mv *MainView) restoreRows(rows []*jqlpb.GetRowResponse) error {
+	failed := []string{}
+	errs := []error{}
+	for i := len(rows) - 1; i >= 0; i-- {
+		primary := api.GetPrimary(rows[i].Columns)
+		pk := rows[i].Row.Entries[primary].Formatted
+		fields := map[string]string{}
+		for j, entry := range rows[i].Row.Entries {
+			if j == primary {
+				continue
+			}
+			fields[rows[i].Columns[j].Name] = entry.Formatted
+		}
+		// an upsert leaves an existing row as is so rows that still exist
+		// are updated instead
+		_, err := mv.dbms.GetRow(ctx, &jqlpb.GetRowRequest{Table: rows[i].Table, Pk: pk})
+		exists := err == nil
+		_, err = mv.dbms.WriteRow(ctx, &jqlpb.WriteRowRequest{
+			Table:      rows[i].Table,
+			Pk:         pk,
+			Fields:     fields,
+			UpdateOnly: exists,
+			InsertOnly: !exists,
+		})
+		if err != nil {
+			failed = append(failed, pk)
+			errs = append(errs, err)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%s remain changed: %w", strings.Join(failed, ", "), errors.Join(errs...))
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ulmenhaus/env/img/jql/api"
+	"github.com/ulmenhaus/env/img/jql/osm"
+	"github.com/ulmenhaus/env/proto/jql/jqlpb"
+)
+
+func TestForEachSelected(t *testing.T) {
+	cases := []struct {
+		name     string
+		marked   []string
+		amount   int32
+		expected map[string]string
+		err      bool
+	}{
+		{
+			name:     "every row changed",
+			marked:   []string{"a", "c"},
+			amount:   2,
+			expected: map[string]string{"a": "7", "b": "12", "c": "5"},
+		},
+		{
+			name:     "failure restores earlier rows",
+			marked:   []string{"a", "b", "c"},
+			amount:   2,
+			expected: map[string]string{"a": "5", "b": "12", "c": "3"},
+			err:      true,
+		},
+		{
+			name:     "failure on the first row",
+			marked:   []string{"b", "c"},
+			amount:   2,
+			expected: map[string]string{"a": "5", "b": "12", "c": "3"},
+			err:      true,
+		},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			mapper, err := osm.NewObjectStoreMapper("test.json")
+			require.NoError(t, err)
+			require.NoError(t, mapper.LoadSnapshot(strings.NewReader(`{
+				"_schemata": {
+					"tasks.Name": {"type": "string", "primary": true},
+					"tasks.Points": {"type": "int", "features": {"min": 1, "max": 13}}
+				},
+				"tasks": {"a": {"Points": 5}, "b": {"Points": 12}, "c": {"Points": 3}}
+			}`)))
+			dbms, err := api.NewLocalDBMS(mapper, "test.json")
+			require.NoError(t, err)
+			mv := &MainView{
+				dbms:    dbms,
+				request: &jqlpb.ListRowsRequest{Table: "tasks"},
+				marked:  map[string]bool{},
+			}
+			for _, pk := range tc.marked {
+				mv.marked[pk] = true
+			}
+			err = mv.forEachSelected(func(pk string) error {
+				_, err := dbms.IncrementEntry(ctx, &jqlpb.IncrementEntryRequest{
+					Table:  "tasks",
+					Pk:     pk,
+					Column: "Points",
+					Amount: tc.amount,
+				})
+				return err
+			})
+			if tc.err {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "Changed none")
+			} else {
+				require.NoError(t, err)
+			}
+			resp, err := dbms.ListRows(ctx, &jqlpb.ListRowsRequest{Table: "tasks"})
+			require.NoError(t, err)
+			points := map[string]string{}
+			for _, row := range resp.Rows {
+				points[row.Entries[0].Formatted] = row.Entries[1].Formatted
+			}
+			require.Equal(t, tc.expected, points)
+		})
+	}
+}
@@ -0,0 +1,283 @@
+package virtual
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ulmenhaus/env/img/jql/api"
+	"github.com/ulmenhaus/env/img/jql/osm"
+	"github.com/ulmenhaus/env/img/jql/storage"
+	"github.com/ulmenhaus/env/proto/jql/jqlpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// schemataTableName is the name of the table containing schemata in a snapshot
+	schemataTableName = "_schemata"
+	// materializedTable is the name under which a virtual table is loaded
+	// into an in-memory database since virtual table names contain dots
+	materializedTable = "virtual"
+)
+
+// A Server serves registered virtual tables as a jqlpb.JQLServer, e.g. to be
+// used as the virtual gateway of a jql daemon
+type Server struct {
+	jqlpb.UnimplementedJQLServer
+
+	mu     sync.RWMutex
+	tables map[string]Table
+}
+
+// NewServer returns a server with no tables
+func NewServer() *Server {
+	return &Server{
+		tables: map[string]Table{},
+	}
+}
+
+// Register adds a table to the server. Tables served through a daemon's
+// virtual gateway must be named with the "vt." prefix.
+func (s *Server) Register(name string, t Table) error {
+	if _, err := primaryColumn(t); err != nil {
+		return fmt.Errorf("invalid table %s: %w", name, err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.tables[name]; ok {
+		return fmt.Errorf("table already registered: %s", name)
+	}
+	s.tables[name] = t
+	return nil
+}
+
+func (s *Server) table(name string) (Table, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	t, ok := s.tables[name]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no such table: %s", name)
+	}
+	return t, nil
+}
+
+// materialize loads the given rows of the table into an in-memory database so
+// that queries can be answered by a LocalDBMS. The table is named
+// materializedTable in the database.
+func materialize(name string, t Table, rows []Row) (*api.LocalDBMS, error) {
+	primary, err := primaryColumn(t)
+	if err != nil {
+		return nil, err
+	}
+	schemata := storage.EncodedTable{}
+	for _, col := range t.Columns() {
+		schema := storage.EncodedEntry{"type": col.Type}
+		if col.Primary {
+			schema["primary"] = true
+		}
+		if col.Features != nil {
+			schema["features"] = col.Features
+		}
+		schemata[fmt.Sprintf("%s.%s", materializedTable, col.Name)] = schema
+	}
+	// round trip through JSON so that features have the same types as
+	// they would when loaded from storage
+	snapshot, err := json.Marshal(storage.EncodedDatabase{
+		schemataTableName: schemata,
+		materializedTable: storage.EncodedTable{},
+	})
+	if err != nil {
+		return nil, err
+	}
+	mapper, err := osm.NewObjectStoreMapper(materializedTable + ".json")
+	if err != nil {
+		return nil, err
+	}
+	if err := mapper.LoadSnapshot(bytes.NewReader(snapshot)); err != nil {
+		return nil, fmt.Errorf("invalid columns for %s: %w", name, err)
+	}
+	table := mapper.GetDB().Tables[materializedTable]
+	for _, row := range rows {
+		pk, ok := row[primary]
+		if !ok {
+			return nil, fmt.Errorf("row of %s is missing primary column %s", name, primary)
+		}
+		fields := map[string]string{}
+		for column, value := range row {
+			if column != primary {
+				fields[column] = value
+			}
+		}
+		if err := table.InsertWithFields(pk, fields); err != nil {
+			return nil, fmt.Errorf("invalid row %s of %s: %w", pk, name, err)
+		}
+	}
+	return api.NewLocalDBMS(mapper, "")
+}
+
+func (s *Server) ListTables(ctx context.Context, in *jqlpb.ListTablesRequest) (*jqlpb.ListTablesResponse, error) {
+	s.mu.RLock()
+	names := []string{}
+	for name := range s.tables {
+		names = append(names, name)
+	}
+	s.mu.RUnlock()
+	sort.Strings(names)
+	resp := &jqlpb.ListTablesResponse{}
+	for _, name := range names {
+		t, err := s.table(name)
+		if err != nil {
+			return nil, err
+		}
+		dbms, err := materialize(name, t, nil)
+		if err != nil {
+			return nil, err
+		}
+		tables, err := dbms.ListTables(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		for _, table := range tables.Tables {
+			table.Name = name
+			resp.Tables = append(resp.Tables, table)
+		}
+	}
+	return resp, nil
+}
+
+func (s *Server) ListRows(ctx context.Context, in *jqlpb.ListRowsRequest) (*jqlpb.ListRowsResponse, error) {
+	t, err := s.table(in.GetTable())
+	if err != nil {
+		return nil, err
+	}
+	rows, err := t.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dbms, err := materialize(in.GetTable(), t, rows)
+	if err != nil {
+		return nil, err
+	}
+	req := proto.Clone(in).(*jqlpb.ListRowsRequest)
+	req.Table = materializedTable
+	resp, err := dbms.ListRows(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Table = in.GetTable()
+	return resp, nil
+}
+
+// materializeRow loads just the requested row if the table is a Getter or all
+// rows otherwise
+func (s *Server) materializeRow(ctx context.Context, name, pk string) (*api.LocalDBMS, error) {
+	t, err := s.table(name)
+	if err != nil {
+		return nil, err
+	}
+	var rows []Row
+	if getter, ok := t.(Getter); ok {
+		row, err := getter.Get(ctx, pk)
+		if errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("no such pk '%s' in table '%s'", pk, name)
+		} else if err != nil {
+			return nil, err
+		}
+		rows = []Row{row}
+	} else {
+		rows, err = t.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return materialize(name, t, rows)
+}
+
+func (s *Server) GetRow(ctx context.Context, in *jqlpb.GetRowRequest) (*jqlpb.GetRowResponse, error) {
+	dbms, err := s.materializeRow(ctx, in.GetTable(), in.GetPk())
+	if err != nil {
+		return nil, err
+	}
+	resp, err := dbms.GetRow(ctx, &jqlpb.GetRowRequest{Table: materializedTable, Pk: in.GetPk()})
+	if err != nil {
+		return nil, fmt.Errorf("no such pk '%s' in table '%s'", in.GetPk(), in.GetTable())
+	}
+	resp.Table = in.GetTable()
+	return resp, nil
+}
+
+func (s *Server) WriteRow(ctx context.Context, in *jqlpb.WriteRowRequest) (*jqlpb.WriteRowResponse, error) {
+	t, err := s.table(in.GetTable())
+	if err != nil {
+		return nil, err
+	}
+	writer, ok := t.(Writer)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "%s does not support writes", in.GetTable())
+	}
+	if in.GetInsertOnly() {
+		_, err := s.GetRow(ctx, &jqlpb.GetRowRequest{Table: in.GetTable(), Pk: in.GetPk()})
+		if err == nil {
+			return nil, fmt.Errorf("Row already exists with pk '%s'", in.GetPk())
+		} else if !api.IsNotExistError(err) {
+			return nil, err
+		}
+	}
+	pk, err := writer.Write(ctx, in.GetPk(), in.GetFields(), in.GetUpdateOnly())
+	if err != nil {
+		return nil, err
+	}
+	return &jqlpb.WriteRowResponse{Pk: pk}, nil
+}
+
+func (s *Server) DeleteRow(ctx context.Context, in *jqlpb.DeleteRowRequest) (*jqlpb.DeleteRowResponse, error) {
+	t, err := s.table(in.GetTable())
+	if err != nil {
+		return nil, err
+	}
+	deleter, ok := t.(Deleter)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "%s does not support deletes", in.GetTable())
+	}
+	return &jqlpb.DeleteRowResponse{}, deleter.Delete(ctx, in.GetPk())
+}
+
+// IncrementEntry increments the entry in an in-memory copy of the row so that
+// it has the same semantics as for stored tables and then writes the result
+func (s *Server) IncrementEntry(ctx context.Context, in *jqlpb.IncrementEntryRequest) (*jqlpb.IncrementEntryResponse, error) {
+	t, err := s.table(in.GetTable())
+	if err != nil {
+		return nil, err
+	}
+	writer, ok := t.(Writer)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "%s does not support writes", in.GetTable())
+	}
+	dbms, err := s.materializeRow(ctx, in.GetTable(), in.GetPk())
+	if err != nil {
+		return nil, err
+	}
+	req := proto.Clone(in).(*jqlpb.IncrementEntryRequest)
+	req.Table = materializedTable
+	if _, err := dbms.IncrementEntry(ctx, req); err != nil {
+		return nil, err
+	}
+	row, err := dbms.GetRow(ctx, &jqlpb.GetRowRequest{Table: materializedTable, Pk: in.GetPk()})
+	if err != nil {
+		return nil, err
+	}
+	value := row.Row.Entries[api.IndexOfField(row.Columns, in.GetColumn())].Formatted
+	_, err = writer.Write(ctx, in.GetPk(), map[string]string{in.GetColumn(): value}, true)
+	return &jqlpb.IncrementEntryResponse{}, err
+}
+
+// Persist is a no-op since virtual tables are responsible for their own storage
+func (s *Server) Persist(ctx context.Context, in *jqlpb.PersistRequest) (*jqlpb.PersistResponse, error) {
+	return &jqlpb.PersistResponse{}, nil
+}
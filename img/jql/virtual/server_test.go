@@ -0,0 +1,176 @@
+package virtual
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ulmenhaus/env/img/jql/api"
+	"github.com/ulmenhaus/env/proto/jql/jqlpb"
+)
+
+// memTable is a writable virtual table backed by a map
+type memTable struct {
+	rows map[string]Row
+}
+
+func newMemTable() *memTable {
+	return &memTable{rows: map[string]Row{
+		"water plants": {"Name": "water plants", "Status": "Pending", "Snoozes": "2"},
+		"call mom":     {"Name": "call mom", "Status": "Active", "Snoozes": "0"},
+		"file taxes":   {"Name": "file taxes", "Status": "Pending", "Snoozes": "5"},
+	}}
+}
+
+func (m *memTable) Columns() []Column {
+	return []Column{
+		{Name: "Name", Type: "string", Primary: true},
+		{Name: "Status", Type: "enum", Features: map[string]interface{}{"values": "Pending, Active, Done"}},
+		{Name: "Snoozes", Type: "int"},
+	}
+}
+
+func (m *memTable) List(ctx context.Context) ([]Row, error) {
+	rows := []Row{}
+	for _, row := range m.rows {
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func (m *memTable) Write(ctx context.Context, pk string, fields map[string]string, updateOnly bool) (string, error) {
+	row, ok := m.rows[pk]
+	if !ok {
+		if updateOnly {
+			return "", fmt.Errorf("no such pk '%s'", pk)
+		}
+		row = Row{"Name": pk}
+		m.rows[pk] = row
+	}
+	for column, value := range fields {
+		row[column] = value
+	}
+	return pk, nil
+}
+
+func (m *memTable) Delete(ctx context.Context, pk string) error {
+	delete(m.rows, pk)
+	return nil
+}
+
+// readOnlyTable is a virtual table that only supports listing
+type readOnlyTable struct{ table *memTable }
+
+func (r readOnlyTable) Columns() []Column                       { return r.table.Columns() }
+func (r readOnlyTable) List(ctx context.Context) ([]Row, error) { return r.table.List(ctx) }
+
+func newTestServer(t *testing.T) (*Server, *memTable) {
+	server := NewServer()
+	table := newMemTable()
+	require.NoError(t, server.Register("vt.reminders", table))
+	require.NoError(t, server.Register("vt.static", readOnlyTable{newMemTable()}))
+	return server, table
+}
+
+func pks(resp *jqlpb.ListRowsResponse) []string {
+	primary := api.GetPrimary(resp.Columns)
+	pks := []string{}
+	for _, row := range resp.Rows {
+		pks = append(pks, row.Entries[primary].Formatted)
+	}
+	return pks
+}
+
+func TestListRows(t *testing.T) {
+	cases := []struct {
+		name     string
+		request  *jqlpb.ListRowsRequest
+		expected []string
+		total    uint32
+	}{
+		{
+			name:     "ordered",
+			request:  &jqlpb.ListRowsRequest{Table: "vt.reminders", OrderBy: "Name"},
+			expected: []string{"call mom", "file taxes", "water plants"},
+			total:    3,
+		},
+		{
+			name: "filtered",
+			request: &jqlpb.ListRowsRequest{
+				Table:   "vt.reminders",
+				OrderBy: "Snoozes",
+				Dec:     true,
+				Conditions: []*jqlpb.Condition{{Requires: []*jqlpb.Filter{{
+					Column: "Status",
+					Match:  &jqlpb.Filter_EqualMatch{EqualMatch: &jqlpb.EqualMatch{Value: "Pending"}},
+				}}}},
+			},
+			expected: []string{"file taxes", "water plants"},
+			total:    2,
+		},
+		{
+			name:     "paged",
+			request:  &jqlpb.ListRowsRequest{Table: "vt.reminders", OrderBy: "Snoozes", Offset: 1, Limit: 1},
+			expected: []string{"water plants"},
+			total:    3,
+		},
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			server, _ := newTestServer(t)
+			resp, err := server.ListRows(context.Background(), tc.request)
+			require.NoError(t, err)
+			require.Equal(t, tc.request.Table, resp.Table)
+			require.Equal(t, tc.expected, pks(resp))
+			require.Equal(t, tc.total, resp.Total)
+		})
+	}
+}
+
+func TestListTables(t *testing.T) {
+	server, _ := newTestServer(t)
+	resp, err := server.ListTables(context.Background(), &jqlpb.ListTablesRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Tables, 2)
+	require.Equal(t, "vt.reminders", resp.Tables[0].Name)
+	status := resp.Tables[0].Columns[api.IndexOfField(resp.Tables[0].Columns, "Status")]
+	require.Equal(t, jqlpb.EntryType_ENUM, status.Type)
+	require.Equal(t, []string{"Pending", "Active", "Done"}, status.Values)
+}
+
+func TestWrites(t *testing.T) {
+	ctx := context.Background()
+	server, table := newTestServer(t)
+
+	_, err := server.WriteRow(ctx, &jqlpb.WriteRowRequest{Table: "vt.reminders", Pk: "buy milk", Fields: map[string]string{"Status": "Active"}})
+	require.NoError(t, err)
+	row, err := server.GetRow(ctx, &jqlpb.GetRowRequest{Table: "vt.reminders", Pk: "buy milk"})
+	require.NoError(t, err)
+	require.Equal(t, "Active", row.Row.Entries[api.IndexOfField(row.Columns, "Status")].Formatted)
+
+	// increments follow the semantics of the column type
+	_, err = server.IncrementEntry(ctx, &jqlpb.IncrementEntryRequest{Table: "vt.reminders", Pk: "call mom", Column: "Status", Amount: 1})
+	require.NoError(t, err)
+	_, err = server.IncrementEntry(ctx, &jqlpb.IncrementEntryRequest{Table: "vt.reminders", Pk: "call mom", Column: "Snoozes", Amount: 3})
+	require.NoError(t, err)
+	require.Equal(t, "Done", table.rows["call mom"]["Status"])
+	require.Equal(t, "3", table.rows["call mom"]["Snoozes"])
+
+	// insert-only writes fail only for existing rows
+	_, err = server.WriteRow(ctx, &jqlpb.WriteRowRequest{Table: "vt.reminders", Pk: "pay rent", InsertOnly: true})
+	require.NoError(t, err)
+	require.Contains(t, table.rows, "pay rent")
+	_, err = server.WriteRow(ctx, &jqlpb.WriteRowRequest{Table: "vt.reminders", Pk: "pay rent", InsertOnly: true})
+	require.Error(t, err)
+
+	_, err = server.DeleteRow(ctx, &jqlpb.DeleteRowRequest{Table: "vt.reminders", Pk: "file taxes"})
+	require.NoError(t, err)
+	_, err = server.GetRow(ctx, &jqlpb.GetRowRequest{Table: "vt.reminders", Pk: "file taxes"})
+	require.Error(t, err)
+
+	_, err = server.WriteRow(ctx, &jqlpb.WriteRowRequest{Table: "vt.static", Pk: "buy milk"})
+	require.Error(t, err)
+	_, err = server.DeleteRow(ctx, &jqlpb.DeleteRowRequest{Table: "vt.static", Pk: "call mom"})
+	require.Error(t, err)
+}
@@ -0,0 +1,77 @@
+// Package virtual provides a way to implement virtual tables in Go. A virtual
+// table only has to list its rows and, optionally, get, write and delete
+// them. The Server takes care of filtering, ordering, paging and grouping the
+// same way the jql daemon does for stored tables by loading the rows into an
+// in-memory database for each request.
+package virtual
+
+import (
+	"context"
+	"fmt"
+)
+
+// A Column describes a column of a virtual table
+type Column struct {
+	Name string
+	// Type is the schema type of the column, e.g. "string", "int", "date",
+	// "enum", or "foreign.tasks"
+	Type string
+	// Primary is true iff the column is the table's primary key. Every
+	// table must have exactly one primary column.
+	Primary bool
+	// Features are the type-specific features of the column, e.g. the
+	// values of an enum
+	Features map[string]interface{}
+}
+
+// A Row maps column names to the formatted values of a row, i.e. values in
+// the form a user would enter them. The primary key must be included.
+type Row map[string]string
+
+// A Table is a virtual table. Implementations may additionally implement
+// Getter, Writer and Deleter.
+type Table interface {
+	// Columns returns the columns of the table
+	Columns() []Column
+	// List returns every row in the table
+	List(ctx context.Context) ([]Row, error)
+}
+
+// A Getter is a Table that can fetch a single row more efficiently than
+// listing every row. Get should return ErrNotFound if there is no such row.
+type Getter interface {
+	Get(ctx context.Context, pk string) (Row, error)
+}
+
+// A Writer is a Table that supports writes. When updateOnly is false the
+// write is an upsert. If pk is empty the table may generate one. Write
+// returns the pk of the written row.
+type Writer interface {
+	Write(ctx context.Context, pk string, fields map[string]string, updateOnly bool) (string, error)
+}
+
+// A Deleter is a Table that supports deleting rows
+type Deleter interface {
+	Delete(ctx context.Context, pk string) error
+}
+
+// ErrNotFound is returned by a Getter when the row does not exist
+var ErrNotFound = fmt.Errorf("no such pk")
+
+// primaryColumn returns the name of the table's primary column
+func primaryColumn(t Table) (string, error) {
+	primary := ""
+	for _, col := range t.Columns() {
+		if !col.Primary {
+			continue
+		}
+		if primary != "" {
+			return "", fmt.Errorf("multiple primary columns: %s and %s", primary, col.Name)
+		}
+		primary = col.Name
+	}
+	if primary == "" {
+		return "", fmt.Errorf("no primary column")
+	}
+	return primary, nil
+}
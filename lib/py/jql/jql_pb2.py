@@ -24,7 +24,7 @@ _sym_db = _symbol_database.Default()
 
 
 
-DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\rjql/jql.proto\x12\x03jql\"\x13\n\x11ListTablesRequest\"7\n\tTableMeta\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x1c\n\x07\x63olumns\x18\x02 \x03(\x0b\x32\x0b.jql.Column\"4\n\x12ListTablesResponse\x12\x1e\n\x06tables\x18\x01 \x03(\x0b\x32\x0e.jql.TableMeta\"\x1b\n\nEqualMatch\x12\r\n\x05value\x18\x01 \x01(\t\"\x1e\n\rLessThanMatch\x12\r\n\x05value\x18\x01 \x01(\t\"!\n\x10GreaterThanMatch\x12\r\n\x05value\x18\x01 \x01(\t\"\x19\n\x07InMatch\x12\x0e\n\x06values\x18\x01 \x03(\t\"-\n\rContainsMatch\x12\r\n\x05\x65xact\x18\x01 \x01(\x08\x12\r\n\x05value\x18\x02 \x01(\t\"-\n\x0bPathToMatch\x12\r\n\x05value\x18\x01 \x01(\t\x12\x0f\n\x07reverse\x18\x02 \x01(\x08\"\xba\x02\n\x06\x46ilter\x12\x0f\n\x07negated\x18\x01 \x01(\x08\x12\x0e\n\x06\x63olumn\x18\x02 \x01(\t\x12&\n\x0b\x65qual_match\x18\x03 \x01(\x0b\x32\x0f.jql.EqualMatchH\x00\x12-\n\x0fless_than_match\x18\x04 \x01(\x0b\x32\x12.jql.LessThanMatchH\x00\x12\x34\n\x13greather_than_match\x18\x05 \x01(\x0b\x32\x15.jql.GreaterThanMatchH\x00\x12 \n\x08in_match\x18\x06 \x01(\x0b\x32\x0c.jql.InMatchH\x00\x12,\n\x0e\x63ontains_match\x18\x07 \x01(\x0b\x32\x12.jql.ContainsMatchH\x00\x12)\n\rpath_to_match\x18\x08 \x01(\x0b\x32\x10.jql.PathToMatchH\x00\x42\x07\n\x05match\"*\n\tCondition\x12\x1d\n\x08requires\x18\x01 \x03(\x0b\x32\x0b.jql.Filter\"\xa2\x01\n\x0fListRowsRequest\x12\r\n\x05table\x18\x01 \x01(\t\x12\"\n\nconditions\x18\x02 \x03(\x0b\x32\x0e.jql.Condition\x12\x10\n\x08order_by\x18\x03 \x01(\t\x12\x0b\n\x03\x64\x65\x63\x18\x04 \x01(\x08\x12\x0e\n\x06offset\x18\x05 \x01(\r\x12\r\n\x05limit\x18\x06 \x01(\r\x12\x1e\n\x08group_by\x18\x07 \x01(\x0b\x32\x0c.jql.GroupBy\"\x97\x01\n\x06\x43olumn\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x1c\n\x04type\x18\x02 \x01(\x0e\x32\x0e.jql.EntryType\x12\x12\n\nmax_length\x18\x03 \x01(\x05\x12\x0f\n\x07primary\x18\x04 \x01(\x08\x12\x15\n\rforeign_table\x18\x05 \x01(\t\x12\x0e\n\x06values\x18\x06 \x03(\t\x12\x15\n\rdisplay_value\x18\x07 \x01(\t\"S\n\x05\x45ntry\x12\x11\n\tformatted\x18\x01 \x01(\t\x12\x15\n\rdisplay_value\x18\x02 \x01(\t\x12\x0c\n\x04link\x18\x03 \x01(\t\x12\x12\n\nlocal_link\x18\x04 \x01(\t\"\"\n\x03Row\x12\x1b\n\x07\x65ntries\x18\x01 \x03(\x0b\x32\n.jql.Entry\"\x95\x01\n\x10ListRowsResponse\x12\r\n\x05table\x18\x01 \x01(\t\x12\x1c\n\x07\x63olumns\x18\x02 \x03(\x0b\x32\x0b.jql.Column\x12\x16\n\x04rows\x18\x03 \x03(\x0b\x32\x08.jql.Row\x12\r\n\x05total\x18\x04 \x01(\r\x12\x0b\n\x03\x61ll\x18\x05 \x01(\r\x12 \n\tgroupings\x18\x06 \x03(\x0b\x32\r.jql.Grouping\"*\n\rGetRowRequest\x12\r\n\x05table\x18\x01 \x01(\t\x12\n\n\x02pk\x18\x02 \x01(\t\"T\n\x0eGetRowResponse\x12\r\n\x05table\x18\x01 \x01(\t\x12\x1c\n\x07\x63olumns\x18\x02 \x03(\x0b\x32\x0b.jql.Column\x12\x15\n\x03row\x18\x03 \x01(\x0b\x32\x08.jql.Row\"\xb7\x01\n\x0fWriteRowRequest\x12\r\n\x05table\x18\x01 \x01(\t\x12\n\n\x02pk\x18\x02 \x01(\t\x12\x30\n\x06\x66ields\x18\x03 \x03(\x0b\x32 .jql.WriteRowRequest.FieldsEntry\x12\x13\n\x0bupdate_only\x18\x04 \x01(\x08\x12\x13\n\x0binsert_only\x18\x05 \x01(\x08\x1a-\n\x0b\x46ieldsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\t:\x02\x38\x01\"\x12\n\x10WriteRowResponse\"R\n\x15IncrementEntryRequest\x12\r\n\x05table\x18\x01 \x01(\t\x12\n\n\x02pk\x18\x02 \x01(\t\x12\x0e\n\x06\x63olumn\x18\x03 \x01(\t\x12\x0e\n\x06\x61mount\x18\x04 \x01(\x05\"\x18\n\x16IncrementEntryResponse\"-\n\x10\x44\x65leteRowRequest\x12\r\n\x05table\x18\x01 \x01(\t\x12\n\n\x02pk\x18\x02 \x01(\t\"\x13\n\x11\x44\x65leteRowResponse\"\x10\n\x0ePersistRequest\"\x11\n\x0fPersistResponse\"\x14\n\x12GetSnapshotRequest\"\'\n\x13GetSnapshotResponse\x12\x10\n\x08snapshot\x18\x01 \x01(\x0c\"\'\n\x13LoadSnapshotRequest\x12\x10\n\x08snapshot\x18\x01 \x01(\x0c\"\x16\n\x14LoadSnapshotResponse\"4\n\x11RequestedGrouping\x12\r\n\x05\x66ield\x18\x01 \x01(\t\x12\x10\n\x08selected\x18\x02 \x01(\t\"4\n\x07GroupBy\x12)\n\tgroupings\x18\x01 \x03(\x0b\x32\x16.jql.RequestedGrouping\"\x85\x01\n\x08Grouping\x12\r\n\x05\x66ield\x18\x01 \x01(\t\x12)\n\x06values\x18\x02 \x03(\x0b\x32\x19.jql.Grouping.ValuesEntry\x12\x10\n\x08selected\x18\x03 \x01(\t\x1a-\n\x0bValuesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x03:\x02\x38\x01*\x80\x01\n\tEntryType\x12\n\n\x06STRING\x10\x00\x12\x07\n\x03INT\x10\x01\x12\x08\n\x04\x44\x41TE\x10\x02\x12\x08\n\x04\x45NUM\x10\x03\x12\x06\n\x02ID\x10\x04\x12\x08\n\x04TIME\x10\x05\x12\x0c\n\x08MONEYAMT\x10\x06\x12\x0b\n\x07\x46OREIGN\x10\x07\x12\x0c\n\x08\x46OREIGNS\x10\x08\x12\x0f\n\x0bPOLYFOREIGN\x10\t2\xad\x04\n\x03JQL\x12=\n\nListTables\x12\x16.jql.ListTablesRequest\x1a\x17.jql.ListTablesResponse\x12\x37\n\x08ListRows\x12\x14.jql.ListRowsRequest\x1a\x15.jql.ListRowsResponse\x12\x31\n\x06GetRow\x12\x12.jql.GetRowRequest\x1a\x13.jql.GetRowResponse\x12\x37\n\x08WriteRow\x12\x14.jql.WriteRowRequest\x1a\x15.jql.WriteRowResponse\x12:\n\tDeleteRow\x12\x15.jql.DeleteRowRequest\x1a\x16.jql.DeleteRowResponse\x12I\n\x0eIncrementEntry\x12\x1a.jql.IncrementEntryRequest\x1a\x1b.jql.IncrementEntryResponse\x12\x34\n\x07Persist\x12\x13.jql.PersistRequest\x1a\x14.jql.PersistResponse\x12@\n\x0bGetSnapshot\x12\x17.jql.GetSnapshotRequest\x1a\x18.jql.GetSnapshotResponse\x12\x43\n\x0cLoadSnapshot\x12\x18.jql.LoadSnapshotRequest\x1a\x19.jql.LoadSnapshotResponseB\x0bZ\tjql/jqlpbb\x06proto3')
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\rjql/jql.proto\x12\x03jql\"\x13\n\x11ListTablesRequest\"7\n\tTableMeta\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x1c\n\x07\x63olumns\x18\x02 \x03(\x0b\x32\x0b.jql.Column\"4\n\x12ListTablesResponse\x12\x1e\n\x06tables\x18\x01 \x03(\x0b\x32\x0e.jql.TableMeta\"\x1b\n\nEqualMatch\x12\r\n\x05value\x18\x01 \x01(\t\"\x1e\n\rLessThanMatch\x12\r\n\x05value\x18\x01 \x01(\t\"!\n\x10GreaterThanMatch\x12\r\n\x05value\x18\x01 \x01(\t\"\x19\n\x07InMatch\x12\x0e\n\x06values\x18\x01 \x03(\t\"-\n\rContainsMatch\x12\r\n\x05\x65xact\x18\x01 \x01(\x08\x12\r\n\x05value\x18\x02 \x01(\t\"-\n\x0bPathToMatch\x12\r\n\x05value\x18\x01 \x01(\t\x12\x0f\n\x07reverse\x18\x02 \x01(\x08\"\x1b\n\nRegexMatch\x12\r\n\x05value\x18\x01 \x01(\t\"\x1c\n\x0bPrefixMatch\x12\r\n\x05value\x18\x01 \x01(\t\"\x8c\x03\n\x06\x46ilter\x12\x0f\n\x07negated\x18\x01 \x01(\x08\x12\x0e\n\x06\x63olumn\x18\x02 \x01(\t\x12&\n\x0b\x65qual_match\x18\x03 \x01(\x0b\x32\x0f.jql.EqualMatchH\x00\x12-\n\x0fless_than_match\x18\x04 \x01(\x0b\x32\x12.jql.LessThanMatchH\x00\x12\x34\n\x13greather_than_match\x18\x05 \x01(\x0b\x32\x15.jql.GreaterThanMatchH\x00\x12 \n\x08in_match\x18\x06 \x01(\x0b\x32\x0c.jql.InMatchH\x00\x12,\n\x0e\x63ontains_match\x18\x07 \x01(\x0b\x32\x12.jql.ContainsMatchH\x00\x12)\n\rpath_to_match\x18\x08 \x01(\x0b\x32\x10.jql.PathToMatchH\x00\x12&\n\x0bregex_match\x18\t \x01(\x0b\x32\x0f.jql.RegexMatchH\x00\x12(\n\x0cprefix_match\x18\n \x01(\x0b\x32\x10.jql.PrefixMatchH\x00\x42\x07\n\x05match\"*\n\tCondition\x12\x1d\n\x08requires\x18\x01 \x03(\x0b\x32\x0b.jql.Filter\"\xa2\x01\n\x0fListRowsRequest\x12\r\n\x05table\x18\x01 \x01(\t\x12\"\n\nconditions\x18\x02 \x03(\x0b\x32\x0e.jql.Condition\x12\x10\n\x08order_by\x18\x03 \x01(\t\x12\x0b\n\x03\x64\x65\x63\x18\x04 \x01(\x08\x12\x0e\n\x06offset\x18\x05 \x01(\r\x12\r\n\x05limit\x18\x06 \x01(\r\x12\x1e\n\x08group_by\x18\x07 \x01(\x0b\x32\x0c.jql.GroupBy\"\xc1\x01\n\x06\x43olumn\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x1c\n\x04type\x18\x02 \x01(\x0e\x32\x0e.jql.EntryType\x12\x12\n\nmax_length\x18\x03 \x01(\x05\x12\x0f\n\x07primary\x18\x04 \x01(\x08\x12\x15\n\rforeign_table\x18\x05 \x01(\t\x12\x0e\n\x06values\x18\x06 \x03(\t\x12\x15\n\rdisplay_value\x18\x07 \x01(\t\x12\x16\n\x0e\x66oreign_tables\x18\x08 \x03(\t\x12\x10\n\x08timezone\x18\t \x01(\t\"S\n\x05\x45ntry\x12\x11\n\tformatted\x18\x01 \x01(\t\x12\x15\n\rdisplay_value\x18\x02 \x01(\t\x12\x0c\n\x04link\x18\x03 \x01(\t\x12\x12\n\nlocal_link\x18\x04 \x01(\t\"\"\n\x03Row\x12\x1b\n\x07\x65ntries\x18\x01 \x03(\x0b\x32\n.jql.Entry\"\x95\x01\n\x10ListRowsResponse\x12\r\n\x05table\x18\x01 \x01(\t\x12\x1c\n\x07\x63olumns\x18\x02 \x03(\x0b\x32\x0b.jql.Column\x12\x16\n\x04rows\x18\x03 \x03(\x0b\x32\x08.jql.Row\x12\r\n\x05total\x18\x04 \x01(\r\x12\x0b\n\x03\x61ll\x18\x05 \x01(\r\x12 \n\tgroupings\x18\x06 \x03(\x0b\x32\r.jql.Grouping\"*\n\rGetRowRequest\x12\r\n\x05table\x18\x01 \x01(\t\x12\n\n\x02pk\x18\x02 \x01(\t\"T\n\x0eGetRowResponse\x12\r\n\x05table\x18\x01 \x01(\t\x12\x1c\n\x07\x63olumns\x18\x02 \x03(\x0b\x32\x0b.jql.Column\x12\x15\n\x03row\x18\x03 \x01(\x0b\x32\x08.jql.Row\"\xb7\x01\n\x0fWriteRowRequest\x12\r\n\x05table\x18\x01 \x01(\t\x12\n\n\x02pk\x18\x02 \x01(\t\x12\x30\n\x06\x66ields\x18\x03 \x03(\x0b\x32 .jql.WriteRowRequest.FieldsEntry\x12\x13\n\x0bupdate_only\x18\x04 \x01(\x08\x12\x13\n\x0binsert_only\x18\x05 \x01(\x08\x1a-\n\x0b\x46ieldsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\t:\x02\x38\x01\"\x1e\n\x10WriteRowResponse\x12\n\n\x02pk\x18\x01 \x01(\t\"R\n\x15IncrementEntryRequest\x12\r\n\x05table\x18\x01 \x01(\t\x12\n\n\x02pk\x18\x02 \x01(\t\x12\x0e\n\x06\x63olumn\x18\x03 \x01(\t\x12\x0e\n\x06\x61mount\x18\x04 \x01(\x05\"\x18\n\x16IncrementEntryResponse\"-\n\x10\x44\x65leteRowRequest\x12\r\n\x05table\x18\x01 \x01(\t\x12\n\n\x02pk\x18\x02 \x01(\t\"\x13\n\x11\x44\x65leteRowResponse\"\x10\n\x0ePersistRequest\"\x11\n\x0fPersistResponse\"\x14\n\x12GetSnapshotRequest\"\'\n\x13GetSnapshotResponse\x12\x10\n\x08snapshot\x18\x01 \x01(\x0c\"\'\n\x13LoadSnapshotRequest\x12\x10\n\x08snapshot\x18\x01 \x01(\x0c\"\x16\n\x14LoadSnapshotResponse\"\xaa\x01\n\x12MigrateEnumRequest\x12\r\n\x05table\x18\x01 \x01(\t\x12\x0e\n\x06\x63olumn\x18\x02 \x01(\t\x12\x0e\n\x06values\x18\x03 \x03(\t\x12\x35\n\x07renames\x18\x04 \x03(\x0b\x32$.jql.MigrateEnumRequest.RenamesEntry\x1a.\n\x0cRenamesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\t:\x02\x38\x01\"\'\n\x13MigrateEnumResponse\x12\x10\n\x08migrated\x18\x01 \x01(\r\"\x0e\n\x0cStatsRequest\"<\n\nTableStats\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x0c\n\x04rows\x18\x02 \x01(\r\x12\x12\n\ndirty_rows\x18\x03 \x01(\r\"j\n\x08RPCStats\x12\x0e\n\x06method\x18\x01 \x01(\t\x12\r\n\x05\x63ount\x18\x02 \x01(\x04\x12\x0e\n\x06\x65rrors\x18\x03 \x01(\x04\x12\x17\n\x0fmean_latency_ms\x18\x04 \x01(\x01\x12\x16\n\x0emax_latency_ms\x18\x05 \x01(\x01\"\x8d\x01\n\rStatsResponse\x12\x1f\n\x06tables\x18\x01 \x03(\x0b\x32\x0f.jql.TableStats\x12\x1c\n\x14last_persist_unix_ms\x18\x02 \x01(\x03\x12 \n\x18last_persist_duration_ms\x18\x03 \x01(\x01\x12\x1b\n\x04rpcs\x18\x04 \x03(\x0b\x32\r.jql.RPCStats\"\x17\n\x15WatchMutationsRequest\"S\n\x08Mutation\x12\r\n\x05table\x18\x01 \x01(\t\x12\n\n\x02pk\x18\x02 \x01(\t\x12\x0b\n\x03row\x18\x03 \x01(\x0c\x12\x0f\n\x07\x64\x65leted\x18\x04 \x01(\x08\x12\x0e\n\x06resync\x18\x05 \x01(\x08\"4\n\x11RequestedGrouping\x12\r\n\x05\x66ield\x18\x01 \x01(\t\x12\x10\n\x08selected\x18\x02 \x01(\t\"4\n\x07GroupBy\x12)\n\tgroupings\x18\x01 \x03(\x0b\x32\x16.jql.RequestedGrouping\"\x85\x01\n\x08Grouping\x12\r\n\x05\x66ield\x18\x01 \x01(\t\x12)\n\x06values\x18\x02 \x03(\x0b\x32\x19.jql.Grouping.ValuesEntry\x12\x10\n\x08selected\x18\x03 \x01(\t\x1a-\n\x0bValuesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x03:\x02\x38\x01*\xb6\x01\n\tEntryType\x12\n\n\x06STRING\x10\x00\x12\x07\n\x03INT\x10\x01\x12\x08\n\x04\x44\x41TE\x10\x02\x12\x08\n\x04\x45NUM\x10\x03\x12\x06\n\x02ID\x10\x04\x12\x08\n\x04TIME\x10\x05\x12\x0c\n\x08MONEYAMT\x10\x06\x12\x0b\n\x07\x46OREIGN\x10\x07\x12\x0c\n\x08\x46OREIGNS\x10\x08\x12\x0f\n\x0bPOLYFOREIGN\x10\t\x12\x08\n\x04\x42OOL\x10\n\x12\t\n\x05\x46LOAT\x10\x0b\x12\x0c\n\x08\x44URATION\x10\x0c\x12\x07\n\x03URL\x10\r\x12\x08\n\x04TAGS\x10\x0e\x32\xde\x05\n\x03JQL\x12=\n\nListTables\x12\x16.jql.ListTablesRequest\x1a\x17.jql.ListTablesResponse\x12\x37\n\x08ListRows\x12\x14.jql.ListRowsRequest\x1a\x15.jql.ListRowsResponse\x12\x31\n\x06GetRow\x12\x12.jql.GetRowRequest\x1a\x13.jql.GetRowResponse\x12\x37\n\x08WriteRow\x12\x14.jql.WriteRowRequest\x1a\x15.jql.WriteRowResponse\x12:\n\tDeleteRow\x12\x15.jql.DeleteRowRequest\x1a\x16.jql.DeleteRowResponse\x12I\n\x0eIncrementEntry\x12\x1a.jql.IncrementEntryRequest\x1a\x1b.jql.IncrementEntryResponse\x12\x34\n\x07Persist\x12\x13.jql.PersistRequest\x1a\x14.jql.PersistResponse\x12@\n\x0bGetSnapshot\x12\x17.jql.GetSnapshotRequest\x1a\x18.jql.GetSnapshotResponse\x12\x43\n\x0cLoadSnapshot\x12\x18.jql.LoadSnapshotRequest\x1a\x19.jql.LoadSnapshotResponse\x12@\n\x0bMigrateEnum\x12\x17.jql.MigrateEnumRequest\x1a\x18.jql.MigrateEnumResponse\x12.\n\x05Stats\x12\x11.jql.StatsRequest\x1a\x12.jql.StatsResponse\x12=\n\x0eWatchMutations\x12\x1a.jql.WatchMutationsRequest\x1a\r.jql.Mutation0\x01\x42\x0bZ\tjql/jqlpbb\x06proto3')
 
 _globals = globals()
 _builder.BuildMessageAndEnumDescriptors(DESCRIPTOR, _globals)
@@ -34,10 +34,12 @@ if not _descriptor._USE_C_DESCRIPTORS:
   _globals['DESCRIPTOR']._serialized_options = b'Z\tjql/jqlpb'
   _globals['_WRITEROWREQUEST_FIELDSENTRY']._loaded_options = None
   _globals['_WRITEROWREQUEST_FIELDSENTRY']._serialized_options = b'8\001'
+  _globals['_MIGRATEENUMREQUEST_RENAMESENTRY']._loaded_options = None
+  _globals['_MIGRATEENUMREQUEST_RENAMESENTRY']._serialized_options = b'8\001'
   _globals['_GROUPING_VALUESENTRY']._loaded_options = None
   _globals['_GROUPING_VALUESENTRY']._serialized_options = b'8\001'
-  _globals['_ENTRYTYPE']._serialized_start=2248
-  _globals['_ENTRYTYPE']._serialized_end=2376
+  _globals['_ENTRYTYPE']._serialized_start=3097
+  _globals['_ENTRYTYPE']._serialized_end=3279
   _globals['_LISTTABLESREQUEST']._serialized_start=22
   _globals['_LISTTABLESREQUEST']._serialized_end=41
   _globals['_TABLEMETA']._serialized_start=43
@@ -56,58 +58,80 @@ if not _descriptor._USE_C_DESCRIPTORS:
   _globals['_CONTAINSMATCH']._serialized_end=322
   _globals['_PATHTOMATCH']._serialized_start=324
   _globals['_PATHTOMATCH']._serialized_end=369
-  _globals['_FILTER']._serialized_start=372
-  _globals['_FILTER']._serialized_end=686
-  _globals['_CONDITION']._serialized_start=688
-  _globals['_CONDITION']._serialized_end=730
-  _globals['_LISTROWSREQUEST']._serialized_start=733
-  _globals['_LISTROWSREQUEST']._serialized_end=895
-  _globals['_COLUMN']._serialized_start=898
-  _globals['_COLUMN']._serialized_end=1049
-  _globals['_ENTRY']._serialized_start=1051
-  _globals['_ENTRY']._serialized_end=1134
-  _globals['_ROW']._serialized_start=1136
-  _globals['_ROW']._serialized_end=1170
-  _globals['_LISTROWSRESPONSE']._serialized_start=1173
-  _globals['_LISTROWSRESPONSE']._serialized_end=1322
-  _globals['_GETROWREQUEST']._serialized_start=1324
-  _globals['_GETROWREQUEST']._serialized_end=1366
-  _globals['_GETROWRESPONSE']._serialized_start=1368
-  _globals['_GETROWRESPONSE']._serialized_end=1452
-  _globals['_WRITEROWREQUEST']._serialized_start=1455
-  _globals['_WRITEROWREQUEST']._serialized_end=1638
-  _globals['_WRITEROWREQUEST_FIELDSENTRY']._serialized_start=1593
-  _globals['_WRITEROWREQUEST_FIELDSENTRY']._serialized_end=1638
-  _globals['_WRITEROWRESPONSE']._serialized_start=1640
-  _globals['_WRITEROWRESPONSE']._serialized_end=1658
-  _globals['_INCREMENTENTRYREQUEST']._serialized_start=1660
-  _globals['_INCREMENTENTRYREQUEST']._serialized_end=1742
-  _globals['_INCREMENTENTRYRESPONSE']._serialized_start=1744
-  _globals['_INCREMENTENTRYRESPONSE']._serialized_end=1768
-  _globals['_DELETEROWREQUEST']._serialized_start=1770
-  _globals['_DELETEROWREQUEST']._serialized_end=1815
-  _globals['_DELETEROWRESPONSE']._serialized_start=1817
-  _globals['_DELETEROWRESPONSE']._serialized_end=1836
-  _globals['_PERSISTREQUEST']._serialized_start=1838
-  _globals['_PERSISTREQUEST']._serialized_end=1854
-  _globals['_PERSISTRESPONSE']._serialized_start=1856
-  _globals['_PERSISTRESPONSE']._serialized_end=1873
-  _globals['_GETSNAPSHOTREQUEST']._serialized_start=1875
-  _globals['_GETSNAPSHOTREQUEST']._serialized_end=1895
-  _globals['_GETSNAPSHOTRESPONSE']._serialized_start=1897
-  _globals['_GETSNAPSHOTRESPONSE']._serialized_end=1936
-  _globals['_LOADSNAPSHOTREQUEST']._serialized_start=1938
-  _globals['_LOADSNAPSHOTREQUEST']._serialized_end=1977
-  _globals['_LOADSNAPSHOTRESPONSE']._serialized_start=1979
-  _globals['_LOADSNAPSHOTRESPONSE']._serialized_end=2001
-  _globals['_REQUESTEDGROUPING']._serialized_start=2003
-  _globals['_REQUESTEDGROUPING']._serialized_end=2055
-  _globals['_GROUPBY']._serialized_start=2057
-  _globals['_GROUPBY']._serialized_end=2109
-  _globals['_GROUPING']._serialized_start=2112
-  _globals['_GROUPING']._serialized_end=2245
-  _globals['_GROUPING_VALUESENTRY']._serialized_start=2200
-  _globals['_GROUPING_VALUESENTRY']._serialized_end=2245
-  _globals['_JQL']._serialized_start=2379
-  _globals['_JQL']._serialized_end=2936
+  _globals['_REGEXMATCH']._serialized_start=371
+  _globals['_REGEXMATCH']._serialized_end=398
+  _globals['_PREFIXMATCH']._serialized_start=400
+  _globals['_PREFIXMATCH']._serialized_end=428
+  _globals['_FILTER']._serialized_start=431
+  _globals['_FILTER']._serialized_end=827
+  _globals['_CONDITION']._serialized_start=829
+  _globals['_CONDITION']._serialized_end=871
+  _globals['_LISTROWSREQUEST']._serialized_start=874
+  _globals['_LISTROWSREQUEST']._serialized_end=1036
+  _globals['_COLUMN']._serialized_start=1039
+  _globals['_COLUMN']._serialized_end=1232
+  _globals['_ENTRY']._serialized_start=1234
+  _globals['_ENTRY']._serialized_end=1317
+  _globals['_ROW']._serialized_start=1319
+  _globals['_ROW']._serialized_end=1353
+  _globals['_LISTROWSRESPONSE']._serialized_start=1356
+  _globals['_LISTROWSRESPONSE']._serialized_end=1505
+  _globals['_GETROWREQUEST']._serialized_start=1507
+  _globals['_GETROWREQUEST']._serialized_end=1549
+  _globals['_GETROWRESPONSE']._serialized_start=1551
+  _globals['_GETROWRESPONSE']._serialized_end=1635
+  _globals['_WRITEROWREQUEST']._serialized_start=1638
+  _globals['_WRITEROWREQUEST']._serialized_end=1821
+  _globals['_WRITEROWREQUEST_FIELDSENTRY']._serialized_start=1776
+  _globals['_WRITEROWREQUEST_FIELDSENTRY']._serialized_end=1821
+  _globals['_WRITEROWRESPONSE']._serialized_start=1823
+  _globals['_WRITEROWRESPONSE']._serialized_end=1853
+  _globals['_INCREMENTENTRYREQUEST']._serialized_start=1855
+  _globals['_INCREMENTENTRYREQUEST']._serialized_end=1937
+  _globals['_INCREMENTENTRYRESPONSE']._serialized_start=1939
+  _globals['_INCREMENTENTRYRESPONSE']._serialized_end=1963
+  _globals['_DELETEROWREQUEST']._serialized_start=1965
+  _globals['_DELETEROWREQUEST']._serialized_end=2010
+  _globals['_DELETEROWRESPONSE']._serialized_start=2012
+  _globals['_DELETEROWRESPONSE']._serialized_end=2031
+  _globals['_PERSISTREQUEST']._serialized_start=2033
+  _globals['_PERSISTREQUEST']._serialized_end=2049
+  _globals['_PERSISTRESPONSE']._serialized_start=2051
+  _globals['_PERSISTRESPONSE']._serialized_end=2068
+  _globals['_GETSNAPSHOTREQUEST']._serialized_start=2070
+  _globals['_GETSNAPSHOTREQUEST']._serialized_end=2090
+  _globals['_GETSNAPSHOTRESPONSE']._serialized_start=2092
+  _globals['_GETSNAPSHOTRESPONSE']._serialized_end=2131
+  _globals['_LOADSNAPSHOTREQUEST']._serialized_start=2133
+  _globals['_LOADSNAPSHOTREQUEST']._serialized_end=2172
+  _globals['_LOADSNAPSHOTRESPONSE']._serialized_start=2174
+  _globals['_LOADSNAPSHOTRESPONSE']._serialized_end=2196
+  _globals['_MIGRATEENUMREQUEST']._serialized_start=2199
+  _globals['_MIGRATEENUMREQUEST']._serialized_end=2369
+  _globals['_MIGRATEENUMREQUEST_RENAMESENTRY']._serialized_start=2323
+  _globals['_MIGRATEENUMREQUEST_RENAMESENTRY']._serialized_end=2369
+  _globals['_MIGRATEENUMRESPONSE']._serialized_start=2371
+  _globals['_MIGRATEENUMRESPONSE']._serialized_end=2410
+  _globals['_STATSREQUEST']._serialized_start=2412
+  _globals['_STATSREQUEST']._serialized_end=2426
+  _globals['_TABLESTATS']._serialized_start=2428
+  _globals['_TABLESTATS']._serialized_end=2488
+  _globals['_RPCSTATS']._serialized_start=2490
+  _globals['_RPCSTATS']._serialized_end=2596
+  _globals['_STATSRESPONSE']._serialized_start=2599
+  _globals['_STATSRESPONSE']._serialized_end=2740
+  _globals['_WATCHMUTATIONSREQUEST']._serialized_start=2742
+  _globals['_WATCHMUTATIONSREQUEST']._serialized_end=2765
+  _globals['_MUTATION']._serialized_start=2767
+  _globals['_MUTATION']._serialized_end=2850
+  _globals['_REQUESTEDGROUPING']._serialized_start=2852
+  _globals['_REQUESTEDGROUPING']._serialized_end=2904
+  _globals['_GROUPBY']._serialized_start=2906
+  _globals['_GROUPBY']._serialized_end=2958
+  _globals['_GROUPING']._serialized_start=2961
+  _globals['_GROUPING']._serialized_end=3094
+  _globals['_GROUPING_VALUESENTRY']._serialized_start=3049
+  _globals['_GROUPING_VALUESENTRY']._serialized_end=3094
+  _globals['_JQL']._serialized_start=3282
+  _globals['_JQL']._serialized_end=4016
 # @@protoc_insertion_point(module_scope)
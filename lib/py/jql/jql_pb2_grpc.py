@@ -79,6 +79,21 @@ class JQLStub(object):
                 request_serializer=jql_dot_jql__pb2.LoadSnapshotRequest.SerializeToString,
                 response_deserializer=jql_dot_jql__pb2.LoadSnapshotResponse.FromString,
                 _registered_method=True)
+        self.MigrateEnum = channel.unary_unary(
+                '/jql.JQL/MigrateEnum',
+                request_serializer=jql_dot_jql__pb2.MigrateEnumRequest.SerializeToString,
+                response_deserializer=jql_dot_jql__pb2.MigrateEnumResponse.FromString,
+                _registered_method=True)
+        self.Stats = channel.unary_unary(
+                '/jql.JQL/Stats',
+                request_serializer=jql_dot_jql__pb2.StatsRequest.SerializeToString,
+                response_deserializer=jql_dot_jql__pb2.StatsResponse.FromString,
+                _registered_method=True)
+        self.WatchMutations = channel.unary_stream(
+                '/jql.JQL/WatchMutations',
+                request_serializer=jql_dot_jql__pb2.WatchMutationsRequest.SerializeToString,
+                response_deserializer=jql_dot_jql__pb2.Mutation.FromString,
+                _registered_method=True)
 
 
 class JQLServicer(object):
@@ -138,6 +153,24 @@ class JQLServicer(object):
         context.set_details('Method not implemented!')
         raise NotImplementedError('Method not implemented!')
 
+    def MigrateEnum(self, request, context):
+        """Missing associated documentation comment in .proto file."""
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def Stats(self, request, context):
+        """Missing associated documentation comment in .proto file."""
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def WatchMutations(self, request, context):
+        """Missing associated documentation comment in .proto file."""
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
 
 def add_JQLServicer_to_server(servicer, server):
     rpc_method_handlers = {
@@ -186,6 +219,21 @@ def add_JQLServicer_to_server(servicer, server):
                     request_deserializer=jql_dot_jql__pb2.LoadSnapshotRequest.FromString,
                     response_serializer=jql_dot_jql__pb2.LoadSnapshotResponse.SerializeToString,
             ),
+            'MigrateEnum': grpc.unary_unary_rpc_method_handler(
+                    servicer.MigrateEnum,
+                    request_deserializer=jql_dot_jql__pb2.MigrateEnumRequest.FromString,
+                    response_serializer=jql_dot_jql__pb2.MigrateEnumResponse.SerializeToString,
+            ),
+            'Stats': grpc.unary_unary_rpc_method_handler(
+                    servicer.Stats,
+                    request_deserializer=jql_dot_jql__pb2.StatsRequest.FromString,
+                    response_serializer=jql_dot_jql__pb2.StatsResponse.SerializeToString,
+            ),
+            'WatchMutations': grpc.unary_stream_rpc_method_handler(
+                    servicer.WatchMutations,
+                    request_deserializer=jql_dot_jql__pb2.WatchMutationsRequest.FromString,
+                    response_serializer=jql_dot_jql__pb2.Mutation.SerializeToString,
+            ),
     }
     generic_handler = grpc.method_handlers_generic_handler(
             'jql.JQL', rpc_method_handlers)
@@ -439,3 +487,84 @@ class JQL(object):
             timeout,
             metadata,
             _registered_method=True)
+
+    @staticmethod
+    def MigrateEnum(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/jql.JQL/MigrateEnum',
+            jql_dot_jql__pb2.MigrateEnumRequest.SerializeToString,
+            jql_dot_jql__pb2.MigrateEnumResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
+
+    @staticmethod
+    def Stats(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/jql.JQL/Stats',
+            jql_dot_jql__pb2.StatsRequest.SerializeToString,
+            jql_dot_jql__pb2.StatsResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
+
+    @staticmethod
+    def WatchMutations(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_stream(
+            request,
+            target,
+            '/jql.JQL/WatchMutations',
+            jql_dot_jql__pb2.WatchMutationsRequest.SerializeToString,
+            jql_dot_jql__pb2.Mutation.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
@@ -34,21 +34,31 @@ const (
 	EntryType_FOREIGN     EntryType = 7
 	EntryType_FOREIGNS    EntryType = 8
 	EntryType_POLYFOREIGN EntryType = 9
+	EntryType_BOOL        EntryType = 10
+	EntryType_FLOAT       EntryType = 11
+	EntryType_DURATION    EntryType = 12
+	EntryType_URL         EntryType = 13
+	EntryType_TAGS        EntryType = 14
 )
 
 // Enum value maps for EntryType.
 var (
 	EntryType_name = map[int32]string{
-		0: "STRING",
-		1: "INT",
-		2: "DATE",
-		3: "ENUM",
-		4: "ID",
-		5: "TIME",
-		6: "MONEYAMT",
-		7: "FOREIGN",
-		8: "FOREIGNS",
-		9: "POLYFOREIGN",
+		0:  "STRING",
+		1:  "INT",
+		2:  "DATE",
+		3:  "ENUM",
+		4:  "ID",
+		5:  "TIME",
+		6:  "MONEYAMT",
+		7:  "FOREIGN",
+		8:  "FOREIGNS",
+		9:  "POLYFOREIGN",
+		10: "BOOL",
+		11: "FLOAT",
+		12: "DURATION",
+		13: "URL",
+		14: "TAGS",
 	}
 	EntryType_value = map[string]int32{
 		"STRING":      0,
@@ -61,6 +71,11 @@ var (
 		"FOREIGN":     7,
 		"FOREIGNS":    8,
 		"POLYFOREIGN": 9,
+		"BOOL":        10,
+		"FLOAT":       11,
+		"DURATION":    12,
+		"URL":         13,
+		"TAGS":        14,
 	}
 )
 
@@ -503,6 +518,94 @@ func (x *PathToMatch) GetReverse() bool {
 	return false
 }
 
+type RegexMatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegexMatch) Reset() {
+	*x = RegexMatch{}
+	mi := &file_jql_jql_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegexMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegexMatch) ProtoMessage() {}
+
+func (x *RegexMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_jql_jql_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegexMatch.ProtoReflect.Descriptor instead.
+func (*RegexMatch) Descriptor() ([]byte, []int) {
+	return file_jql_jql_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RegexMatch) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type PrefixMatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PrefixMatch) Reset() {
+	*x = PrefixMatch{}
+	mi := &file_jql_jql_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PrefixMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrefixMatch) ProtoMessage() {}
+
+func (x *PrefixMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_jql_jql_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrefixMatch.ProtoReflect.Descriptor instead.
+func (*PrefixMatch) Descriptor() ([]byte, []int) {
+	return file_jql_jql_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PrefixMatch) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
 type Filter struct {
 	state   protoimpl.MessageState `protogen:"open.v1"`
 	Negated bool                   `protobuf:"varint,1,opt,name=negated,proto3" json:"negated,omitempty"`
@@ -515,6 +618,8 @@ type Filter struct {
 	//	*Filter_InMatch
 	//	*Filter_ContainsMatch
 	//	*Filter_PathToMatch
+	//	*Filter_RegexMatch
+	//	*Filter_PrefixMatch
 	Match         isFilter_Match `protobuf_oneof:"match"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -522,7 +627,7 @@ type Filter struct {
 
 func (x *Filter) Reset() {
 	*x = Filter{}
-	mi := &file_jql_jql_proto_msgTypes[9]
+	mi := &file_jql_jql_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -534,7 +639,7 @@ func (x *Filter) String() string {
 func (*Filter) ProtoMessage() {}
 
 func (x *Filter) ProtoReflect() protoreflect.Message {
-	mi := &file_jql_jql_proto_msgTypes[9]
+	mi := &file_jql_jql_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -547,7 +652,7 @@ func (x *Filter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Filter.ProtoReflect.Descriptor instead.
 func (*Filter) Descriptor() ([]byte, []int) {
-	return file_jql_jql_proto_rawDescGZIP(), []int{9}
+	return file_jql_jql_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *Filter) GetNegated() bool {
@@ -625,6 +730,24 @@ func (x *Filter) GetPathToMatch() *PathToMatch {
 	return nil
 }
 
+func (x *Filter) GetRegexMatch() *RegexMatch {
+	if x != nil {
+		if x, ok := x.Match.(*Filter_RegexMatch); ok {
+			return x.RegexMatch
+		}
+	}
+	return nil
+}
+
+func (x *Filter) GetPrefixMatch() *PrefixMatch {
+	if x != nil {
+		if x, ok := x.Match.(*Filter_PrefixMatch); ok {
+			return x.PrefixMatch
+		}
+	}
+	return nil
+}
+
 type isFilter_Match interface {
 	isFilter_Match()
 }
@@ -653,6 +776,14 @@ type Filter_PathToMatch struct {
 	PathToMatch *PathToMatch `protobuf:"bytes,8,opt,name=path_to_match,json=pathToMatch,proto3,oneof"`
 }
 
+type Filter_RegexMatch struct {
+	RegexMatch *RegexMatch `protobuf:"bytes,9,opt,name=regex_match,json=regexMatch,proto3,oneof"`
+}
+
+type Filter_PrefixMatch struct {
+	PrefixMatch *PrefixMatch `protobuf:"bytes,10,opt,name=prefix_match,json=prefixMatch,proto3,oneof"`
+}
+
 func (*Filter_EqualMatch) isFilter_Match() {}
 
 func (*Filter_LessThanMatch) isFilter_Match() {}
@@ -665,6 +796,10 @@ func (*Filter_ContainsMatch) isFilter_Match() {}
 
 func (*Filter_PathToMatch) isFilter_Match() {}
 
+func (*Filter_RegexMatch) isFilter_Match() {}
+
+func (*Filter_PrefixMatch) isFilter_Match() {}
+
 type Condition struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Requires      []*Filter              `protobuf:"bytes,1,rep,name=requires,proto3" json:"requires,omitempty"`
@@ -674,7 +809,7 @@ type Condition struct {
 
 func (x *Condition) Reset() {
 	*x = Condition{}
-	mi := &file_jql_jql_proto_msgTypes[10]
+	mi := &file_jql_jql_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -686,7 +821,7 @@ func (x *Condition) String() string {
 func (*Condition) ProtoMessage() {}
 
 func (x *Condition) ProtoReflect() protoreflect.Message {
-	mi := &file_jql_jql_proto_msgTypes[10]
+	mi := &file_jql_jql_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -699,7 +834,7 @@ func (x *Condition) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Condition.ProtoReflect.Descriptor instead.
 func (*Condition) Descriptor() ([]byte, []int) {
-	return file_jql_jql_proto_rawDescGZIP(), []int{10}
+	return file_jql_jql_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *Condition) GetRequires() []*Filter {
@@ -726,7 +861,7 @@ type ListRowsRequest struct {
 
 func (x *ListRowsRequest) Reset() {
 	*x = ListRowsRequest{}
-	mi := &file_jql_jql_proto_msgTypes[11]
+	mi := &file_jql_jql_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -738,7 +873,7 @@ func (x *ListRowsRequest) String() string {
 func (*ListRowsRequest) ProtoMessage() {}
 
 func (x *ListRowsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_jql_jql_proto_msgTypes[11]
+	mi := &file_jql_jql_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -751,7 +886,7 @@ func (x *ListRowsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListRowsRequest.ProtoReflect.Descriptor instead.
 func (*ListRowsRequest) Descriptor() ([]byte, []int) {
-	return file_jql_jql_proto_rawDescGZIP(), []int{11}
+	return file_jql_jql_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ListRowsRequest) GetTable() string {
@@ -810,16 +945,20 @@ type Column struct {
 	MaxLength int32                  `protobuf:"varint,3,opt,name=max_length,json=maxLength,proto3" json:"max_length,omitempty"`
 	Primary   bool                   `protobuf:"varint,4,opt,name=primary,proto3" json:"primary,omitempty"`
 	// Type-specific fields
-	ForeignTable  string   `protobuf:"bytes,5,opt,name=foreign_table,json=foreignTable,proto3" json:"foreign_table,omitempty"`
-	Values        []string `protobuf:"bytes,6,rep,name=values,proto3" json:"values,omitempty"`
-	DisplayValue  string   `protobuf:"bytes,7,opt,name=display_value,json=displayValue,proto3" json:"display_value,omitempty"`
+	ForeignTable string   `protobuf:"bytes,5,opt,name=foreign_table,json=foreignTable,proto3" json:"foreign_table,omitempty"`
+	Values       []string `protobuf:"bytes,6,rep,name=values,proto3" json:"values,omitempty"`
+	DisplayValue string   `protobuf:"bytes,7,opt,name=display_value,json=displayValue,proto3" json:"display_value,omitempty"`
+	// The tables a polyforeign column may refer to
+	ForeignTables []string `protobuf:"bytes,8,rep,name=foreign_tables,json=foreignTables,proto3" json:"foreign_tables,omitempty"`
+	// The time zone in which a date or time column is displayed
+	Timezone      string `protobuf:"bytes,9,opt,name=timezone,proto3" json:"timezone,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Column) Reset() {
 	*x = Column{}
-	mi := &file_jql_jql_proto_msgTypes[12]
+	mi := &file_jql_jql_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -831,7 +970,7 @@ func (x *Column) String() string {
 func (*Column) ProtoMessage() {}
 
 func (x *Column) ProtoReflect() protoreflect.Message {
-	mi := &file_jql_jql_proto_msgTypes[12]
+	mi := &file_jql_jql_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -844,7 +983,7 @@ func (x *Column) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Column.ProtoReflect.Descriptor instead.
 func (*Column) Descriptor() ([]byte, []int) {
-	return file_jql_jql_proto_rawDescGZIP(), []int{12}
+	return file_jql_jql_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *Column) GetName() string {
@@ -896,6 +1035,20 @@ func (x *Column) GetDisplayValue() string {
 	return ""
 }
 
+func (x *Column) GetForeignTables() []string {
+	if x != nil {
+		return x.ForeignTables
+	}
+	return nil
+}
+
+func (x *Column) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
 type Entry struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Formatted     string                 `protobuf:"bytes,1,opt,name=formatted,proto3" json:"formatted,omitempty"`
@@ -908,7 +1061,7 @@ type Entry struct {
 
 func (x *Entry) Reset() {
 	*x = Entry{}
-	mi := &file_jql_jql_proto_msgTypes[13]
+	mi := &file_jql_jql_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -920,7 +1073,7 @@ func (x *Entry) String() string {
 func (*Entry) ProtoMessage() {}
 
 func (x *Entry) ProtoReflect() protoreflect.Message {
-	mi := &file_jql_jql_proto_msgTypes[13]
+	mi := &file_jql_jql_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -933,7 +1086,7 @@ func (x *Entry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Entry.ProtoReflect.Descriptor instead.
 func (*Entry) Descriptor() ([]byte, []int) {
-	return file_jql_jql_proto_rawDescGZIP(), []int{13}
+	return file_jql_jql_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *Entry) GetFormatted() string {
@@ -973,7 +1126,7 @@ type Row struct {
 
 func (x *Row) Reset() {
 	*x = Row{}
-	mi := &file_jql_jql_proto_msgTypes[14]
+	mi := &file_jql_jql_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -985,7 +1138,7 @@ func (x *Row) String() string {
 func (*Row) ProtoMessage() {}
 
 func (x *Row) ProtoReflect() protoreflect.Message {
-	mi := &file_jql_jql_proto_msgTypes[14]
+	mi := &file_jql_jql_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -998,7 +1151,7 @@ func (x *Row) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Row.ProtoReflect.Descriptor instead.
 func (*Row) Descriptor() ([]byte, []int) {
-	return file_jql_jql_proto_rawDescGZIP(), []int{14}
+	return file_jql_jql_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *Row) GetEntries() []*Entry {
@@ -1022,7 +1175,7 @@ type ListRowsResponse struct {
 
 func (x *ListRowsResponse) Reset() {
 	*x = ListRowsResponse{}
-	mi := &file_jql_jql_proto_msgTypes[15]
+	mi := &file_jql_jql_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1034,7 +1187,7 @@ func (x *ListRowsResponse) String() string {
 func (*ListRowsResponse) ProtoMessage() {}
 
 func (x *ListRowsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_jql_jql_proto_msgTypes[15]
+	mi := &file_jql_jql_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1047,7 +1200,7 @@ func (x *ListRowsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListRowsResponse.ProtoReflect.Descriptor instead.
 func (*ListRowsResponse) Descriptor() ([]byte, []int) {
-	return file_jql_jql_proto_rawDescGZIP(), []int{15}
+	return file_jql_jql_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *ListRowsResponse) GetTable() string {
@@ -1102,7 +1255,7 @@ type GetRowRequest struct {
 
 func (x *GetRowRequest) Reset() {
 	*x = GetRowRequest{}
-	mi := &file_jql_jql_proto_msgTypes[16]
+	mi := &file_jql_jql_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1114,7 +1267,7 @@ func (x *GetRowRequest) String() string {
 func (*GetRowRequest) ProtoMessage() {}
 
 func (x *GetRowRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_jql_jql_proto_msgTypes[16]
+	mi := &file_jql_jql_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1127,7 +1280,7 @@ func (x *GetRowRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRowRequest.ProtoReflect.Descriptor instead.
 func (*GetRowRequest) Descriptor() ([]byte, []int) {
-	return file_jql_jql_proto_rawDescGZIP(), []int{16}
+	return file_jql_jql_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *GetRowRequest) GetTable() string {
@@ -1155,7 +1308,7 @@ type GetRowResponse struct {
 
 func (x *GetRowResponse) Reset() {
 	*x = GetRowResponse{}
-	mi := &file_jql_jql_proto_msgTypes[17]
+	mi := &file_jql_jql_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1167,7 +1320,7 @@ func (x *GetRowResponse) String() string {
 func (*GetRowResponse) ProtoMessage() {}
 
 func (x *GetRowResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_jql_jql_proto_msgTypes[17]
+	mi := &file_jql_jql_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1180,7 +1333,7 @@ func (x *GetRowResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRowResponse.ProtoReflect.Descriptor instead.
 func (*GetRowResponse) Descriptor() ([]byte, []int) {
-	return file_jql_jql_proto_rawDescGZIP(), []int{17}
+	return file_jql_jql_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *GetRowResponse) GetTable() string {
@@ -1205,19 +1358,21 @@ func (x *GetRowResponse) GetRow() *Row {
 }
 
 type WriteRowRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Table         string                 `protobuf:"bytes,1,opt,name=table,proto3" json:"table,omitempty"`
-	Pk            string                 `protobuf:"bytes,2,opt,name=pk,proto3" json:"pk,omitempty"`
-	Fields        map[string]string      `protobuf:"bytes,3,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	UpdateOnly    bool                   `protobuf:"varint,4,opt,name=update_only,json=updateOnly,proto3" json:"update_only,omitempty"`
-	InsertOnly    bool                   `protobuf:"varint,5,opt,name=insert_only,json=insertOnly,proto3" json:"insert_only,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Table string                 `protobuf:"bytes,1,opt,name=table,proto3" json:"table,omitempty"`
+	// If empty when inserting into a table whose primary column is an ID,
+	// a new pk is generated using the column's strategy
+	Pk            string            `protobuf:"bytes,2,opt,name=pk,proto3" json:"pk,omitempty"`
+	Fields        map[string]string `protobuf:"bytes,3,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	UpdateOnly    bool              `protobuf:"varint,4,opt,name=update_only,json=updateOnly,proto3" json:"update_only,omitempty"`
+	InsertOnly    bool              `protobuf:"varint,5,opt,name=insert_only,json=insertOnly,proto3" json:"insert_only,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *WriteRowRequest) Reset() {
 	*x = WriteRowRequest{}
-	mi := &file_jql_jql_proto_msgTypes[18]
+	mi := &file_jql_jql_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1229,7 +1384,7 @@ func (x *WriteRowRequest) String() string {
 func (*WriteRowRequest) ProtoMessage() {}
 
 func (x *WriteRowRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_jql_jql_proto_msgTypes[18]
+	mi := &file_jql_jql_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1242,7 +1397,7 @@ func (x *WriteRowRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WriteRowRequest.ProtoReflect.Descriptor instead.
 func (*WriteRowRequest) Descriptor() ([]byte, []int) {
-	return file_jql_jql_proto_rawDescGZIP(), []int{18}
+	return file_jql_jql_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *WriteRowRequest) GetTable() string {
@@ -1281,14 +1436,16 @@ func (x *WriteRowRequest) GetInsertOnly() bool {
 }
 
 type WriteRowResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The pk of the written row
+	Pk            string `protobuf:"bytes,1,opt,name=pk,proto3" json:"pk,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *WriteRowResponse) Reset() {
 	*x = WriteRowResponse{}
-	mi := &file_jql_jql_proto_msgTypes[19]
+	mi := &file_jql_jql_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1300,7 +1457,7 @@ func (x *WriteRowResponse) String() string {
 func (*WriteRowResponse) ProtoMessage() {}
 
 func (x *WriteRowResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_jql_jql_proto_msgTypes[19]
+	mi := &file_jql_jql_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1313,7 +1470,14 @@ func (x *WriteRowResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WriteRowResponse.ProtoReflect.Descriptor instead.
 func (*WriteRowResponse) Descriptor() ([]byte, []int) {
-	return file_jql_jql_proto_rawDescGZIP(), []int{19}
+	return file_jql_jql_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *WriteRowResponse) GetPk() string {
+	if x != nil {
+		return x.Pk
+	}
+	return ""
 }
 
 type IncrementEntryRequest struct {
@@ -1328,7 +1492,7 @@ type IncrementEntryRequest struct {
 
 func (x *IncrementEntryRequest) Reset() {
 	*x = IncrementEntryRequest{}
-	mi := &file_jql_jql_proto_msgTypes[20]
+	mi := &file_jql_jql_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1340,7 +1504,7 @@ func (x *IncrementEntryRequest) String() string {
 func (*IncrementEntryRequest) ProtoMessage() {}
 
 func (x *IncrementEntryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_jql_jql_proto_msgTypes[20]
+	mi := &file_jql_jql_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1353,7 +1517,7 @@ func (x *IncrementEntryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IncrementEntryRequest.ProtoReflect.Descriptor instead.
 func (*IncrementEntryRequest) Descriptor() ([]byte, []int) {
-	return file_jql_jql_proto_rawDescGZIP(), []int{20}
+	return file_jql_jql_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *IncrementEntryRequest) GetTable() string {
@@ -1392,7 +1556,7 @@ type IncrementEntryResponse struct {
 
 func (x *IncrementEntryResponse) Reset() {
 	*x = IncrementEntryResponse{}
-	mi := &file_jql_jql_proto_msgTypes[21]
+	mi := &file_jql_jql_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1404,7 +1568,7 @@ func (x *IncrementEntryResponse) String() string {
 func (*IncrementEntryResponse) ProtoMessage() {}
 
 func (x *IncrementEntryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_jql_jql_proto_msgTypes[21]
+	mi := &file_jql_jql_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1417,7 +1581,7 @@ func (x *IncrementEntryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IncrementEntryResponse.ProtoReflect.Descriptor instead.
 func (*IncrementEntryResponse) Descriptor() ([]byte, []int) {
-	return file_jql_jql_proto_rawDescGZIP(), []int{21}
+	return file_jql_jql_proto_rawDescGZIP(), []int{23}
 }
 
 type DeleteRowRequest struct {
@@ -1430,7 +1594,7 @@ type DeleteRowRequest struct {
 
 func (x *DeleteRowRequest) Reset() {
 	*x = DeleteRowRequest{}
-	mi := &file_jql_jql_proto_msgTypes[22]
+	mi := &file_jql_jql_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1442,7 +1606,7 @@ func (x *DeleteRowRequest) String() string {
 func (*DeleteRowRequest) ProtoMessage() {}
 
 func (x *DeleteRowRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_jql_jql_proto_msgTypes[22]
+	mi := &file_jql_jql_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1455,7 +1619,7 @@ func (x *DeleteRowRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteRowRequest.ProtoReflect.Descriptor instead.
 func (*DeleteRowRequest) Descriptor() ([]byte, []int) {
-	return file_jql_jql_proto_rawDescGZIP(), []int{22}
+	return file_jql_jql_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *DeleteRowRequest) GetTable() string {
@@ -1480,7 +1644,7 @@ type DeleteRowResponse struct {
 
 func (x *DeleteRowResponse) Reset() {
 	*x = DeleteRowResponse{}
-	mi := &file_jql_jql_proto_msgTypes[23]
+	mi := &file_jql_jql_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1492,7 +1656,7 @@ func (x *DeleteRowResponse) String() string {
 func (*DeleteRowResponse) ProtoMessage() {}
 
 func (x *DeleteRowResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_jql_jql_proto_msgTypes[23]
+	mi := &file_jql_jql_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1505,7 +1669,7 @@ func (x *DeleteRowResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteRowResponse.ProtoReflect.Descriptor instead.
 func (*DeleteRowResponse) Descriptor() ([]byte, []int) {
-	return file_jql_jql_proto_rawDescGZIP(), []int{23}
+	return file_jql_jql_proto_rawDescGZIP(), []int{25}
 }
 
 type PersistRequest struct {
@@ -1516,7 +1680,7 @@ type PersistRequest struct {
 
 func (x *PersistRequest) Reset() {
 	*x = PersistRequest{}
-	mi := &file_jql_jql_proto_msgTypes[24]
+	mi := &file_jql_jql_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1528,7 +1692,7 @@ func (x *PersistRequest) String() string {
 func (*PersistRequest) ProtoMessage() {}
 
 func (x *PersistRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_jql_jql_proto_msgTypes[24]
+	mi := &file_jql_jql_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1541,7 +1705,7 @@ func (x *PersistRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PersistRequest.ProtoReflect.Descriptor instead.
 func (*PersistRequest) Descriptor() ([]byte, []int) {
-	return file_jql_jql_proto_rawDescGZIP(), []int{24}
+	return file_jql_jql_proto_rawDescGZIP(), []int{26}
 }
 
 type PersistResponse struct {
@@ -1552,7 +1716,7 @@ type PersistResponse struct {
 
 func (x *PersistResponse) Reset() {
 	*x = PersistResponse{}
-	mi := &file_jql_jql_proto_msgTypes[25]
+	mi := &file_jql_jql_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1564,7 +1728,7 @@ func (x *PersistResponse) String() string {
 func (*PersistResponse) ProtoMessage() {}
 
 func (x *PersistResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_jql_jql_proto_msgTypes[25]
+	mi := &file_jql_jql_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1577,7 +1741,7 @@ func (x *PersistResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PersistResponse.ProtoReflect.Descriptor instead.
 func (*PersistResponse) Descriptor() ([]byte, []int) {
-	return file_jql_jql_proto_rawDescGZIP(), []int{25}
+	return file_jql_jql_proto_rawDescGZIP(), []int{27}
 }
 
 type GetSnapshotRequest struct {
@@ -1588,7 +1752,7 @@ type GetSnapshotRequest struct {
 
 func (x *GetSnapshotRequest) Reset() {
 	*x = GetSnapshotRequest{}
-	mi := &file_jql_jql_proto_msgTypes[26]
+	mi := &file_jql_jql_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1600,7 +1764,7 @@ func (x *GetSnapshotRequest) String() string {
 func (*GetSnapshotRequest) ProtoMessage() {}
 
 func (x *GetSnapshotRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_jql_jql_proto_msgTypes[26]
+	mi := &file_jql_jql_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1613,7 +1777,7 @@ func (x *GetSnapshotRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSnapshotRequest.ProtoReflect.Descriptor instead.
 func (*GetSnapshotRequest) Descriptor() ([]byte, []int) {
-	return file_jql_jql_proto_rawDescGZIP(), []int{26}
+	return file_jql_jql_proto_rawDescGZIP(), []int{28}
 }
 
 type GetSnapshotResponse struct {
@@ -1625,7 +1789,7 @@ type GetSnapshotResponse struct {
 
 func (x *GetSnapshotResponse) Reset() {
 	*x = GetSnapshotResponse{}
-	mi := &file_jql_jql_proto_msgTypes[27]
+	mi := &file_jql_jql_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1637,7 +1801,7 @@ func (x *GetSnapshotResponse) String() string {
 func (*GetSnapshotResponse) ProtoMessage() {}
 
 func (x *GetSnapshotResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_jql_jql_proto_msgTypes[27]
+	mi := &file_jql_jql_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1650,7 +1814,7 @@ func (x *GetSnapshotResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSnapshotResponse.ProtoReflect.Descriptor instead.
 func (*GetSnapshotResponse) Descriptor() ([]byte, []int) {
-	return file_jql_jql_proto_rawDescGZIP(), []int{27}
+	return file_jql_jql_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *GetSnapshotResponse) GetSnapshot() []byte {
@@ -1669,7 +1833,7 @@ type LoadSnapshotRequest struct {
 
 func (x *LoadSnapshotRequest) Reset() {
 	*x = LoadSnapshotRequest{}
-	mi := &file_jql_jql_proto_msgTypes[28]
+	mi := &file_jql_jql_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1681,7 +1845,7 @@ func (x *LoadSnapshotRequest) String() string {
 func (*LoadSnapshotRequest) ProtoMessage() {}
 
 func (x *LoadSnapshotRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_jql_jql_proto_msgTypes[28]
+	mi := &file_jql_jql_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1694,7 +1858,7 @@ func (x *LoadSnapshotRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoadSnapshotRequest.ProtoReflect.Descriptor instead.
 func (*LoadSnapshotRequest) Descriptor() ([]byte, []int) {
-	return file_jql_jql_proto_rawDescGZIP(), []int{28}
+	return file_jql_jql_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *LoadSnapshotRequest) GetSnapshot() []byte {
@@ -1712,7 +1876,7 @@ type LoadSnapshotResponse struct {
 
 func (x *LoadSnapshotResponse) Reset() {
 	*x = LoadSnapshotResponse{}
-	mi := &file_jql_jql_proto_msgTypes[29]
+	mi := &file_jql_jql_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1724,7 +1888,7 @@ func (x *LoadSnapshotResponse) String() string {
 func (*LoadSnapshotResponse) ProtoMessage() {}
 
 func (x *LoadSnapshotResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_jql_jql_proto_msgTypes[29]
+	mi := &file_jql_jql_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1737,32 +1901,36 @@ func (x *LoadSnapshotResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoadSnapshotResponse.ProtoReflect.Descriptor instead.
 func (*LoadSnapshotResponse) Descriptor() ([]byte, []int) {
-	return file_jql_jql_proto_rawDescGZIP(), []int{29}
+	return file_jql_jql_proto_rawDescGZIP(), []int{31}
 }
 
-type RequestedGrouping struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
-	Selected      string                 `protobuf:"bytes,2,opt,name=selected,proto3" json:"selected,omitempty"`
+type MigrateEnumRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Table  string                 `protobuf:"bytes,1,opt,name=table,proto3" json:"table,omitempty"`
+	Column string                 `protobuf:"bytes,2,opt,name=column,proto3" json:"column,omitempty"`
+	// The full, ordered list of values the enum should have after the migration
+	Values []string `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`
+	// Maps values being renamed or removed to the value existing rows should take
+	Renames       map[string]string `protobuf:"bytes,4,rep,name=renames,proto3" json:"renames,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RequestedGrouping) Reset() {
-	*x = RequestedGrouping{}
-	mi := &file_jql_jql_proto_msgTypes[30]
+func (x *MigrateEnumRequest) Reset() {
+	*x = MigrateEnumRequest{}
+	mi := &file_jql_jql_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RequestedGrouping) String() string {
+func (x *MigrateEnumRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RequestedGrouping) ProtoMessage() {}
+func (*MigrateEnumRequest) ProtoMessage() {}
 
-func (x *RequestedGrouping) ProtoReflect() protoreflect.Message {
-	mi := &file_jql_jql_proto_msgTypes[30]
+func (x *MigrateEnumRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jql_jql_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1773,14 +1941,484 @@ func (x *RequestedGrouping) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RequestedGrouping.ProtoReflect.Descriptor instead.
-func (*RequestedGrouping) Descriptor() ([]byte, []int) {
-	return file_jql_jql_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use MigrateEnumRequest.ProtoReflect.Descriptor instead.
+func (*MigrateEnumRequest) Descriptor() ([]byte, []int) {
+	return file_jql_jql_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *RequestedGrouping) GetField() string {
+func (x *MigrateEnumRequest) GetTable() string {
 	if x != nil {
-		return x.Field
+		return x.Table
+	}
+	return ""
+}
+
+func (x *MigrateEnumRequest) GetColumn() string {
+	if x != nil {
+		return x.Column
+	}
+	return ""
+}
+
+func (x *MigrateEnumRequest) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *MigrateEnumRequest) GetRenames() map[string]string {
+	if x != nil {
+		return x.Renames
+	}
+	return nil
+}
+
+type MigrateEnumResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The number of rows whose value changed
+	Migrated      uint32 `protobuf:"varint,1,opt,name=migrated,proto3" json:"migrated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MigrateEnumResponse) Reset() {
+	*x = MigrateEnumResponse{}
+	mi := &file_jql_jql_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MigrateEnumResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MigrateEnumResponse) ProtoMessage() {}
+
+func (x *MigrateEnumResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jql_jql_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MigrateEnumResponse.ProtoReflect.Descriptor instead.
+func (*MigrateEnumResponse) Descriptor() ([]byte, []int) {
+	return file_jql_jql_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *MigrateEnumResponse) GetMigrated() uint32 {
+	if x != nil {
+		return x.Migrated
+	}
+	return 0
+}
+
+type StatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatsRequest) Reset() {
+	*x = StatsRequest{}
+	mi := &file_jql_jql_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsRequest) ProtoMessage() {}
+
+func (x *StatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jql_jql_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsRequest.ProtoReflect.Descriptor instead.
+func (*StatsRequest) Descriptor() ([]byte, []int) {
+	return file_jql_jql_proto_rawDescGZIP(), []int{34}
+}
+
+type TableStats struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Rows  uint32                 `protobuf:"varint,2,opt,name=rows,proto3" json:"rows,omitempty"`
+	// The number of rows modified since the database was last persisted
+	DirtyRows     uint32 `protobuf:"varint,3,opt,name=dirty_rows,json=dirtyRows,proto3" json:"dirty_rows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TableStats) Reset() {
+	*x = TableStats{}
+	mi := &file_jql_jql_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TableStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TableStats) ProtoMessage() {}
+
+func (x *TableStats) ProtoReflect() protoreflect.Message {
+	mi := &file_jql_jql_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TableStats.ProtoReflect.Descriptor instead.
+func (*TableStats) Descriptor() ([]byte, []int) {
+	return file_jql_jql_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *TableStats) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TableStats) GetRows() uint32 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+func (x *TableStats) GetDirtyRows() uint32 {
+	if x != nil {
+		return x.DirtyRows
+	}
+	return 0
+}
+
+type RPCStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Method        string                 `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	Count         uint64                 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Errors        uint64                 `protobuf:"varint,3,opt,name=errors,proto3" json:"errors,omitempty"`
+	MeanLatencyMs float64                `protobuf:"fixed64,4,opt,name=mean_latency_ms,json=meanLatencyMs,proto3" json:"mean_latency_ms,omitempty"`
+	MaxLatencyMs  float64                `protobuf:"fixed64,5,opt,name=max_latency_ms,json=maxLatencyMs,proto3" json:"max_latency_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RPCStats) Reset() {
+	*x = RPCStats{}
+	mi := &file_jql_jql_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RPCStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RPCStats) ProtoMessage() {}
+
+func (x *RPCStats) ProtoReflect() protoreflect.Message {
+	mi := &file_jql_jql_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RPCStats.ProtoReflect.Descriptor instead.
+func (*RPCStats) Descriptor() ([]byte, []int) {
+	return file_jql_jql_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *RPCStats) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *RPCStats) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *RPCStats) GetErrors() uint64 {
+	if x != nil {
+		return x.Errors
+	}
+	return 0
+}
+
+func (x *RPCStats) GetMeanLatencyMs() float64 {
+	if x != nil {
+		return x.MeanLatencyMs
+	}
+	return 0
+}
+
+func (x *RPCStats) GetMaxLatencyMs() float64 {
+	if x != nil {
+		return x.MaxLatencyMs
+	}
+	return 0
+}
+
+type StatsResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Tables []*TableStats          `protobuf:"bytes,1,rep,name=tables,proto3" json:"tables,omitempty"`
+	// Unix time in milliseconds of the last successful persist or 0 if
+	// the database has not been persisted since the daemon started
+	LastPersistUnixMs     int64   `protobuf:"varint,2,opt,name=last_persist_unix_ms,json=lastPersistUnixMs,proto3" json:"last_persist_unix_ms,omitempty"`
+	LastPersistDurationMs float64 `protobuf:"fixed64,3,opt,name=last_persist_duration_ms,json=lastPersistDurationMs,proto3" json:"last_persist_duration_ms,omitempty"`
+	// Per-RPC counts and latencies since the daemon started
+	Rpcs          []*RPCStats `protobuf:"bytes,4,rep,name=rpcs,proto3" json:"rpcs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatsResponse) Reset() {
+	*x = StatsResponse{}
+	mi := &file_jql_jql_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsResponse) ProtoMessage() {}
+
+func (x *StatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jql_jql_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsResponse.ProtoReflect.Descriptor instead.
+func (*StatsResponse) Descriptor() ([]byte, []int) {
+	return file_jql_jql_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *StatsResponse) GetTables() []*TableStats {
+	if x != nil {
+		return x.Tables
+	}
+	return nil
+}
+
+func (x *StatsResponse) GetLastPersistUnixMs() int64 {
+	if x != nil {
+		return x.LastPersistUnixMs
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetLastPersistDurationMs() float64 {
+	if x != nil {
+		return x.LastPersistDurationMs
+	}
+	return 0
+}
+
+func (x *StatsResponse) GetRpcs() []*RPCStats {
+	if x != nil {
+		return x.Rpcs
+	}
+	return nil
+}
+
+type WatchMutationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchMutationsRequest) Reset() {
+	*x = WatchMutationsRequest{}
+	mi := &file_jql_jql_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchMutationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchMutationsRequest) ProtoMessage() {}
+
+func (x *WatchMutationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jql_jql_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchMutationsRequest.ProtoReflect.Descriptor instead.
+func (*WatchMutationsRequest) Descriptor() ([]byte, []int) {
+	return file_jql_jql_proto_rawDescGZIP(), []int{38}
+}
+
+type Mutation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Table string                 `protobuf:"bytes,1,opt,name=table,proto3" json:"table,omitempty"`
+	Pk    string                 `protobuf:"bytes,2,opt,name=pk,proto3" json:"pk,omitempty"`
+	// The row as it is encoded in storage, as JSON. Empty if the row was deleted.
+	Row     []byte `protobuf:"bytes,3,opt,name=row,proto3" json:"row,omitempty"`
+	Deleted bool   `protobuf:"varint,4,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	// Set when the change can't be described by a single row, e.g. a snapshot
+	// was loaded or a schema was migrated. Watchers should reload the snapshot.
+	Resync        bool `protobuf:"varint,5,opt,name=resync,proto3" json:"resync,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Mutation) Reset() {
+	*x = Mutation{}
+	mi := &file_jql_jql_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Mutation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Mutation) ProtoMessage() {}
+
+func (x *Mutation) ProtoReflect() protoreflect.Message {
+	mi := &file_jql_jql_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Mutation.ProtoReflect.Descriptor instead.
+func (*Mutation) Descriptor() ([]byte, []int) {
+	return file_jql_jql_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *Mutation) GetTable() string {
+	if x != nil {
+		return x.Table
+	}
+	return ""
+}
+
+func (x *Mutation) GetPk() string {
+	if x != nil {
+		return x.Pk
+	}
+	return ""
+}
+
+func (x *Mutation) GetRow() []byte {
+	if x != nil {
+		return x.Row
+	}
+	return nil
+}
+
+func (x *Mutation) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+func (x *Mutation) GetResync() bool {
+	if x != nil {
+		return x.Resync
+	}
+	return false
+}
+
+type RequestedGrouping struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Selected      string                 `protobuf:"bytes,2,opt,name=selected,proto3" json:"selected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestedGrouping) Reset() {
+	*x = RequestedGrouping{}
+	mi := &file_jql_jql_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestedGrouping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestedGrouping) ProtoMessage() {}
+
+func (x *RequestedGrouping) ProtoReflect() protoreflect.Message {
+	mi := &file_jql_jql_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestedGrouping.ProtoReflect.Descriptor instead.
+func (*RequestedGrouping) Descriptor() ([]byte, []int) {
+	return file_jql_jql_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *RequestedGrouping) GetField() string {
+	if x != nil {
+		return x.Field
 	}
 	return ""
 }
@@ -1801,7 +2439,7 @@ type GroupBy struct {
 
 func (x *GroupBy) Reset() {
 	*x = GroupBy{}
-	mi := &file_jql_jql_proto_msgTypes[31]
+	mi := &file_jql_jql_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1813,7 +2451,7 @@ func (x *GroupBy) String() string {
 func (*GroupBy) ProtoMessage() {}
 
 func (x *GroupBy) ProtoReflect() protoreflect.Message {
-	mi := &file_jql_jql_proto_msgTypes[31]
+	mi := &file_jql_jql_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1826,7 +2464,7 @@ func (x *GroupBy) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GroupBy.ProtoReflect.Descriptor instead.
 func (*GroupBy) Descriptor() ([]byte, []int) {
-	return file_jql_jql_proto_rawDescGZIP(), []int{31}
+	return file_jql_jql_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *GroupBy) GetGroupings() []*RequestedGrouping {
@@ -1847,7 +2485,7 @@ type Grouping struct {
 
 func (x *Grouping) Reset() {
 	*x = Grouping{}
-	mi := &file_jql_jql_proto_msgTypes[32]
+	mi := &file_jql_jql_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1859,7 +2497,7 @@ func (x *Grouping) String() string {
 func (*Grouping) ProtoMessage() {}
 
 func (x *Grouping) ProtoReflect() protoreflect.Message {
-	mi := &file_jql_jql_proto_msgTypes[32]
+	mi := &file_jql_jql_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1872,7 +2510,7 @@ func (x *Grouping) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Grouping.ProtoReflect.Descriptor instead.
 func (*Grouping) Descriptor() ([]byte, []int) {
-	return file_jql_jql_proto_rawDescGZIP(), []int{32}
+	return file_jql_jql_proto_rawDescGZIP(), []int{42}
 }
 
 func (x *Grouping) GetField() string {
@@ -1927,138 +2565,209 @@ var file_jql_jql_proto_rawDesc = string([]byte{
 	0x74, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x76,
 	0x65, 0x72, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x65, 0x76, 0x65,
-	0x72, 0x73, 0x65, 0x22, 0x9e, 0x03, 0x0a, 0x06, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x18,
-	0x0a, 0x07, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x07, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6c, 0x75,
-	0x6d, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e,
-	0x12, 0x32, 0x0a, 0x0b, 0x65, 0x71, 0x75, 0x61, 0x6c, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x45, 0x71, 0x75, 0x61,
-	0x6c, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x48, 0x00, 0x52, 0x0a, 0x65, 0x71, 0x75, 0x61, 0x6c, 0x4d,
-	0x61, 0x74, 0x63, 0x68, 0x12, 0x3c, 0x0a, 0x0f, 0x6c, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x68, 0x61,
-	0x6e, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
-	0x6a, 0x71, 0x6c, 0x2e, 0x4c, 0x65, 0x73, 0x73, 0x54, 0x68, 0x61, 0x6e, 0x4d, 0x61, 0x74, 0x63,
-	0x68, 0x48, 0x00, 0x52, 0x0d, 0x6c, 0x65, 0x73, 0x73, 0x54, 0x68, 0x61, 0x6e, 0x4d, 0x61, 0x74,
-	0x63, 0x68, 0x12, 0x47, 0x0a, 0x13, 0x67, 0x72, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x5f, 0x74,
-	0x68, 0x61, 0x6e, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x15, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x65, 0x61, 0x74, 0x65, 0x72, 0x54, 0x68, 0x61,
-	0x6e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x48, 0x00, 0x52, 0x11, 0x67, 0x72, 0x65, 0x61, 0x74, 0x68,
-	0x65, 0x72, 0x54, 0x68, 0x61, 0x6e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x29, 0x0a, 0x08, 0x69,
-	0x6e, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e,
-	0x6a, 0x71, 0x6c, 0x2e, 0x49, 0x6e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x48, 0x00, 0x52, 0x07, 0x69,
-	0x6e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x3b, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69,
-	0x6e, 0x73, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
-	0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x73, 0x4d, 0x61, 0x74,
-	0x63, 0x68, 0x48, 0x00, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x73, 0x4d, 0x61,
-	0x74, 0x63, 0x68, 0x12, 0x36, 0x0a, 0x0d, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x74, 0x6f, 0x5f, 0x6d,
-	0x61, 0x74, 0x63, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6a, 0x71, 0x6c,
-	0x2e, 0x50, 0x61, 0x74, 0x68, 0x54, 0x6f, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x48, 0x00, 0x52, 0x0b,
-	0x70, 0x61, 0x74, 0x68, 0x54, 0x6f, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x42, 0x07, 0x0a, 0x05, 0x6d,
-	0x61, 0x74, 0x63, 0x68, 0x22, 0x34, 0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x27, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x73, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
-	0x52, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x73, 0x22, 0xdb, 0x01, 0x0a, 0x0f, 0x4c,
-	0x69, 0x73, 0x74, 0x52, 0x6f, 0x77, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14,
+	0x72, 0x73, 0x65, 0x22, 0x22, 0x0a, 0x0a, 0x52, 0x65, 0x67, 0x65, 0x78, 0x4d, 0x61, 0x74, 0x63,
+	0x68, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x23, 0x0a, 0x0b, 0x50, 0x72, 0x65, 0x66, 0x69,
+	0x78, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x89, 0x04, 0x0a,
+	0x06, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65, 0x67, 0x61, 0x74,
+	0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x65,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x32, 0x0a, 0x0b, 0x65, 0x71, 0x75,
+	0x61, 0x6c, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f,
+	0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x45, 0x71, 0x75, 0x61, 0x6c, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x48,
+	0x00, 0x52, 0x0a, 0x65, 0x71, 0x75, 0x61, 0x6c, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x3c, 0x0a,
+	0x0f, 0x6c, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x68, 0x61, 0x6e, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x4c, 0x65, 0x73,
+	0x73, 0x54, 0x68, 0x61, 0x6e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x48, 0x00, 0x52, 0x0d, 0x6c, 0x65,
+	0x73, 0x73, 0x54, 0x68, 0x61, 0x6e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x47, 0x0a, 0x13, 0x67,
+	0x72, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x5f, 0x74, 0x68, 0x61, 0x6e, 0x5f, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x47,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x72, 0x54, 0x68, 0x61, 0x6e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x48,
+	0x00, 0x52, 0x11, 0x67, 0x72, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x54, 0x68, 0x61, 0x6e, 0x4d,
+	0x61, 0x74, 0x63, 0x68, 0x12, 0x29, 0x0a, 0x08, 0x69, 0x6e, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x49, 0x6e, 0x4d,
+	0x61, 0x74, 0x63, 0x68, 0x48, 0x00, 0x52, 0x07, 0x69, 0x6e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12,
+	0x3b, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x73, 0x5f, 0x6d, 0x61, 0x74, 0x63,
+	0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x43, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x73, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x48, 0x00, 0x52, 0x0d, 0x63,
+	0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x73, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x36, 0x0a, 0x0d,
+	0x70, 0x61, 0x74, 0x68, 0x5f, 0x74, 0x6f, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x54, 0x6f,
+	0x4d, 0x61, 0x74, 0x63, 0x68, 0x48, 0x00, 0x52, 0x0b, 0x70, 0x61, 0x74, 0x68, 0x54, 0x6f, 0x4d,
+	0x61, 0x74, 0x63, 0x68, 0x12, 0x32, 0x0a, 0x0b, 0x72, 0x65, 0x67, 0x65, 0x78, 0x5f, 0x6d, 0x61,
+	0x74, 0x63, 0x68, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6a, 0x71, 0x6c, 0x2e,
+	0x52, 0x65, 0x67, 0x65, 0x78, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x48, 0x00, 0x52, 0x0a, 0x72, 0x65,
+	0x67, 0x65, 0x78, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x35, 0x0a, 0x0c, 0x70, 0x72, 0x65, 0x66,
+	0x69, 0x78, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x4d, 0x61, 0x74, 0x63, 0x68,
+	0x48, 0x00, 0x52, 0x0b, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x42,
+	0x07, 0x0a, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x22, 0x34, 0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x64,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x27, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x46, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x73, 0x22, 0xdb,
+	0x01, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x6f, 0x77, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x2e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x64,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6a,
+	0x71, 0x6c, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x63, 0x6f,
+	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65,
+	0x72, 0x5f, 0x62, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65,
+	0x72, 0x42, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x65, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x03, 0x64, 0x65, 0x63, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x12, 0x27, 0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x62, 0x79, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x42, 0x79, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x42, 0x79, 0x22, 0x9e, 0x02, 0x0a,
+	0x06, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x6a, 0x71, 0x6c, 0x2e,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
+	0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x18,
+	0x0a, 0x07, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x07, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x6f, 0x72, 0x65,
+	0x69, 0x67, 0x6e, 0x5f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79,
+	0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x69,
+	0x73, 0x70, 0x6c, 0x61, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x66, 0x6f,
+	0x72, 0x65, 0x69, 0x67, 0x6e, 0x5f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0d, 0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x54, 0x61, 0x62, 0x6c, 0x65,
+	0x73, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x22, 0x7d, 0x0a,
+	0x05, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74,
+	0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x74, 0x65, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x69, 0x73,
+	0x70, 0x6c, 0x61, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e,
+	0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x12, 0x1d, 0x0a,
+	0x0a, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x4c, 0x69, 0x6e, 0x6b, 0x22, 0x2b, 0x0a, 0x03,
+	0x52, 0x6f, 0x77, 0x12, 0x24, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0xc2, 0x01, 0x0a, 0x10, 0x4c, 0x69,
+	0x73, 0x74, 0x52, 0x6f, 0x77, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14,
 	0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74,
-	0x61, 0x62, 0x6c, 0x65, 0x12, 0x2e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x43,
-	0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x62, 0x79,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x79, 0x12,
-	0x10, 0x0a, 0x03, 0x64, 0x65, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x64, 0x65,
-	0x63, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d,
-	0x69, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12,
-	0x27, 0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x62, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x0c, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x42, 0x79, 0x52,
-	0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x42, 0x79, 0x22, 0xdb, 0x01, 0x0a, 0x06, 0x43, 0x6f, 0x6c,
-	0x75, 0x6d, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6d,
-	0x61, 0x78, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x09, 0x6d, 0x61, 0x78, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72,
-	0x69, 0x6d, 0x61, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x70, 0x72, 0x69,
-	0x6d, 0x61, 0x72, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x6f, 0x72, 0x65, 0x69, 0x67, 0x6e, 0x5f,
-	0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x66, 0x6f, 0x72,
-	0x65, 0x69, 0x67, 0x6e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x73, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61,
-	0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x7d, 0x0a, 0x05, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
-	0x1c, 0x0a, 0x09, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x74, 0x65, 0x64, 0x12, 0x23, 0x0a,
-	0x0d, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x56, 0x61, 0x6c,
-	0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x6c, 0x69, 0x6e, 0x6b, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f,
-	0x6c, 0x69, 0x6e, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x6f, 0x63, 0x61,
-	0x6c, 0x4c, 0x69, 0x6e, 0x6b, 0x22, 0x2b, 0x0a, 0x03, 0x52, 0x6f, 0x77, 0x12, 0x24, 0x0a, 0x07,
-	0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e,
-	0x6a, 0x71, 0x6c, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69,
-	0x65, 0x73, 0x22, 0xc2, 0x01, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x6f, 0x77, 0x73, 0x52,
+	0x61, 0x62, 0x6c, 0x65, 0x12, 0x25, 0x0a, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x43, 0x6f, 0x6c, 0x75,
+	0x6d, 0x6e, 0x52, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x12, 0x1c, 0x0a, 0x04, 0x72,
+	0x6f, 0x77, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x6a, 0x71, 0x6c, 0x2e,
+	0x52, 0x6f, 0x77, 0x52, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12,
+	0x10, 0x0a, 0x03, 0x61, 0x6c, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x61, 0x6c,
+	0x6c, 0x12, 0x2b, 0x0a, 0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x06,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x69, 0x6e, 0x67, 0x52, 0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x35,
+	0x0a, 0x0d, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x70, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x70, 0x6b, 0x22, 0x69, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x77, 0x52,
 	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x25, 0x0a,
 	0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b,
 	0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x52, 0x07, 0x63, 0x6f, 0x6c,
-	0x75, 0x6d, 0x6e, 0x73, 0x12, 0x1c, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x03, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x08, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x52, 0x6f, 0x77, 0x52, 0x04, 0x72, 0x6f,
-	0x77, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x6c, 0x6c, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x61, 0x6c, 0x6c, 0x12, 0x2b, 0x0a, 0x09, 0x67, 0x72,
-	0x6f, 0x75, 0x70, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
-	0x6a, 0x71, 0x6c, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x69, 0x6e, 0x67, 0x52, 0x09, 0x67, 0x72,
-	0x6f, 0x75, 0x70, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x35, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x52, 0x6f,
-	0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x0e,
-	0x0a, 0x02, 0x70, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x70, 0x6b, 0x22, 0x69,
-	0x0a, 0x0e, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x14, 0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x25, 0x0a, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e,
-	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x43, 0x6f,
-	0x6c, 0x75, 0x6d, 0x6e, 0x52, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x12, 0x1a, 0x0a,
-	0x03, 0x72, 0x6f, 0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x6a, 0x71, 0x6c,
-	0x2e, 0x52, 0x6f, 0x77, 0x52, 0x03, 0x72, 0x6f, 0x77, 0x22, 0xee, 0x01, 0x0a, 0x0f, 0x57, 0x72,
-	0x69, 0x74, 0x65, 0x52, 0x6f, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
+	0x75, 0x6d, 0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x03, 0x72, 0x6f, 0x77, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x08, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x52, 0x6f, 0x77, 0x52, 0x03, 0x72, 0x6f, 0x77,
+	0x22, 0xee, 0x01, 0x0a, 0x0f, 0x57, 0x72, 0x69, 0x74, 0x65, 0x52, 0x6f, 0x77, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x70, 0x6b,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x70, 0x6b, 0x12, 0x38, 0x0a, 0x06, 0x66, 0x69,
+	0x65, 0x6c, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6a, 0x71, 0x6c,
+	0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x52, 0x6f, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x66, 0x69,
+	0x65, 0x6c, 0x64, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x6f,
+	0x6e, 0x6c, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x4f, 0x6e, 0x6c, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x5f,
+	0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x6e, 0x73, 0x65,
+	0x72, 0x74, 0x4f, 0x6e, 0x6c, 0x79, 0x1a, 0x39, 0x0a, 0x0b, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0x22, 0x0a, 0x10, 0x57, 0x72, 0x69, 0x74, 0x65, 0x52, 0x6f, 0x77, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x70, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x70, 0x6b, 0x22, 0x6d, 0x0a, 0x15, 0x49, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74,
+	0x61, 0x62, 0x6c, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x70, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x70, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x16, 0x0a, 0x06,
+	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x22, 0x18, 0x0a, 0x16, 0x49, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x38,
+	0x0a, 0x10, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x6f, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x70, 0x6b, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x70, 0x6b, 0x22, 0x13, 0x0a, 0x11, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x52, 0x6f, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x10, 0x0a,
+	0x0e, 0x50, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22,
+	0x11, 0x0a, 0x0f, 0x50, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x14, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x31, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x22, 0x31, 0x0a, 0x13, 0x4c,
+	0x6f, 0x61, 0x64, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x22, 0x16,
+	0x0a, 0x14, 0x4c, 0x6f, 0x61, 0x64, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xd6, 0x01, 0x0a, 0x12, 0x4d, 0x69, 0x67, 0x72, 0x61,
+	0x74, 0x65, 0x45, 0x6e, 0x75, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
 	0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x61,
-	0x62, 0x6c, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x70, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x02, 0x70, 0x6b, 0x12, 0x38, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x03, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x52,
-	0x6f, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x1f, 0x0a,
-	0x0b, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4f, 0x6e, 0x6c, 0x79, 0x12, 0x1f,
-	0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x4f, 0x6e, 0x6c, 0x79, 0x1a,
-	0x39, 0x0a, 0x0b, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
-	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
-	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x12, 0x0a, 0x10, 0x57, 0x72,
-	0x69, 0x74, 0x65, 0x52, 0x6f, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x6d,
-	0x0a, 0x15, 0x49, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x0e, 0x0a,
-	0x02, 0x70, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x70, 0x6b, 0x12, 0x16, 0x0a,
-	0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63,
-	0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x18, 0x0a,
-	0x16, 0x49, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x38, 0x0a, 0x10, 0x44, 0x65, 0x6c, 0x65, 0x74,
-	0x65, 0x52, 0x6f, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74,
-	0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x61, 0x62, 0x6c,
-	0x65, 0x12, 0x0e, 0x0a, 0x02, 0x70, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x70,
-	0x6b, 0x22, 0x13, 0x0a, 0x11, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x6f, 0x77, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x10, 0x0a, 0x0e, 0x50, 0x65, 0x72, 0x73, 0x69, 0x73,
-	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x11, 0x0a, 0x0f, 0x50, 0x65, 0x72, 0x73,
-	0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x14, 0x0a, 0x12, 0x47,
-	0x65, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x22, 0x31, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x6e, 0x61, 0x70,
-	0x73, 0x68, 0x6f, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x73, 0x6e, 0x61, 0x70,
-	0x73, 0x68, 0x6f, 0x74, 0x22, 0x31, 0x0a, 0x13, 0x4c, 0x6f, 0x61, 0x64, 0x53, 0x6e, 0x61, 0x70,
-	0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73,
-	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x73,
-	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x22, 0x16, 0x0a, 0x14, 0x4c, 0x6f, 0x61, 0x64, 0x53,
-	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x62, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x73, 0x12, 0x3e, 0x0a, 0x07, 0x72, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x4d, 0x69, 0x67, 0x72, 0x61,
+	0x74, 0x65, 0x45, 0x6e, 0x75, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x52, 0x65,
+	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x72, 0x65, 0x6e, 0x61,
+	0x6d, 0x65, 0x73, 0x1a, 0x3a, 0x0a, 0x0c, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x31, 0x0a, 0x13, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x75, 0x6d, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x69, 0x67, 0x72, 0x61, 0x74,
+	0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6d, 0x69, 0x67, 0x72, 0x61, 0x74,
+	0x65, 0x64, 0x22, 0x0e, 0x0a, 0x0c, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x22, 0x53, 0x0a, 0x0a, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x69, 0x72, 0x74,
+	0x79, 0x5f, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x64, 0x69,
+	0x72, 0x74, 0x79, 0x52, 0x6f, 0x77, 0x73, 0x22, 0x9e, 0x01, 0x0a, 0x08, 0x52, 0x50, 0x43, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6d, 0x65,
+	0x61, 0x6e, 0x5f, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6d, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0d, 0x6d, 0x65, 0x61, 0x6e, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79,
+	0x4d, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63,
+	0x79, 0x5f, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x4c,
+	0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x73, 0x22, 0xc5, 0x01, 0x0a, 0x0d, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x06, 0x74, 0x61,
+	0x62, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6a, 0x71, 0x6c,
+	0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x06, 0x74, 0x61, 0x62,
+	0x6c, 0x65, 0x73, 0x12, 0x2f, 0x0a, 0x14, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x70, 0x65, 0x72, 0x73,
+	0x69, 0x73, 0x74, 0x5f, 0x75, 0x6e, 0x69, 0x78, 0x5f, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x11, 0x6c, 0x61, 0x73, 0x74, 0x50, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x55, 0x6e,
+	0x69, 0x78, 0x4d, 0x73, 0x12, 0x37, 0x0a, 0x18, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x70, 0x65, 0x72,
+	0x73, 0x69, 0x73, 0x74, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x15, 0x6c, 0x61, 0x73, 0x74, 0x50, 0x65, 0x72, 0x73,
+	0x69, 0x73, 0x74, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x12, 0x21, 0x0a,
+	0x04, 0x72, 0x70, 0x63, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6a, 0x71,
+	0x6c, 0x2e, 0x52, 0x50, 0x43, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x04, 0x72, 0x70, 0x63, 0x73,
+	0x22, 0x17, 0x0a, 0x15, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x74, 0x0a, 0x08, 0x4d, 0x75, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x70,
+	0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x70, 0x6b, 0x12, 0x10, 0x0a, 0x03, 0x72,
+	0x6f, 0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x72, 0x6f, 0x77, 0x12, 0x18, 0x0a,
+	0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
+	0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x79, 0x6e,
+	0x63, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x65, 0x73, 0x79, 0x6e, 0x63, 0x22,
 	0x45, 0x0a, 0x11, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x47, 0x72, 0x6f, 0x75,
 	0x70, 0x69, 0x6e, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65,
@@ -2078,7 +2787,7 @@ var file_jql_jql_proto_rawDesc = string([]byte{
 	0x75, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
 	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x3a, 0x02, 0x38, 0x01, 0x2a, 0x80, 0x01, 0x0a, 0x09, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x54, 0x79,
+	0x3a, 0x02, 0x38, 0x01, 0x2a, 0xb6, 0x01, 0x0a, 0x09, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x54, 0x79,
 	0x70, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x54, 0x52, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x07,
 	0x0a, 0x03, 0x49, 0x4e, 0x54, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x44, 0x41, 0x54, 0x45, 0x10,
 	0x02, 0x12, 0x08, 0x0a, 0x04, 0x45, 0x4e, 0x55, 0x4d, 0x10, 0x03, 0x12, 0x06, 0x0a, 0x02, 0x49,
@@ -2086,43 +2795,58 @@ var file_jql_jql_proto_rawDesc = string([]byte{
 	0x08, 0x4d, 0x4f, 0x4e, 0x45, 0x59, 0x41, 0x4d, 0x54, 0x10, 0x06, 0x12, 0x0b, 0x0a, 0x07, 0x46,
 	0x4f, 0x52, 0x45, 0x49, 0x47, 0x4e, 0x10, 0x07, 0x12, 0x0c, 0x0a, 0x08, 0x46, 0x4f, 0x52, 0x45,
 	0x49, 0x47, 0x4e, 0x53, 0x10, 0x08, 0x12, 0x0f, 0x0a, 0x0b, 0x50, 0x4f, 0x4c, 0x59, 0x46, 0x4f,
-	0x52, 0x45, 0x49, 0x47, 0x4e, 0x10, 0x09, 0x32, 0xad, 0x04, 0x0a, 0x03, 0x4a, 0x51, 0x4c, 0x12,
-	0x3d, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x16, 0x2e,
-	0x6a, 0x71, 0x6c, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x4c, 0x69, 0x73, 0x74,
-	0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37,
-	0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x6f, 0x77, 0x73, 0x12, 0x14, 0x2e, 0x6a, 0x71, 0x6c,
-	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x6f, 0x77, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x15, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x6f, 0x77, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x06, 0x47, 0x65, 0x74, 0x52, 0x6f,
-	0x77, 0x12, 0x12, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x77, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x47, 0x65, 0x74, 0x52,
-	0x6f, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x08, 0x57, 0x72,
-	0x69, 0x74, 0x65, 0x52, 0x6f, 0x77, 0x12, 0x14, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x57, 0x72, 0x69,
-	0x74, 0x65, 0x52, 0x6f, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x6a,
-	0x71, 0x6c, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x52, 0x6f, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x09, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x6f, 0x77,
-	0x12, 0x15, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x6f, 0x77,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x44, 0x65,
-	0x6c, 0x65, 0x74, 0x65, 0x52, 0x6f, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x49, 0x0a, 0x0e, 0x49, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x12, 0x1a, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x49, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e,
-	0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e,
-	0x6a, 0x71, 0x6c, 0x2e, 0x49, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x07, 0x50, 0x65,
-	0x72, 0x73, 0x69, 0x73, 0x74, 0x12, 0x13, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x50, 0x65, 0x72, 0x73,
-	0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x6a, 0x71, 0x6c,
-	0x2e, 0x50, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x40, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12,
-	0x17, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
-	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x47,
-	0x65, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x43, 0x0a, 0x0c, 0x4c, 0x6f, 0x61, 0x64, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
-	0x6f, 0x74, 0x12, 0x18, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x53, 0x6e, 0x61,
-	0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x6a,
-	0x71, 0x6c, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x0b, 0x5a, 0x09, 0x6a, 0x71, 0x6c, 0x2f, 0x6a,
-	0x71, 0x6c, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x45, 0x49, 0x47, 0x4e, 0x10, 0x09, 0x12, 0x08, 0x0a, 0x04, 0x42, 0x4f, 0x4f, 0x4c, 0x10,
+	0x0a, 0x12, 0x09, 0x0a, 0x05, 0x46, 0x4c, 0x4f, 0x41, 0x54, 0x10, 0x0b, 0x12, 0x0c, 0x0a, 0x08,
+	0x44, 0x55, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x0c, 0x12, 0x07, 0x0a, 0x03, 0x55, 0x52,
+	0x4c, 0x10, 0x0d, 0x12, 0x08, 0x0a, 0x04, 0x54, 0x41, 0x47, 0x53, 0x10, 0x0e, 0x32, 0xde, 0x05,
+	0x0a, 0x03, 0x4a, 0x51, 0x4c, 0x12, 0x3d, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x62,
+	0x6c, 0x65, 0x73, 0x12, 0x16, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61,
+	0x62, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x6a, 0x71,
+	0x6c, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x6f, 0x77, 0x73,
+	0x12, 0x14, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x6f, 0x77, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x52, 0x6f, 0x77, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a,
+	0x06, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x77, 0x12, 0x12, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x47, 0x65,
+	0x74, 0x52, 0x6f, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x6a, 0x71,
+	0x6c, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x37, 0x0a, 0x08, 0x57, 0x72, 0x69, 0x74, 0x65, 0x52, 0x6f, 0x77, 0x12, 0x14, 0x2e, 0x6a,
+	0x71, 0x6c, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x52, 0x6f, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x15, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x52, 0x6f,
+	0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x09, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x52, 0x6f, 0x77, 0x12, 0x15, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x52, 0x6f, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
+	0x6a, 0x71, 0x6c, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x6f, 0x77, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x0e, 0x49, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x1a, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x49, 0x6e,
+	0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x49, 0x6e, 0x63, 0x72, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x34, 0x0a, 0x07, 0x50, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x12, 0x13, 0x2e, 0x6a, 0x71,
+	0x6c, 0x2e, 0x50, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x14, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x50, 0x65, 0x72, 0x73, 0x69, 0x73, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x17, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x47, 0x65, 0x74, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18,
+	0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x0c, 0x4c, 0x6f, 0x61, 0x64,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x18, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x4c,
+	0x6f, 0x61, 0x64, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x19, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a,
+	0x0b, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x75, 0x6d, 0x12, 0x17, 0x2e, 0x6a,
+	0x71, 0x6c, 0x2e, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x75, 0x6d, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x4d, 0x69, 0x67, 0x72,
+	0x61, 0x74, 0x65, 0x45, 0x6e, 0x75, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x2e, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x11, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x6a, 0x71,
+	0x6c, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x3d, 0x0a, 0x0e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x1a, 0x2e, 0x6a, 0x71, 0x6c, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x75, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e,
+	0x6a, 0x71, 0x6c, 0x2e, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x30, 0x01, 0x42, 0x0b,
+	0x5a, 0x09, 0x6a, 0x71, 0x6c, 0x2f, 0x6a, 0x71, 0x6c, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 })
 
 var (
@@ -2138,7 +2862,7 @@ func file_jql_jql_proto_rawDescGZIP() []byte {
 }
 
 var file_jql_jql_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_jql_jql_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
+var file_jql_jql_proto_msgTypes = make([]protoimpl.MessageInfo, 46)
 var file_jql_jql_proto_goTypes = []any{
 	(EntryType)(0),                 // 0: jql.EntryType
 	(*ListTablesRequest)(nil),      // 1: jql.ListTablesRequest
@@ -2150,35 +2874,46 @@ var file_jql_jql_proto_goTypes = []any{
 	(*InMatch)(nil),                // 7: jql.InMatch
 	(*ContainsMatch)(nil),          // 8: jql.ContainsMatch
 	(*PathToMatch)(nil),            // 9: jql.PathToMatch
-	(*Filter)(nil),                 // 10: jql.Filter
-	(*Condition)(nil),              // 11: jql.Condition
-	(*ListRowsRequest)(nil),        // 12: jql.ListRowsRequest
-	(*Column)(nil),                 // 13: jql.Column
-	(*Entry)(nil),                  // 14: jql.Entry
-	(*Row)(nil),                    // 15: jql.Row
-	(*ListRowsResponse)(nil),       // 16: jql.ListRowsResponse
-	(*GetRowRequest)(nil),          // 17: jql.GetRowRequest
-	(*GetRowResponse)(nil),         // 18: jql.GetRowResponse
-	(*WriteRowRequest)(nil),        // 19: jql.WriteRowRequest
-	(*WriteRowResponse)(nil),       // 20: jql.WriteRowResponse
-	(*IncrementEntryRequest)(nil),  // 21: jql.IncrementEntryRequest
-	(*IncrementEntryResponse)(nil), // 22: jql.IncrementEntryResponse
-	(*DeleteRowRequest)(nil),       // 23: jql.DeleteRowRequest
-	(*DeleteRowResponse)(nil),      // 24: jql.DeleteRowResponse
-	(*PersistRequest)(nil),         // 25: jql.PersistRequest
-	(*PersistResponse)(nil),        // 26: jql.PersistResponse
-	(*GetSnapshotRequest)(nil),     // 27: jql.GetSnapshotRequest
-	(*GetSnapshotResponse)(nil),    // 28: jql.GetSnapshotResponse
-	(*LoadSnapshotRequest)(nil),    // 29: jql.LoadSnapshotRequest
-	(*LoadSnapshotResponse)(nil),   // 30: jql.LoadSnapshotResponse
-	(*RequestedGrouping)(nil),      // 31: jql.RequestedGrouping
-	(*GroupBy)(nil),                // 32: jql.GroupBy
-	(*Grouping)(nil),               // 33: jql.Grouping
-	nil,                            // 34: jql.WriteRowRequest.FieldsEntry
-	nil,                            // 35: jql.Grouping.ValuesEntry
+	(*RegexMatch)(nil),             // 10: jql.RegexMatch
+	(*PrefixMatch)(nil),            // 11: jql.PrefixMatch
+	(*Filter)(nil),                 // 12: jql.Filter
+	(*Condition)(nil),              // 13: jql.Condition
+	(*ListRowsRequest)(nil),        // 14: jql.ListRowsRequest
+	(*Column)(nil),                 // 15: jql.Column
+	(*Entry)(nil),                  // 16: jql.Entry
+	(*Row)(nil),                    // 17: jql.Row
+	(*ListRowsResponse)(nil),       // 18: jql.ListRowsResponse
+	(*GetRowRequest)(nil),          // 19: jql.GetRowRequest
+	(*GetRowResponse)(nil),         // 20: jql.GetRowResponse
+	(*WriteRowRequest)(nil),        // 21: jql.WriteRowRequest
+	(*WriteRowResponse)(nil),       // 22: jql.WriteRowResponse
+	(*IncrementEntryRequest)(nil),  // 23: jql.IncrementEntryRequest
+	(*IncrementEntryResponse)(nil), // 24: jql.IncrementEntryResponse
+	(*DeleteRowRequest)(nil),       // 25: jql.DeleteRowRequest
+	(*DeleteRowResponse)(nil),      // 26: jql.DeleteRowResponse
+	(*PersistRequest)(nil),         // 27: jql.PersistRequest
+	(*PersistResponse)(nil),        // 28: jql.PersistResponse
+	(*GetSnapshotRequest)(nil),     // 29: jql.GetSnapshotRequest
+	(*GetSnapshotResponse)(nil),    // 30: jql.GetSnapshotResponse
+	(*LoadSnapshotRequest)(nil),    // 31: jql.LoadSnapshotRequest
+	(*LoadSnapshotResponse)(nil),   // 32: jql.LoadSnapshotResponse
+	(*MigrateEnumRequest)(nil),     // 33: jql.MigrateEnumRequest
+	(*MigrateEnumResponse)(nil),    // 34: jql.MigrateEnumResponse
+	(*StatsRequest)(nil),           // 35: jql.StatsRequest
+	(*TableStats)(nil),             // 36: jql.TableStats
+	(*RPCStats)(nil),               // 37: jql.RPCStats
+	(*StatsResponse)(nil),          // 38: jql.StatsResponse
+	(*WatchMutationsRequest)(nil),  // 39: jql.WatchMutationsRequest
+	(*Mutation)(nil),               // 40: jql.Mutation
+	(*RequestedGrouping)(nil),      // 41: jql.RequestedGrouping
+	(*GroupBy)(nil),                // 42: jql.GroupBy
+	(*Grouping)(nil),               // 43: jql.Grouping
+	nil,                            // 44: jql.WriteRowRequest.FieldsEntry
+	nil,                            // 45: jql.MigrateEnumRequest.RenamesEntry
+	nil,                            // 46: jql.Grouping.ValuesEntry
 }
 var file_jql_jql_proto_depIdxs = []int32{
-	13, // 0: jql.TableMeta.columns:type_name -> jql.Column
+	15, // 0: jql.TableMeta.columns:type_name -> jql.Column
 	2,  // 1: jql.ListTablesResponse.tables:type_name -> jql.TableMeta
 	4,  // 2: jql.Filter.equal_match:type_name -> jql.EqualMatch
 	5,  // 3: jql.Filter.less_than_match:type_name -> jql.LessThanMatch
@@ -2186,42 +2921,53 @@ var file_jql_jql_proto_depIdxs = []int32{
 	7,  // 5: jql.Filter.in_match:type_name -> jql.InMatch
 	8,  // 6: jql.Filter.contains_match:type_name -> jql.ContainsMatch
 	9,  // 7: jql.Filter.path_to_match:type_name -> jql.PathToMatch
-	10, // 8: jql.Condition.requires:type_name -> jql.Filter
-	11, // 9: jql.ListRowsRequest.conditions:type_name -> jql.Condition
-	32, // 10: jql.ListRowsRequest.group_by:type_name -> jql.GroupBy
-	0,  // 11: jql.Column.type:type_name -> jql.EntryType
-	14, // 12: jql.Row.entries:type_name -> jql.Entry
-	13, // 13: jql.ListRowsResponse.columns:type_name -> jql.Column
-	15, // 14: jql.ListRowsResponse.rows:type_name -> jql.Row
-	33, // 15: jql.ListRowsResponse.groupings:type_name -> jql.Grouping
-	13, // 16: jql.GetRowResponse.columns:type_name -> jql.Column
-	15, // 17: jql.GetRowResponse.row:type_name -> jql.Row
-	34, // 18: jql.WriteRowRequest.fields:type_name -> jql.WriteRowRequest.FieldsEntry
-	31, // 19: jql.GroupBy.groupings:type_name -> jql.RequestedGrouping
-	35, // 20: jql.Grouping.values:type_name -> jql.Grouping.ValuesEntry
-	1,  // 21: jql.JQL.ListTables:input_type -> jql.ListTablesRequest
-	12, // 22: jql.JQL.ListRows:input_type -> jql.ListRowsRequest
-	17, // 23: jql.JQL.GetRow:input_type -> jql.GetRowRequest
-	19, // 24: jql.JQL.WriteRow:input_type -> jql.WriteRowRequest
-	23, // 25: jql.JQL.DeleteRow:input_type -> jql.DeleteRowRequest
-	21, // 26: jql.JQL.IncrementEntry:input_type -> jql.IncrementEntryRequest
-	25, // 27: jql.JQL.Persist:input_type -> jql.PersistRequest
-	27, // 28: jql.JQL.GetSnapshot:input_type -> jql.GetSnapshotRequest
-	29, // 29: jql.JQL.LoadSnapshot:input_type -> jql.LoadSnapshotRequest
-	3,  // 30: jql.JQL.ListTables:output_type -> jql.ListTablesResponse
-	16, // 31: jql.JQL.ListRows:output_type -> jql.ListRowsResponse
-	18, // 32: jql.JQL.GetRow:output_type -> jql.GetRowResponse
-	20, // 33: jql.JQL.WriteRow:output_type -> jql.WriteRowResponse
-	24, // 34: jql.JQL.DeleteRow:output_type -> jql.DeleteRowResponse
-	22, // 35: jql.JQL.IncrementEntry:output_type -> jql.IncrementEntryResponse
-	26, // 36: jql.JQL.Persist:output_type -> jql.PersistResponse
-	28, // 37: jql.JQL.GetSnapshot:output_type -> jql.GetSnapshotResponse
-	30, // 38: jql.JQL.LoadSnapshot:output_type -> jql.LoadSnapshotResponse
-	30, // [30:39] is the sub-list for method output_type
-	21, // [21:30] is the sub-list for method input_type
-	21, // [21:21] is the sub-list for extension type_name
-	21, // [21:21] is the sub-list for extension extendee
-	0,  // [0:21] is the sub-list for field type_name
+	10, // 8: jql.Filter.regex_match:type_name -> jql.RegexMatch
+	11, // 9: jql.Filter.prefix_match:type_name -> jql.PrefixMatch
+	12, // 10: jql.Condition.requires:type_name -> jql.Filter
+	13, // 11: jql.ListRowsRequest.conditions:type_name -> jql.Condition
+	42, // 12: jql.ListRowsRequest.group_by:type_name -> jql.GroupBy
+	0,  // 13: jql.Column.type:type_name -> jql.EntryType
+	16, // 14: jql.Row.entries:type_name -> jql.Entry
+	15, // 15: jql.ListRowsResponse.columns:type_name -> jql.Column
+	17, // 16: jql.ListRowsResponse.rows:type_name -> jql.Row
+	43, // 17: jql.ListRowsResponse.groupings:type_name -> jql.Grouping
+	15, // 18: jql.GetRowResponse.columns:type_name -> jql.Column
+	17, // 19: jql.GetRowResponse.row:type_name -> jql.Row
+	44, // 20: jql.WriteRowRequest.fields:type_name -> jql.WriteRowRequest.FieldsEntry
+	45, // 21: jql.MigrateEnumRequest.renames:type_name -> jql.MigrateEnumRequest.RenamesEntry
+	36, // 22: jql.StatsResponse.tables:type_name -> jql.TableStats
+	37, // 23: jql.StatsResponse.rpcs:type_name -> jql.RPCStats
+	41, // 24: jql.GroupBy.groupings:type_name -> jql.RequestedGrouping
+	46, // 25: jql.Grouping.values:type_name -> jql.Grouping.ValuesEntry
+	1,  // 26: jql.JQL.ListTables:input_type -> jql.ListTablesRequest
+	14, // 27: jql.JQL.ListRows:input_type -> jql.ListRowsRequest
+	19, // 28: jql.JQL.GetRow:input_type -> jql.GetRowRequest
+	21, // 29: jql.JQL.WriteRow:input_type -> jql.WriteRowRequest
+	25, // 30: jql.JQL.DeleteRow:input_type -> jql.DeleteRowRequest
+	23, // 31: jql.JQL.IncrementEntry:input_type -> jql.IncrementEntryRequest
+	27, // 32: jql.JQL.Persist:input_type -> jql.PersistRequest
+	29, // 33: jql.JQL.GetSnapshot:input_type -> jql.GetSnapshotRequest
+	31, // 34: jql.JQL.LoadSnapshot:input_type -> jql.LoadSnapshotRequest
+	33, // 35: jql.JQL.MigrateEnum:input_type -> jql.MigrateEnumRequest
+	35, // 36: jql.JQL.Stats:input_type -> jql.StatsRequest
+	39, // 37: jql.JQL.WatchMutations:input_type -> jql.WatchMutationsRequest
+	3,  // 38: jql.JQL.ListTables:output_type -> jql.ListTablesResponse
+	18, // 39: jql.JQL.ListRows:output_type -> jql.ListRowsResponse
+	20, // 40: jql.JQL.GetRow:output_type -> jql.GetRowResponse
+	22, // 41: jql.JQL.WriteRow:output_type -> jql.WriteRowResponse
+	26, // 42: jql.JQL.DeleteRow:output_type -> jql.DeleteRowResponse
+	24, // 43: jql.JQL.IncrementEntry:output_type -> jql.IncrementEntryResponse
+	28, // 44: jql.JQL.Persist:output_type -> jql.PersistResponse
+	30, // 45: jql.JQL.GetSnapshot:output_type -> jql.GetSnapshotResponse
+	32, // 46: jql.JQL.LoadSnapshot:output_type -> jql.LoadSnapshotResponse
+	34, // 47: jql.JQL.MigrateEnum:output_type -> jql.MigrateEnumResponse
+	38, // 48: jql.JQL.Stats:output_type -> jql.StatsResponse
+	40, // 49: jql.JQL.WatchMutations:output_type -> jql.Mutation
+	38, // [38:50] is the sub-list for method output_type
+	26, // [26:38] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
 }
 
 func init() { file_jql_jql_proto_init() }
@@ -2229,13 +2975,15 @@ func file_jql_jql_proto_init() {
 	if File_jql_jql_proto != nil {
 		return
 	}
-	file_jql_jql_proto_msgTypes[9].OneofWrappers = []any{
+	file_jql_jql_proto_msgTypes[11].OneofWrappers = []any{
 		(*Filter_EqualMatch)(nil),
 		(*Filter_LessThanMatch)(nil),
 		(*Filter_GreatherThanMatch)(nil),
 		(*Filter_InMatch)(nil),
 		(*Filter_ContainsMatch)(nil),
 		(*Filter_PathToMatch)(nil),
+		(*Filter_RegexMatch)(nil),
+		(*Filter_PrefixMatch)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -2243,7 +2991,7 @@ func file_jql_jql_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_jql_jql_proto_rawDesc), len(file_jql_jql_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   35,
+			NumMessages:   46,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -28,6 +28,9 @@ const (
 	JQL_Persist_FullMethodName        = "/jql.JQL/Persist"
 	JQL_GetSnapshot_FullMethodName    = "/jql.JQL/GetSnapshot"
 	JQL_LoadSnapshot_FullMethodName   = "/jql.JQL/LoadSnapshot"
+	JQL_MigrateEnum_FullMethodName    = "/jql.JQL/MigrateEnum"
+	JQL_Stats_FullMethodName          = "/jql.JQL/Stats"
+	JQL_WatchMutations_FullMethodName = "/jql.JQL/WatchMutations"
 )
 
 // JQLClient is the client API for JQL service.
@@ -43,6 +46,9 @@ type JQLClient interface {
 	Persist(ctx context.Context, in *PersistRequest, opts ...grpc.CallOption) (*PersistResponse, error)
 	GetSnapshot(ctx context.Context, in *GetSnapshotRequest, opts ...grpc.CallOption) (*GetSnapshotResponse, error)
 	LoadSnapshot(ctx context.Context, in *LoadSnapshotRequest, opts ...grpc.CallOption) (*LoadSnapshotResponse, error)
+	MigrateEnum(ctx context.Context, in *MigrateEnumRequest, opts ...grpc.CallOption) (*MigrateEnumResponse, error)
+	Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error)
+	WatchMutations(ctx context.Context, in *WatchMutationsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Mutation], error)
 }
 
 type jQLClient struct {
@@ -143,6 +149,45 @@ func (c *jQLClient) LoadSnapshot(ctx context.Context, in *LoadSnapshotRequest, o
 	return out, nil
 }
 
+func (c *jQLClient) MigrateEnum(ctx context.Context, in *MigrateEnumRequest, opts ...grpc.CallOption) (*MigrateEnumResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MigrateEnumResponse)
+	err := c.cc.Invoke(ctx, JQL_MigrateEnum_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jQLClient) Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatsResponse)
+	err := c.cc.Invoke(ctx, JQL_Stats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jQLClient) WatchMutations(ctx context.Context, in *WatchMutationsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Mutation], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &JQL_ServiceDesc.Streams[0], JQL_WatchMutations_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchMutationsRequest, Mutation]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type JQL_WatchMutationsClient = grpc.ServerStreamingClient[Mutation]
+
 // JQLServer is the server API for JQL service.
 // All implementations must embed UnimplementedJQLServer
 // for forward compatibility.
@@ -156,6 +201,9 @@ type JQLServer interface {
 	Persist(context.Context, *PersistRequest) (*PersistResponse, error)
 	GetSnapshot(context.Context, *GetSnapshotRequest) (*GetSnapshotResponse, error)
 	LoadSnapshot(context.Context, *LoadSnapshotRequest) (*LoadSnapshotResponse, error)
+	MigrateEnum(context.Context, *MigrateEnumRequest) (*MigrateEnumResponse, error)
+	Stats(context.Context, *StatsRequest) (*StatsResponse, error)
+	WatchMutations(*WatchMutationsRequest, grpc.ServerStreamingServer[Mutation]) error
 	mustEmbedUnimplementedJQLServer()
 }
 
@@ -193,6 +241,15 @@ func (UnimplementedJQLServer) GetSnapshot(context.Context, *GetSnapshotRequest)
 func (UnimplementedJQLServer) LoadSnapshot(context.Context, *LoadSnapshotRequest) (*LoadSnapshotResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method LoadSnapshot not implemented")
 }
+func (UnimplementedJQLServer) MigrateEnum(context.Context, *MigrateEnumRequest) (*MigrateEnumResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MigrateEnum not implemented")
+}
+func (UnimplementedJQLServer) Stats(context.Context, *StatsRequest) (*StatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stats not implemented")
+}
+func (UnimplementedJQLServer) WatchMutations(*WatchMutationsRequest, grpc.ServerStreamingServer[Mutation]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchMutations not implemented")
+}
 func (UnimplementedJQLServer) mustEmbedUnimplementedJQLServer() {}
 func (UnimplementedJQLServer) testEmbeddedByValue()             {}
 
@@ -376,6 +433,53 @@ func _JQL_LoadSnapshot_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _JQL_MigrateEnum_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MigrateEnumRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JQLServer).MigrateEnum(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JQL_MigrateEnum_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JQLServer).MigrateEnum(ctx, req.(*MigrateEnumRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JQL_Stats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JQLServer).Stats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JQL_Stats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JQLServer).Stats(ctx, req.(*StatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JQL_WatchMutations_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchMutationsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(JQLServer).WatchMutations(m, &grpc.GenericServerStream[WatchMutationsRequest, Mutation]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type JQL_WatchMutationsServer = grpc.ServerStreamingServer[Mutation]
+
 // JQL_ServiceDesc is the grpc.ServiceDesc for JQL service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -419,7 +523,21 @@ var JQL_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "LoadSnapshot",
 			Handler:    _JQL_LoadSnapshot_Handler,
 		},
+		{
+			MethodName: "MigrateEnum",
+			Handler:    _JQL_MigrateEnum_Handler,
+		},
+		{
+			MethodName: "Stats",
+			Handler:    _JQL_Stats_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchMutations",
+			Handler:       _JQL_WatchMutations_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "jql/jql.proto",
 }